@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	configDryRun           bool
+	configSetString        bool
+	configResolvedDefaults bool
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and edit service.yaml while keeping comments",
+	Long: `Reads and edits service.yaml through the YAML node tree, so comments and key
+order are kept. Every edit is validated before the file is written; an edit
+that introduces validation errors is rejected.
+
+Paths are dot separated:
+
+  build.builder_image                      mapping field
+  service.ports[0].port                    list index
+  plugins.items[name=agent].required       list item selected by field value
+  local_dev.compose.labels["app.tier"]     key containing dots`,
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <path>",
+	Short: "Print a value from service.yaml",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runConfigGet,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <path> <value>",
+	Short: "Set a value in service.yaml",
+	Long: `Sets a value, creating missing parent mappings. Values are parsed as YAML
+scalars or flow collections ("8080", "true", "[git, make]"); anything else,
+such as "@builders.org/go_1.23", is written as a string. A value replacing an
+existing string stays a string (language.version 1.20 keeps "1.20").`,
+	Example: `  svcgen config set build.builder_image @builders.org/go_1.23
+  svcgen config set service.ports[name=http].port 8081
+  svcgen config set local_dev.kubernetes.enabled true`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigSet,
+}
+
+var configAddCmd = &cobra.Command{
+	Use:   "add <path> <item>",
+	Short: "Append an item to a list in service.yaml",
+	Long: `Appends an item to a list, creating the list when missing. The item is either
+comma separated key=value pairs (a mapping), a flow collection ("{...}"), or a
+single value.`,
+	Example: `  svcgen config add service.ports name=grpc,port=9000,protocol=TCP
+  svcgen config add build.dependencies.system_pkgs git`,
+	Args: cobra.ExactArgs(2),
+	RunE: runConfigAdd,
+}
+
+var configResolvedCmd = &cobra.Command{
+	Use:   "resolved [path]",
+	Short: "Print the configuration after defaults are applied",
+	Long: `Prints the configuration as svcgen sees it after loading: schema migrations
+and defaults applied. Fields filled in by a default (rather than set in
+service.yaml) are marked with a "# default: ..." comment.`,
+	Example: `  svcgen config resolved
+  svcgen config resolved runtime.healthcheck
+  svcgen config resolved --defaults`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigResolved,
+}
+
+func init() {
+	configCmd.PersistentFlags().BoolVar(&configDryRun, "dry-run", false, "Print the edited file instead of writing it")
+	configSetCmd.Flags().BoolVar(&configSetString, "string", false, "Always write the value as a string")
+	configResolvedCmd.Flags().BoolVar(&configResolvedDefaults, "defaults", false, "Only list the fields filled in by defaults")
+
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configAddCmd)
+	configCmd.AddCommand(configResolvedCmd)
+}
+
+func runConfigGet(cmd *cobra.Command, args []string) error {
+	editor, _, err := openConfigEditor()
+	if err != nil {
+		return err
+	}
+	node, err := editor.Get(args[0])
+	if err != nil {
+		return err
+	}
+	value, err := config.FormatNode(node)
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
+
+func runConfigSet(cmd *cobra.Command, args []string) error {
+	editor, original, err := openConfigEditor()
+	if err != nil {
+		return err
+	}
+
+	value := config.ParseValue(args[1])
+	if configSetString {
+		value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: args[1]}
+	}
+	if err := editor.Set(args[0], value); err != nil {
+		return err
+	}
+	return writeEditedConfig(original, editor, fmt.Sprintf("Set %s", args[0]))
+}
+
+func runConfigAdd(cmd *cobra.Command, args []string) error {
+	editor, original, err := openConfigEditor()
+	if err != nil {
+		return err
+	}
+
+	item, err := config.ParseItem(args[1])
+	if err != nil {
+		return err
+	}
+	if err := editor.Add(args[0], item); err != nil {
+		return err
+	}
+	return writeEditedConfig(original, editor, fmt.Sprintf("Added an item to %s", args[0]))
+}
+
+func runConfigResolved(cmd *cobra.Command, args []string) error {
+	cfg, err := config.NewLoader(configFile).Load()
+	if err != nil {
+		return fmt.Errorf("failed to load configuration: %w", err)
+	}
+
+	if configResolvedDefaults {
+		for _, d := range cfg.Defaults {
+			fmt.Printf("%s = %v  # %s\n", d.Path, d.Value, d.Description)
+		}
+		return nil
+	}
+
+	data, err := config.MarshalResolved(cfg)
+	if err != nil {
+		return err
+	}
+	if len(args) == 0 {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	editor, err := config.NewEditor(data)
+	if err != nil {
+		return err
+	}
+	node, err := editor.Get(args[0])
+	if err != nil {
+		return err
+	}
+	value, err := config.FormatNode(node)
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
+
+// openConfigEditor 读取配置文件并创建编辑器
+func openConfigEditor() (*config.Editor, []byte, error) {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	editor, err := config.NewEditor(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", configFile, err)
+	}
+	return editor, data, nil
+}
+
+// writeEditedConfig 校验编辑结果后写回配置文件
+// 只拒绝本次编辑新引入的校验错误，原文件中已有的错误不阻止编辑（便于逐项修复）
+func writeEditedConfig(original []byte, editor *config.Editor, summary string) error {
+	edited := editor.Bytes()
+	loader := config.NewLoader(configFile)
+
+	cfg, err := loader.LoadBytes(edited)
+	if err != nil {
+		return fmt.Errorf("edit rejected: %w", err)
+	}
+	validator := config.NewValidator(cfg)
+	if validator.Validate() != nil {
+		existing := map[string]bool{}
+		if before, err := loader.LoadBytes(original); err == nil {
+			previous := config.NewValidator(before)
+			_ = previous.Validate()
+			for _, e := range previous.Errors() {
+				existing[e] = true
+			}
+		}
+		var introduced []string
+		for _, e := range validator.Errors() {
+			if !existing[e] {
+				introduced = append(introduced, "  - "+e)
+			}
+		}
+		if len(introduced) > 0 {
+			return fmt.Errorf("edit rejected, it would make the configuration invalid:\n%s", strings.Join(introduced, "\n"))
+		}
+	}
+
+	if configDryRun {
+		fmt.Print(string(edited))
+		return nil
+	}
+
+	info, err := os.Stat(configFile)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(configFile, edited, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	fmt.Printf("✓ %s in %s\n", summary, configFile)
+	return nil
+}
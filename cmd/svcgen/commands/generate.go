@@ -1,15 +1,22 @@
 package commands
 
 import (
+	"bytes"
+	gocontext "context"
 	"fmt"
+	"io/fs"
+	"path/filepath"
 
-	"github.com/junjiewwang/service-template/pkg/config"
-	"github.com/junjiewwang/service-template/pkg/generator"
+	"github.com/junjiewwang/service-template/pkg/generator/filewriter/strategies"
+	"github.com/junjiewwang/service-template/pkg/svcgen"
+	"github.com/junjiewwang/service-template/pkg/vfs"
 	"github.com/spf13/cobra"
 )
 
 var (
-	skipValidation bool
+	skipValidation          bool
+	generateStrictTemplates bool
+	generateDryRun          bool
 )
 
 var generateCmd = &cobra.Command{
@@ -21,34 +28,59 @@ var generateCmd = &cobra.Command{
 
 func init() {
 	generateCmd.Flags().BoolVar(&skipValidation, "skip-validation", false, "Skip configuration validation")
+	generateCmd.Flags().BoolVar(&generateStrictTemplates, "strict-templates", false, "Fail on templates that reference missing variables and on undefined ${VAR} references")
+	generateCmd.Flags().BoolVar(&generateDryRun, "dry-run", false, "Show which files would be created or updated without writing anything")
 }
 
 func runGenerate(cmd *cobra.Command, args []string) error {
 	fmt.Println("Loading configuration...")
 
 	// Load configuration
-	loader := config.NewLoader(configFile)
-	cfg, err := loader.Load()
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return err
 	}
 
-	// Validate configuration unless skipped
+	// Render all files in memory (validating the configuration unless skipped), then write them
 	if !skipValidation {
 		fmt.Println("Validating configuration...")
-		validator := config.NewValidator(cfg)
-		if err := validator.Validate(); err != nil {
-			return err
-		}
+	}
+	set, err := svcgen.Render(cmd.Context(), cfg, svcgen.Options{
+		OutputDir:       outputDir,
+		ProjectDir:      filepath.Dir(configFile),
+		SkipValidation:  skipValidation,
+		StrictTemplates: generateStrictTemplates,
+	})
+	if set != nil {
+		printDiagnostics(set.Warnings())
+	}
+	if err != nil {
+		return err
+	}
+	if !skipValidation {
 		fmt.Println("✓ Configuration is valid")
 	}
 
-	// Generate project files
+	if generateDryRun {
+		return dryRunGenerate(cmd.Context(), set)
+	}
+
 	fmt.Println("\nGenerating project files...")
-	gen := generator.NewGenerator(cfg, outputDir)
-	if err := gen.Generate(); err != nil {
+	if err := set.WriteTo(cmd.Context(), outputDir); err != nil {
 		return fmt.Errorf("generation failed: %w", err)
 	}
+	for _, artifact := range set.Artifacts {
+		switch artifact.Strategy {
+		case strategies.IncrementalStrategyID:
+			fmt.Printf("✓ Generated %s (incremental update)\n", artifact.Path)
+		case strategies.BlockStrategyID:
+			fmt.Printf("✓ Updated %s\n", artifact.Path)
+		case strategies.SkipStrategyID:
+			fmt.Printf("✓ Generated %s (kept if it exists)\n", artifact.Path)
+		default:
+			fmt.Printf("✓ Generated %s\n", artifact.Path)
+		}
+	}
 
 	fmt.Println("\n✓ All files generated successfully!")
 	fmt.Printf("\nOutput directory: %s\n", outputDir)
@@ -59,3 +91,56 @@ func runGenerate(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// dryRunGenerate writes the artifacts into memory on top of the output directory and reports
+// what a real run would change
+func dryRunGenerate(ctx gocontext.Context, set *svcgen.ArtifactSet) error {
+	disk := vfs.OS(outputDir)
+	changes := vfs.NewMem()
+	if err := set.WriteToFS(ctx, vfs.Overlay(changes, disk)); err != nil {
+		return fmt.Errorf("generation failed: %w", err)
+	}
+
+	fmt.Println("\nDry run, nothing is written:")
+	counts := map[string]int{}
+	for _, artifact := range set.Artifacts {
+		action := dryRunAction(changes, disk, artifact.Path)
+		counts[action]++
+		fmt.Printf("  %-9s %s\n", action, artifact.Path)
+	}
+	fmt.Printf("\n%d to create, %d to update, %d unchanged in %s\n",
+		counts["create"], counts["update"], counts["unchanged"], outputDir)
+	return nil
+}
+
+// dryRunAction compares a file written into changes with the file on disk
+func dryRunAction(changes, disk vfs.FS, name string) string {
+	updated, err := changes.ReadFile(name)
+	if err != nil {
+		// skip 策略下已存在的文件不会被写入
+		return "unchanged"
+	}
+	existing, err := disk.ReadFile(name)
+	if err != nil {
+		return "create"
+	}
+	if !bytes.Equal(updated, existing) || fileMode(changes, name) != fileMode(disk, name) {
+		return "update"
+	}
+	return "unchanged"
+}
+
+func fileMode(fsys fs.FS, name string) fs.FileMode {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return 0
+	}
+	return info.Mode().Perm()
+}
+
+// printDiagnostics prints non-fatal diagnostics
+func printDiagnostics(diagnostics []svcgen.Diagnostic) {
+	for _, d := range diagnostics {
+		fmt.Printf("⚠ %s\n", d)
+	}
+}
@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"text/tabwriter"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/generator/external"
+	"github.com/spf13/cobra"
+)
+
+var generatorsCmd = &cobra.Command{
+	Use:   "generators",
+	Short: "Manage external generators (svcgen-gen-* executables)",
+	Long: `External generators produce files that are not built into svcgen, like protoc
+plugins. svcgen runs every generator listed under generators.items (and, with
+generators.discover: true, every svcgen-gen-* executable on PATH), writes a JSON
+request with the resolved config, variables and paths to its stdin, and writes
+the files it returns on stdout together with the built-in outputs.`,
+}
+
+var generatorsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured external generators and svcgen-gen-* executables on PATH",
+	Args:  cobra.NoArgs,
+	RunE:  runGeneratorsList,
+}
+
+func init() {
+	generatorsCmd.AddCommand(generatorsListCmd)
+}
+
+func runGeneratorsList(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	configured := map[string]bool{}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSTATUS\tCOMMAND")
+	for _, gen := range external.Resolve(cfg.Generators) {
+		configured[gen.Name] = true
+		status := "enabled"
+		if gen.Discovered {
+			status = "enabled (discovered)"
+		}
+		command, err := exec.LookPath(gen.Command)
+		if err != nil {
+			status = "not found"
+			command = gen.Command
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", gen.Name, status, command)
+	}
+	for _, gen := range external.Discover(os.Getenv("PATH")) {
+		if !configured[gen.Name] {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", gen.Name, "available (not enabled)", gen.Command)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if cfg.Generators.IsEmpty() {
+		fmt.Printf("\nAdd a generator to generators.items, or set generators.discover: true to run every %s* executable on PATH.\n", config.ExternalGeneratorPrefix)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/images"
+	"github.com/spf13/cobra"
+)
+
+var (
+	lockPlainHTTP []string
+	lockTimeout   time.Duration
+)
+
+var imagesCmd = &cobra.Command{
+	Use:   "images",
+	Short: "Manage builder/runtime images",
+}
+
+var imagesLockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Pin all images to digests in images.lock.yaml",
+	Long: `Resolves every effective builder/runtime image (including language defaults)
+and all base_images presets to a manifest digest through the registry v2 API,
+and writes images.lock.yaml next to service.yaml.
+
+When images.lock.yaml exists, generate emits image@sha256:... references
+in devops.yaml and the Dockerfiles.`,
+	RunE: runImagesLock,
+}
+
+var imagesPresetsCmd = &cobra.Command{
+	Use:   "presets",
+	Short: "List available @builders.* / @runtimes.* presets",
+	Long: `Lists the builder and runtime presets available to service.yaml, including
+presets merged from base_images.imports, with their amd64/arm64 images.
+
+Without a service.yaml, all embedded catalogs are listed.`,
+	RunE: runImagesPresets,
+}
+
+func init() {
+	imagesLockCmd.Flags().StringSliceVar(&lockPlainHTTP, "plain-http", nil, "Registry hosts to access over plain HTTP (localhost is always plain HTTP)")
+	imagesLockCmd.Flags().DurationVar(&lockTimeout, "timeout", 2*time.Minute, "Timeout for resolving all digests")
+
+	imagesCmd.AddCommand(imagesLockCmd)
+	imagesCmd.AddCommand(imagesPresetsCmd)
+}
+
+func runImagesPresets(cmd *cobra.Command, args []string) error {
+	baseImages, err := presetSource()
+	if err != nil {
+		return err
+	}
+
+	printPresets("Builders", "@builders.", baseImages.ListBuilders(), baseImages.Builders)
+	fmt.Println()
+	printPresets("Runtimes", "@runtimes.", baseImages.ListRuntimes(), baseImages.Runtimes)
+	return nil
+}
+
+// presetSource 返回 service.yaml 中的 base_images；配置文件不存在时使用全部内置目录
+func presetSource() (*config.BaseImagesConfig, error) {
+	if _, err := os.Stat(configFile); errors.Is(err, fs.ErrNotExist) {
+		fmt.Printf("%s not found, listing embedded catalogs\n\n", configFile)
+		baseImages := &config.BaseImagesConfig{Imports: config.ListEmbeddedCatalogs()}
+		if err := baseImages.ResolveImports("."); err != nil {
+			return nil, err
+		}
+		return baseImages, nil
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &cfg.BaseImages, nil
+}
+
+func printPresets(title, prefix string, names []string, presets map[string]config.ArchImageConfig) {
+	fmt.Printf("%s:\n", title)
+	if len(names) == 0 {
+		fmt.Println("  (none)")
+		return
+	}
+	for _, name := range names {
+		images := presets[name]
+		fmt.Printf("  %s%s\n", prefix, name)
+		fmt.Printf("    amd64: %s\n", images.AMD64)
+		fmt.Printf("    arm64: %s\n", images.ARM64)
+	}
+}
+
+func runImagesLock(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	refs, err := config.LockableImages(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to resolve images: %w", err)
+	}
+	if len(refs) == 0 {
+		fmt.Println("No images to lock")
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), lockTimeout)
+	defer cancel()
+
+	client := images.NewRegistryClient(images.WithPlainHTTP(lockPlainHTTP...))
+	lock, err := images.Lock(ctx, client, refs)
+	if err != nil {
+		return err
+	}
+
+	lockPath := config.ImageLockPath(configFile)
+	if err := lock.Save(lockPath); err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		fmt.Printf("✓ %s → %s\n", ref, lock.Images[ref])
+	}
+	fmt.Printf("\n✓ Wrote %s (%d images)\n", lockPath, len(refs))
+	return nil
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/junjiewwang/service-template/pkg/importer"
+	"github.com/junjiewwang/service-template/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importDockerfile string
+	importCompose    string
+	importService    string
+	importName       string
+	importLanguage   string
+	importForce      bool
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create service.yaml from an existing Dockerfile and/or docker-compose.yaml",
+	Long: `Parses an existing Dockerfile and/or compose file and maps them onto service.yaml:
+
+  FROM                      → build.builder_image / build.runtime_image
+  EXPOSE, ports, expose     → service.ports
+  ENV                       → runtime.startup.env
+  environment               → local_dev.compose.environment
+  volumes, labels           → local_dev.compose.volumes / labels
+  deploy.resources          → local_dev.compose.resources
+  HEALTHCHECK, healthcheck  → runtime.healthcheck (+ local_dev.compose.healthcheck timings)
+  CMD / ENTRYPOINT, command → runtime.startup.command
+
+Constructs that cannot be mapped are listed in a report after the import.`,
+	RunE: runImport,
+}
+
+func init() {
+	flags := importCmd.Flags()
+	flags.StringVar(&importDockerfile, "from-dockerfile", "", "Path to the Dockerfile to import")
+	flags.StringVar(&importCompose, "from-compose", "", "Path to the compose file to import")
+	flags.StringVar(&importService, "service", "", "Compose service to import (required when the compose file has several services)")
+	flags.StringVar(&importName, "name", "", "Service name (default: compose service name or project directory name)")
+	flags.StringVar(&importLanguage, "language", "", "Language type (default: detected from the project or the builder image)")
+	flags.BoolVar(&importForce, "force", false, "Overwrite an existing configuration file")
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	if importDockerfile == "" && importCompose == "" {
+		return fmt.Errorf("specify --from-dockerfile and/or --from-compose")
+	}
+	if utils.FileExists(configFile) && !importForce {
+		return fmt.Errorf("configuration file %s already exists (use --force to overwrite)", configFile)
+	}
+
+	result, err := importer.Import(importer.Options{
+		DockerfilePath: importDockerfile,
+		ComposePath:    importCompose,
+		ComposeService: importService,
+		Name:           importName,
+		Language:       importLanguage,
+		ProjectDir:     filepath.Dir(configFile),
+	})
+	if err != nil {
+		return err
+	}
+
+	var sources []string
+	for _, source := range []string{importDockerfile, importCompose} {
+		if source != "" {
+			sources = append(sources, filepath.Base(source))
+		}
+	}
+	content, err := result.Render(sources...)
+	if err != nil {
+		return err
+	}
+	if err := utils.WriteFile(configFile, content); err != nil {
+		return fmt.Errorf("failed to write configuration file: %w", err)
+	}
+
+	fmt.Printf("✓ Imported %s (%s) into %s\n", result.Config.Service.Name, result.Config.Language.Type, configFile)
+
+	if len(result.Unmapped) > 0 {
+		fmt.Printf("\nCould not map %d construct(s):\n", len(result.Unmapped))
+		for _, finding := range result.Unmapped {
+			fmt.Printf("  - %s\n", finding)
+		}
+	}
+
+	// 导入结果写入后再校验，便于用户在文件中直接修正
+	if err := validateGeneratedConfig(content); err != nil {
+		fmt.Printf("\n⚠ %v\n", err)
+		return nil
+	}
+	fmt.Println("\n✓ Imported configuration is valid; run 'svcgen generate' after reviewing it")
+	return nil
+}
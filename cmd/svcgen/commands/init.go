@@ -2,20 +2,68 @@ package commands
 
 import (
 	_ "embed"
+	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/generator/context"
+	"github.com/junjiewwang/service-template/pkg/generator/domain/services/languageservice"
+	"github.com/junjiewwang/service-template/pkg/project"
 	"github.com/junjiewwang/service-template/pkg/utils"
+	"github.com/junjiewwang/service-template/pkg/wizard"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 //go:embed templates/service.example.yaml
 var serviceYamlExample string
 
+var (
+	initExample     bool
+	initInteractive bool
+	initAnswersFile string
+	// 向导答案（非 TTY 时与 --answers 文件一起作为答案，交互时作为默认值）
+	initAnswers    wizard.Answers
+	initPorts      string
+	initKubernetes bool
+)
+
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Initialize a new service.yaml configuration file",
-	Long:  `Creates a new service.yaml configuration file with example values.`,
-	RunE:  runInit,
+	Long: `Creates a new service.yaml configuration file.
+
+The project next to the configuration file is inspected (go.mod, package.json,
+pyproject.toml / requirements.txt, pom.xml / build.gradle, Cargo.toml, Dockerfile
+EXPOSE lines and compose ports) to prefill the language, version, build command,
+startup command and ports. When no project is detected, or with --example, the
+full example configuration is written instead.
+
+With --interactive, a wizard asks for the service name, language, ports, image
+format, plugins, healthcheck and Kubernetes settings, validating each answer.
+When stdin is not a terminal, or --answers is given, the wizard does not prompt
+and takes its answers from the answers file and flags instead:
+
+  svcgen init --interactive --name demo --language go --ports http:8080
+  svcgen init --answers answers.yaml`,
+	RunE: runInit,
+}
+
+func init() {
+	flags := initCmd.Flags()
+	flags.BoolVar(&initExample, "example", false, "Write the full example configuration instead of detecting the project")
+	flags.BoolVarP(&initInteractive, "interactive", "i", false, "Run the configuration wizard")
+	flags.StringVar(&initAnswersFile, "answers", "", "Wizard answers file (YAML); implies --interactive without prompting")
+	flags.StringVar(&initAnswers.Name, "name", "", "Wizard: service name")
+	flags.StringVar(&initAnswers.Language, "language", "", "Wizard: language type")
+	flags.StringVar(&initAnswers.Version, "language-version", "", "Wizard: language version")
+	flags.StringVar(&initPorts, "ports", "", "Wizard: ports as name:port[/protocol], comma separated")
+	flags.StringVar(&initAnswers.Image.Format, "image-format", "", "Wizard: image format (auto, direct, preset, per-arch)")
+	flags.StringVar(&initAnswers.Healthcheck.Type, "healthcheck", "", "Wizard: healthcheck type (default, http, tcp, binary, none)")
+	flags.BoolVar(&initKubernetes, "kubernetes", false, "Wizard: enable Kubernetes manifests for local development")
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -24,8 +72,27 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("configuration file %s already exists", configFile)
 	}
 
-	// Write example configuration
-	if err := utils.WriteFile(configFile, getServiceYamlExample()); err != nil {
+	content := getServiceYamlExample()
+	switch {
+	case initInteractive || initAnswersFile != "":
+		generated, err := runWizard(cmd)
+		if err != nil {
+			return err
+		}
+		content = generated
+	case !initExample:
+		detected, err := detectServiceYaml(filepath.Dir(configFile))
+		switch {
+		case errors.Is(err, project.ErrNotDetected):
+			fmt.Println("No project detected, writing the example configuration")
+		case err != nil:
+			return err
+		default:
+			content = detected
+		}
+	}
+
+	if err := utils.WriteFile(configFile, content); err != nil {
 		return fmt.Errorf("failed to create configuration file: %w", err)
 	}
 
@@ -38,6 +105,140 @@ func runInit(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// detectServiceYaml 检测项目并生成预填充的 service.yaml，写入前确保配置可以通过校验
+func detectServiceYaml(dir string) (string, error) {
+	p, err := project.DetectDir(dir)
+	if err != nil {
+		return "", err
+	}
+
+	// 未检测到构建命令时复用语言策略的默认构建命令（会读取 main_package / build_tool 等配置）
+	if p.BuildCommand == "" {
+		cfg := &config.ServiceConfig{Language: p.LanguageSettings()}
+		p.BuildCommand = languageservice.NewLanguageService(context.NewGeneratorContext(cfg, dir)).
+			GetDefaultBuildCommand(p.Language)
+	}
+
+	content, err := p.Render()
+	if err != nil {
+		return "", err
+	}
+	if err := validateGeneratedConfig(content); err != nil {
+		return "", err
+	}
+
+	printDetectedProject(p)
+	return content, nil
+}
+
+// runWizard 运行配置向导
+// stdin 为终端且未指定 --answers 时逐项提问，否则使用 --answers 文件与命令行参数作为答案
+func runWizard(cmd *cobra.Command) (string, error) {
+	answers := &wizard.Answers{}
+	if initAnswersFile != "" {
+		loaded, err := wizard.LoadAnswers(initAnswersFile)
+		if err != nil {
+			return "", err
+		}
+		answers = loaded
+	}
+	if err := applyWizardFlags(cmd, answers); err != nil {
+		return "", err
+	}
+
+	// 检测到的项目信息作为缺省答案
+	if p, err := project.DetectDir(filepath.Dir(configFile)); err == nil {
+		if answers.Name == "" {
+			answers.Name = p.Name
+		}
+		if answers.Language == "" {
+			answers.Language, answers.Version = p.Language, p.Version
+		}
+		if len(answers.Ports) == 0 {
+			for _, port := range p.Ports {
+				answers.Ports = append(answers.Ports, config.PortConfig{Name: port.Name, Port: port.Port, Protocol: port.Protocol, Expose: true})
+			}
+		}
+	}
+
+	interactive := initAnswersFile == "" && isTerminal(os.Stdin)
+	languages := languageservice.NewLanguageService(nil).ListSupportedLanguages()
+	result, err := wizard.New(os.Stdin, os.Stdout).
+		WithInteractive(interactive).
+		WithLanguages(languages).
+		WithAnswers(answers).
+		Run()
+	if err != nil {
+		return "", err
+	}
+
+	content, err := result.Render()
+	if err != nil {
+		return "", err
+	}
+	if err := validateGeneratedConfig(content); err != nil {
+		return "", err
+	}
+	return content, nil
+}
+
+// applyWizardFlags 将显式指定的命令行参数覆盖到答案上
+func applyWizardFlags(cmd *cobra.Command, answers *wizard.Answers) error {
+	flags := cmd.Flags()
+	overrides := map[string]func(){
+		"name":             func() { answers.Name = initAnswers.Name },
+		"language":         func() { answers.Language = initAnswers.Language },
+		"language-version": func() { answers.Version = initAnswers.Version },
+		"image-format":     func() { answers.Image.Format = initAnswers.Image.Format },
+		"healthcheck":      func() { answers.Healthcheck.Type = initAnswers.Healthcheck.Type },
+		"kubernetes":       func() { answers.Kubernetes.Enabled = initKubernetes },
+	}
+	for name, apply := range overrides {
+		if flags.Changed(name) {
+			apply()
+		}
+	}
+
+	if flags.Changed("ports") {
+		ports, err := wizard.ParsePorts(initPorts)
+		if err != nil {
+			return err
+		}
+		answers.Ports = ports
+	}
+	return nil
+}
+
+// validateGeneratedConfig 写入前确保生成的配置可以通过加载与校验
+func validateGeneratedConfig(content string) error {
+	cfg, err := config.LoadFromBytes([]byte(content))
+	if err != nil {
+		return fmt.Errorf("generated configuration is invalid: %w", err)
+	}
+	if err := config.NewValidator(cfg).Validate(); err != nil {
+		return fmt.Errorf("generated configuration is invalid: %w", err)
+	}
+	return nil
+}
+
+// isTerminal 判断 f 是否为交互式终端（/dev/null 等字符设备不算）
+func isTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+func printDetectedProject(p *project.Project) {
+	fmt.Printf("Detected %s project '%s' (from %s)\n", p.Language, p.Name, strings.Join(p.Evidence, ", "))
+	if p.Version != "" {
+		fmt.Printf("  Version: %s\n", p.Version)
+	}
+	if len(p.Binaries) > 0 {
+		fmt.Printf("  Binaries: %s\n", strings.Join(p.Binaries, ", "))
+	}
+	for _, port := range p.Ports {
+		fmt.Printf("  Port: %s %d/%s\n", port.Name, port.Port, port.Protocol)
+	}
+}
+
 func getServiceYamlExample() string {
 	if serviceYamlExample != "" {
 		return serviceYamlExample
@@ -83,7 +284,6 @@ build:
     build: |
       cd ${SERVICE_ROOT}
       go build -o ${BUILD_OUTPUT_DIR}/bin/${SERVICE_NAME} ./cmd/server
-  output_dir: dist
 
 runtime:
   healthcheck:
@@ -103,7 +303,7 @@ local_dev:
     output_dir: k8s-manifests
 
 metadata:
-  template_version: "2.0.0"
+  template_version: "2.1.0"
   generator: "svcgen"
 `
 }
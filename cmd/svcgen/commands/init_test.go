@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsTerminal_CharacterDevice(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	defer devNull.Close()
+	assert.False(t, isTerminal(devNull), "/dev/null is a character device but not a terminal")
+
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+	assert.False(t, isTerminal(r))
+}
+
+func TestRunWizard_NonTerminalStdin(t *testing.T) {
+	devNull, err := os.Open(os.DevNull)
+	require.NoError(t, err)
+	defer devNull.Close()
+
+	stdin, stdout, file := os.Stdin, os.Stdout, configFile
+	defer func() { os.Stdin, os.Stdout, configFile = stdin, stdout, file }()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdin, os.Stdout = devNull, w
+	configFile = filepath.Join(t.TempDir(), "service.yaml")
+
+	require.NoError(t, initCmd.Flags().Set("name", "demo"))
+	defer func() { initAnswers.Name = "" }()
+
+	content, err := runWizard(initCmd)
+	require.NoError(t, w.Close())
+	output, readErr := io.ReadAll(r)
+	require.NoError(t, readErr)
+
+	require.NoError(t, err)
+	assert.Contains(t, content, "name: demo")
+	assert.NotContains(t, string(output), "Service name", "non-terminal stdin must not prompt")
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var migrateWrite bool
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade service.yaml to the current schema version",
+	Long: `Detects the schema version of service.yaml (metadata.template_version) and
+applies the registered migrations up to the current version, reporting every
+rewritten or dropped field.
+
+Without --write the file is left untouched. With --write it is rewritten in
+place; comments and field order are preserved.
+
+Other commands apply the same migrations in memory when loading an older file.`,
+	RunE: runMigrate,
+}
+
+func init() {
+	migrateCmd.Flags().BoolVar(&migrateWrite, "write", false, "Rewrite the configuration file in place")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	result, err := config.Migrate(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", configFile, err)
+	}
+	if !result.Changed() {
+		fmt.Printf("✓ %s is already at schema version %s\n", configFile, result.ToVersion)
+		return nil
+	}
+
+	fmt.Printf("%s: schema version %s → %s\n", configFile, result.FromVersion, result.ToVersion)
+	for _, m := range result.Applied {
+		fmt.Printf("  %s → %s: %s\n", m.From, m.To, m.Description)
+	}
+	if len(result.Diagnostics) > 0 {
+		fmt.Println()
+		for _, diag := range result.Diagnostics {
+			fmt.Printf("  - %s\n", diag)
+		}
+	}
+
+	if !migrateWrite {
+		fmt.Println("\nRun with --write to update the file")
+		return nil
+	}
+
+	info, err := os.Stat(configFile)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(configFile, result.Content, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	fmt.Printf("\n✓ Updated %s\n", configFile)
+
+	if err := validateGeneratedConfig(string(result.Content)); err != nil {
+		fmt.Printf("\n⚠ %v\n", err)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/wizard"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	pluginURL            string
+	pluginURLAMD64       string
+	pluginURLARM64       string
+	pluginDescription    string
+	pluginInstallCommand string
+	pluginInstallDir     string
+	pluginRequired       bool
+	pluginEnv            []string
+)
+
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Manage plugins in service.yaml",
+}
+
+var pluginAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a plugin to plugins.items",
+	Long: `Appends a plugin to plugins.items while keeping the comments in service.yaml.
+plugins.install_dir is set when missing (default /plugins) or when --install-dir
+is given. The result is validated before the file is written.`,
+	Example: `  svcgen plugin add selfMonitor --url https://example.com/selfMonitor.sh --required
+  svcgen plugin add agent --url-amd64 https://example.com/agent-x86.tgz --url-arm64 https://example.com/agent-arm.tgz \
+    --env AGENT_HOME=/plugins/agent`,
+	Args: cobra.ExactArgs(1),
+	RunE: runPluginAdd,
+}
+
+func init() {
+	flags := pluginAddCmd.Flags()
+	flags.StringVar(&pluginURL, "url", "", "Download URL (same for all architectures)")
+	flags.StringVar(&pluginURLAMD64, "url-amd64", "", "Download URL for amd64 (with --url-arm64)")
+	flags.StringVar(&pluginURLARM64, "url-arm64", "", "Download URL for arm64 (with --url-amd64)")
+	flags.StringVar(&pluginDescription, "description", "", "Plugin description")
+	flags.StringVar(&pluginInstallCommand, "install-command", wizard.DefaultPluginInstallCommand, "Install command")
+	flags.StringVar(&pluginInstallDir, "install-dir", "", "Set plugins.install_dir (shared by all plugins)")
+	flags.BoolVar(&pluginRequired, "required", false, "Fail the build when the plugin cannot be installed")
+	flags.StringArrayVar(&pluginEnv, "env", nil, "Runtime environment variable NAME=VALUE (repeatable)")
+	pluginAddCmd.Flags().SortFlags = false
+
+	pluginCmd.AddCommand(pluginAddCmd)
+}
+
+func runPluginAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	plugin := config.PluginConfig{
+		Name:           name,
+		Description:    pluginDescription,
+		InstallCommand: pluginInstallCommand,
+		Required:       pluginRequired,
+	}
+
+	switch {
+	case pluginURL != "" && (pluginURLAMD64 != "" || pluginURLARM64 != ""):
+		return fmt.Errorf("use either --url or --url-amd64/--url-arm64")
+	case pluginURL != "":
+		plugin.DownloadURL = config.NewStaticDownloadURL(pluginURL)
+	case pluginURLAMD64 != "" && pluginURLARM64 != "":
+		plugin.DownloadURL = config.NewArchMappingDownloadURL(map[string]string{
+			"x86_64":  pluginURLAMD64,
+			"aarch64": pluginURLARM64,
+		})
+	default:
+		return fmt.Errorf("a download URL is required: --url, or both --url-amd64 and --url-arm64")
+	}
+
+	for _, env := range pluginEnv {
+		key, value, ok := strings.Cut(env, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --env %q: expected NAME=VALUE", env)
+		}
+		plugin.RuntimeEnv = append(plugin.RuntimeEnv, config.EnvironmentVariable{Name: key, Value: value})
+	}
+
+	editor, original, err := openConfigEditor()
+	if err != nil {
+		return err
+	}
+	if _, err := editor.Get(fmt.Sprintf("plugins.items[name=%s]", name)); err == nil {
+		return fmt.Errorf("plugin %s already exists in plugins.items", name)
+	}
+
+	var item yaml.Node
+	if err := item.Encode(&plugin); err != nil {
+		return err
+	}
+	if plugin.Description == "" {
+		dropMappingKey(&item, "description")
+	}
+	if err := editor.Add("plugins.items", &item); err != nil {
+		return err
+	}
+
+	if pluginInstallDir != "" {
+		if err := editor.Set("plugins.install_dir", &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: pluginInstallDir}); err != nil {
+			return err
+		}
+	} else if dir, err := editor.Get("plugins.install_dir"); err != nil || dir.Value == "" {
+		if err := editor.Set("plugins.install_dir", config.ParseValue(wizard.DefaultPluginInstallDir)); err != nil {
+			return err
+		}
+	}
+
+	return writeEditedConfig(original, editor, fmt.Sprintf("Added plugin %s", name))
+}
+
+// dropMappingKey 删除 mapping 节点中的字段
+func dropMappingKey(m *yaml.Node, key string) {
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			return
+		}
+	}
+}
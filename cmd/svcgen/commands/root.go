@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/spf13/cobra"
 )
 
 var (
-	configFile string
-	outputDir  string
+	configFile    string
+	outputDir     string
+	mirrorProfile string
+	templatesDir  string
 )
 
 var rootCmd = &cobra.Command{
@@ -18,7 +21,8 @@ var rootCmd = &cobra.Command{
 	Long: `Service Template Generator - A configuration-driven tool to generate 
 service templates with Docker, Kubernetes, and CI/CD configurations.
 
-All templates are embedded in the binary, no external template files needed.`,
+All templates are embedded in the binary, no external template files needed.
+Individual templates can be overridden with templates.override_dir or --templates.`,
 }
 
 // Execute runs the root command
@@ -36,14 +40,51 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&configFile, "config", "c", "service.yaml", "Path to service.yaml configuration file")
 	rootCmd.PersistentFlags().StringVarP(&outputDir, "output", "o", cwd, "Output directory for generated files")
+	rootCmd.PersistentFlags().StringVar(&mirrorProfile, "mirror-profile", "", "Override registry_mirrors.profile (use 'none' to disable mirror rewriting)")
+	rootCmd.PersistentFlags().StringVar(&templatesDir, "templates", "", "Template override directory (overrides templates.override_dir)")
 
 	// Add subcommands
 	rootCmd.AddCommand(initCmd)
+	rootCmd.AddCommand(importCmd)
 	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(pluginCmd)
 	rootCmd.AddCommand(generateCmd)
+	rootCmd.AddCommand(imagesCmd)
+	rootCmd.AddCommand(templatesCmd)
+	rootCmd.AddCommand(generatorsCmd)
+	rootCmd.AddCommand(varsCmd)
+	rootCmd.AddCommand(renderCmd)
 	rootCmd.AddCommand(versionCmd)
 }
 
+// loadConfig loads service.yaml and applies global flag overrides
+func loadConfig() (*config.ServiceConfig, error) {
+	cfg, err := config.NewLoader(configFile).Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	if mirrorProfile != "" {
+		cfg.RegistryMirrors.Profile = mirrorProfile
+	}
+	if templatesDir != "" {
+		cfg.Templates.OverrideDir = templatesDir
+	}
+	if m := cfg.Migration; m != nil && len(m.Diagnostics) > 0 {
+		fmt.Printf("⚠ %s uses schema %s and was migrated to %s in memory (%d change(s)); run 'svcgen migrate --write' to update it\n",
+			configFile, m.FromVersion, m.ToVersion, len(m.Diagnostics))
+	}
+	return cfg, nil
+}
+
+// printWarnings prints non-fatal validation findings
+func printWarnings(warnings []string) {
+	for _, warning := range warnings {
+		fmt.Printf("⚠ %s\n", warning)
+	}
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print version information",
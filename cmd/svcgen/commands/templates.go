@@ -0,0 +1,137 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/generator/core"
+	"github.com/spf13/cobra"
+)
+
+// defaultTemplatesDir templates export 在未配置 templates.override_dir 时的默认目录
+const defaultTemplatesDir = ".svcgen/templates"
+
+var (
+	templatesExportDir   string
+	templatesExportForce bool
+)
+
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List and export the embedded generator templates",
+	Long: `Every generated file comes from an embedded template. A file in
+templates.override_dir (or --templates) at the same relative path replaces the
+embedded template; a file that only contains {{ define "name" }} blocks
+replaces just those blocks, e.g. "runtime_extra" in docker/dockerfile/dockerfile_.tmpl.`,
+}
+
+var templatesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List embedded templates, their named blocks and which ones are overridden",
+	Args:  cobra.NoArgs,
+	RunE:  runTemplatesList,
+}
+
+var templatesExportCmd = &cobra.Command{
+	Use:   "export [template...]",
+	Short: "Write embedded templates to the override directory as a starting point",
+	Long: `Writes the embedded templates (all, or the given paths) to the override
+directory. Exported files start with a header recording the embedded template
+version, so svcgen can warn when an override is based on an older template.`,
+	Example: `  svcgen templates export docker/dockerfile/dockerfile_.tmpl
+  svcgen templates export --dir build/templates`,
+	RunE: runTemplatesExport,
+}
+
+func init() {
+	templatesExportCmd.Flags().StringVar(&templatesExportDir, "dir", "", "Target directory (default: --templates, templates.override_dir or "+defaultTemplatesDir+")")
+	templatesExportCmd.Flags().BoolVar(&templatesExportForce, "force", false, "Overwrite existing files")
+
+	templatesCmd.AddCommand(templatesListCmd)
+	templatesCmd.AddCommand(templatesExportCmd)
+}
+
+func runTemplatesList(cmd *cobra.Command, args []string) error {
+	dir := templateOverrideDir()
+	for _, path := range core.TemplatePaths() {
+		override, err := core.LoadTemplateOverride(dir, path)
+		if err != nil {
+			return err
+		}
+		line := path
+		if override != "" {
+			line += fmt.Sprintf(" (overridden in %s)", dir)
+		}
+		blocks, err := core.TemplateBlocks(path)
+		if err != nil {
+			return err
+		}
+		if len(blocks) > 0 {
+			line += fmt.Sprintf("\n    blocks: %s", strings.Join(blocks, ", "))
+		}
+		fmt.Println(line)
+	}
+	if partials := core.PartialNames(); len(partials) > 0 {
+		fmt.Printf("\nShared partials ({{ template \"name\" . }}): %s\n", strings.Join(partials, ", "))
+	}
+	return nil
+}
+
+func runTemplatesExport(cmd *cobra.Command, args []string) error {
+	dir := templatesExportDir
+	if dir == "" {
+		dir = templateOverrideDir()
+	}
+	if dir == "" {
+		dir = defaultTemplatesDir
+	}
+
+	paths := args
+	if len(paths) == 0 {
+		paths = core.TemplatePaths()
+	}
+	for _, path := range paths {
+		content, err := core.ExportTemplate(path)
+		if err != nil {
+			return fmt.Errorf("%w (see 'svcgen templates list')", err)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if _, err := os.Stat(target); err == nil && !templatesExportForce {
+			fmt.Printf("⚠ %s already exists, skipped (use --force to overwrite)\n", target)
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+		if err := os.WriteFile(target, []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", target, err)
+		}
+		fmt.Printf("✓ Exported %s\n", target)
+	}
+	return nil
+}
+
+// templateOverrideDir 当前生效的模板覆盖目录：--templates 优先，其次为 service.yaml 中的 templates.override_dir
+func templateOverrideDir() string {
+	if templatesDir != "" {
+		return templatesDir
+	}
+	cfg, err := config.NewLoader(configFile).Load()
+	if err != nil {
+		return ""
+	}
+	return cfg.Templates.OverrideDir
+}
+
+// printTemplateWarnings 检查模板覆盖目录并输出警告
+func printTemplateWarnings(cfg *config.ServiceConfig) error {
+	warnings, err := core.CheckTemplateOverrides(cfg.Templates.OverrideDir)
+	if err != nil {
+		return err
+	}
+	printWarnings(warnings)
+	return nil
+}
@@ -2,39 +2,100 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
 	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/generator"
 	"github.com/spf13/cobra"
 )
 
 var validateCmd = &cobra.Command{
 	Use:   "validate",
 	Short: "Validate service.yaml configuration",
-	Long:  `Validates the service.yaml configuration file for correctness and completeness.`,
-	RunE:  runValidate,
+	Long: `Validates the service.yaml configuration file for correctness and completeness.
+
+Templates are rendered in strict mode (without writing files): a template that
+references a missing variable fails instead of rendering "<no value>", and
+${VAR} references in build commands, install commands, startup commands,
+scripts, env values and volume sources that are neither generator variables
+nor declared env variables are reported. User variables (vars:) must not
+collide with built-in variables or reference each other in a cycle.`,
+	RunE: runValidate,
+}
+
+var (
+	strictValidation        bool
+	validateStrictTemplates bool
+)
+
+func init() {
+	validateCmd.Flags().BoolVar(&strictValidation, "strict", false, "Treat warnings (image policy, language version files, undefined variables) as errors (for CI)")
+	validateCmd.Flags().BoolVar(&validateStrictTemplates, "strict-templates", true, "Render templates in strict mode and check ${VAR} references in user commands")
 }
 
 func runValidate(cmd *cobra.Command, args []string) error {
 	// Load configuration
-	loader := config.NewLoader(configFile)
-	cfg, err := loader.Load()
+	cfg, err := loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return err
 	}
 
 	// Validate configuration
-	validator := config.NewValidator(cfg)
+	validator := config.NewValidator(cfg).WithStrict(strictValidation).WithProjectDir(filepath.Dir(configFile))
 	if err := validator.Validate(); err != nil {
 		return err
 	}
+	printWarnings(validator.Warnings())
+	if err := generator.NewGenerator(cfg, outputDir).ValidateUserVariables(); err != nil {
+		return err
+	}
+	if err := printTemplateWarnings(cfg); err != nil {
+		return err
+	}
+	if validateStrictTemplates {
+		if err := checkTemplateVariables(cfg, strictValidation); err != nil {
+			return err
+		}
+	}
 
 	fmt.Println("✓ Configuration is valid")
 	fmt.Printf("\nService: %s\n", cfg.Service.Name)
-	fmt.Printf("Language: %s\n", cfg.Language.Type)
+	fmt.Printf("Language: %s %s\n", cfg.Language.Type, cfg.Language.GetVersion())
 	fmt.Printf("Ports: %d configured\n", len(cfg.Service.Ports))
 	if len(cfg.Plugins.Items) > 0 {
 		fmt.Printf("Plugins: %d configured\n", len(cfg.Plugins.Items))
 	}
+	if len(cfg.Generators.Items) > 0 {
+		fmt.Printf("External generators: %d configured\n", len(cfg.Generators.Items))
+	}
+	if !cfg.RegistryMirrors.IsEmpty() {
+		profile := cfg.RegistryMirrors.Profile
+		if profile == "" {
+			profile = "(default)"
+		}
+		fmt.Printf("Registry mirrors: profile %s\n", profile)
+	}
+
+	return nil
+}
+
+// checkTemplateVariables 以严格模式渲染全部模板（不写文件），并检查用户命令中未定义的 ${VAR} 引用
+// warningsAsErrors 为 true 时未定义的引用视为错误
+func checkTemplateVariables(cfg *config.ServiceConfig, warningsAsErrors bool) error {
+	gen := generator.NewGenerator(cfg, outputDir).WithStrictTemplates(true)
+	if _, err := gen.Render(); err != nil {
+		return fmt.Errorf("strict template check failed: %w", err)
+	}
 
+	var messages []string
+	for _, undefined := range gen.CheckVariableReferences() {
+		messages = append(messages, undefined.String())
+	}
+	if warningsAsErrors && len(messages) > 0 {
+		return fmt.Errorf("configuration validation failed:\n  - %s", strings.Join(messages, "\n  - "))
+	}
+	printWarnings(messages)
+	printWarnings(gen.Warnings())
 	return nil
 }
@@ -0,0 +1,129 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/junjiewwang/service-template/pkg/generator"
+	"github.com/junjiewwang/service-template/pkg/generator/context"
+	"github.com/spf13/cobra"
+)
+
+var (
+	varsGenerator string
+	varsArch      string
+	varsFormat    string
+	renderExpr    string
+)
+
+var varsCmd = &cobra.Command{
+	Use:   "vars",
+	Short: "List the template variables available to a generator",
+	Long: `Prints every variable available as {{ .VAR }} in templates and ${VAR} in
+commands, with its resolved value and category (common, build, runtime, plugin,
+ci-paths, service, language, user for vars: entries, arch, or generator for
+variables a generator adds itself). Without --generator the shared variables are listed.`,
+	Example: `  svcgen vars
+  svcgen vars --generator compose
+  svcgen vars --generator dockerfile --arch arm64 --format json`,
+	Args: cobra.NoArgs,
+	RunE: runVars,
+}
+
+var renderCmd = &cobra.Command{
+	Use:   "render",
+	Short: "Expand an expression with the template variables",
+	Long: `Expands ${VAR} references (with ${VAR:-default} and $${VAR} escaping) and
+{{ .VAR }} template expressions the same way generated files do.`,
+	Example: `  svcgen render --expr '${SERVICE_ROOT}/bin'
+  svcgen render --generator dockerfile --arch arm64 --expr '{{ .BUILDER_IMAGE }}'`,
+	Args: cobra.NoArgs,
+	RunE: runRender,
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{varsCmd, renderCmd} {
+		cmd.Flags().StringVar(&varsGenerator, "generator", "", "Generator type ("+strings.Join(generator.GeneratorTypes(), ", ")+")")
+		cmd.Flags().StringVar(&varsArch, "arch", "amd64", "Target architecture (amd64, arm64)")
+	}
+	varsCmd.Flags().StringVar(&varsFormat, "format", "text", "Output format (text, json)")
+	renderCmd.Flags().StringVar(&renderExpr, "expr", "", "Expression to expand")
+	_ = renderCmd.MarkFlagRequired("expr")
+}
+
+func runVars(cmd *cobra.Command, args []string) error {
+	if varsFormat != "text" && varsFormat != "json" {
+		return fmt.Errorf("unsupported format %q (use text or json)", varsFormat)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	variables, err := generator.NewGenerator(cfg, outputDir).Variables(varsGenerator, varsArch)
+	if err != nil {
+		return err
+	}
+
+	if varsFormat == "json" {
+		data, err := json.MarshalIndent(variables, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode variables: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCATEGORY\tVALUE")
+	for _, v := range variables {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", v.Name, v.Category, formatVariableValue(v.Value))
+	}
+	return w.Flush()
+}
+
+func runRender(cmd *cobra.Command, args []string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+	gen := generator.NewGenerator(cfg, outputDir)
+	result, err := gen.Expand(renderExpr, varsGenerator, varsArch)
+	if err != nil {
+		return err
+	}
+
+	variables, err := gen.Variables(varsGenerator, varsArch)
+	if err != nil {
+		return err
+	}
+	known := make(map[string]bool, len(variables))
+	for _, v := range variables {
+		known[v.Name] = true
+	}
+	var warnings []string
+	for _, name := range context.ReferencedVariables(renderExpr) {
+		if !known[name] {
+			warnings = append(warnings, fmt.Sprintf("${%s} is not a template variable and is left for the runtime shell", name))
+		}
+	}
+	printWarnings(warnings)
+
+	fmt.Println(result)
+	return nil
+}
+
+// formatVariableValue formats a value on a single line: strings as is, other values as JSON
+func formatVariableValue(value interface{}) string {
+	if s, ok := value.(string); ok && !strings.Contains(s, "\n") {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
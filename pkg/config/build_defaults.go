@@ -1,5 +1,26 @@
 package config
 
+import (
+	"fmt"
+	"strings"
+)
+
+// 构建元数据参数名（Dockerfile ARG，由 Makefile 从 git 信息填充）
+const (
+	BuildArgVersion   = "VERSION"    // git describe --tags --always --dirty
+	BuildArgRevision  = "VCS_REF"    // git rev-parse HEAD
+	BuildArgBuildDate = "BUILD_DATE" // UTC RFC3339 构建时间
+)
+
+// BuildArgs Dockerfile 构建阶段声明的构建元数据 ARG，构建命令中可以直接引用
+var BuildArgs = []string{BuildArgVersion, BuildArgRevision, BuildArgBuildDate}
+
+// DefaultGoVersionPackage Go 版本信息注入的默认包路径（-ldflags -X <pkg>.Version=...）
+const DefaultGoVersionPackage = "main"
+
+// DefaultGoMainPackage Go 默认构建的 main 包路径（可通过 language.config.main_package 覆盖）
+const DefaultGoMainPackage = "./cmd/server"
+
 // DefaultBuildCommand 根据语言类型和语言配置推导默认的构建命令
 // 返回空字符串表示该语言没有合理的默认构建命令
 func DefaultBuildCommand(langType string, langCfg *LanguageConfig) string {
@@ -25,14 +46,78 @@ func ResolveBuildCommand(cfg *ServiceConfig) string {
 	return DefaultBuildCommand(cfg.Language.Type, &cfg.Language)
 }
 
+// CheckCommandProvider 按语言配置提供默认的测试 / 静态检查命令
+// 语言相关的默认值由生成器的语言策略维护，config 不直接依赖生成器包，由其在 init 时注册
+type CheckCommandProvider func(lang *LanguageConfig) (test, lint string)
+
+// checkCommandProvider 已注册的默认命令来源（未注册时没有默认命令）
+var checkCommandProvider CheckCommandProvider
+
+// RegisterCheckCommandProvider 注册默认测试 / 静态检查命令的来源
+func RegisterCheckCommandProvider(p CheckCommandProvider) {
+	checkCommandProvider = p
+}
+
+// defaultCheckCommands 通过已注册的 provider 推导默认测试 / 静态检查命令
+func defaultCheckCommands(lang *LanguageConfig) (test, lint string) {
+	if checkCommandProvider == nil {
+		return "", ""
+	}
+	return checkCommandProvider(lang)
+}
+
+// 可选 Dockerfile stage（可在 build.skip_stages 中关闭）
+const (
+	StageTest = "test"
+	StageLint = "lint"
+)
+
+// OptionalStages 可通过 build.skip_stages 关闭的 stage
+var OptionalStages = []string{StageTest, StageLint}
+
+// SkipsStage 判断可选 stage 是否在 build.skip_stages 中被关闭
+func (b *BuildConfig) SkipsStage(stage string) bool {
+	for _, s := range b.SkipStages {
+		if s == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveTestCommand 解析测试命令（build.skip_stages 关闭时为空 > 用户显式配置 > 按语言推导）
+func ResolveTestCommand(cfg *ServiceConfig) string {
+	if cfg.Build.SkipsStage(StageTest) {
+		return ""
+	}
+	if cfg.Build.Commands.Test != "" {
+		return cfg.Build.Commands.Test
+	}
+	test, _ := defaultCheckCommands(&cfg.Language)
+	return test
+}
+
+// ResolveLintCommand 解析静态检查命令（build.skip_stages 关闭时为空 > 用户显式配置 > 按语言推导）
+func ResolveLintCommand(cfg *ServiceConfig) string {
+	if cfg.Build.SkipsStage(StageLint) {
+		return ""
+	}
+	if cfg.Build.Commands.Lint != "" {
+		return cfg.Build.Commands.Lint
+	}
+	_, lint := defaultCheckCommands(&cfg.Language)
+	return lint
+}
+
 // ============================================
 // 默认构建命令推导映射表
 // ============================================
 
 var defaultBuildCommandFuncs = map[string]func(cfg *LanguageConfig) string{
 	"go": func(cfg *LanguageConfig) string {
-		// Go 标准构建：静态编译，输出到 ${BUILD_OUTPUT_DIR}/bin/
-		return `CGO_ENABLED=0 go build -ldflags="-s -w" -o ${BUILD_OUTPUT_DIR}/bin/${SERVICE_NAME} ./cmd/server`
+		// Go 标准构建：静态编译，通过 -ldflags -X 注入版本信息，输出到 ${BUILD_OUTPUT_DIR}/bin/
+		return fmt.Sprintf(`CGO_ENABLED=0 go build -ldflags="-s -w %s" -o ${BUILD_OUTPUT_DIR}/bin/${SERVICE_NAME} %s`,
+			GoVersionLdflags(cfg), cfg.GetString("main_package", DefaultGoMainPackage))
 	},
 	"python": func(cfg *LanguageConfig) string {
 		// Python 拷贝源码到构建输出目录
@@ -54,3 +139,26 @@ var defaultBuildCommandFuncs = map[string]func(cfg *LanguageConfig) string{
 		return `cargo build --release && cp target/release/${SERVICE_NAME} ${BUILD_OUTPUT_DIR}/bin/${SERVICE_NAME}`
 	},
 }
+
+// GoVersionLdflags 生成注入版本信息的 -X 参数
+// 包路径可通过 language.config 配置：
+//   - version_package: 三个变量共用的包路径（默认 main）
+//   - version_var / revision_var / build_date_var: 单独指定完整变量路径（如 github.com/org/svc/internal/version.Commit）
+func GoVersionLdflags(cfg *LanguageConfig) string {
+	pkg := cfg.GetString("version_package", DefaultGoVersionPackage)
+
+	vars := []struct {
+		key, name, arg, fallback string
+	}{
+		{"version_var", "Version", BuildArgVersion, "dev"},
+		{"revision_var", "Revision", BuildArgRevision, "unknown"},
+		{"build_date_var", "BuildDate", BuildArgBuildDate, "unknown"},
+	}
+
+	flags := make([]string, 0, len(vars))
+	for _, v := range vars {
+		target := cfg.GetString(v.key, pkg+"."+v.name)
+		flags = append(flags, fmt.Sprintf("-X %s=${%s:-%s}", target, v.arg, v.fallback))
+	}
+	return strings.Join(flags, " ")
+}
@@ -14,6 +14,10 @@ func TestDefaultBuildCommand(t *testing.T) {
 		contains string // 检查命令中包含的关键字
 	}{
 		{"go", "go", &LanguageConfig{Type: "go"}, "go build"},
+		{"go main package", "go", &LanguageConfig{
+			Type:   "go",
+			Config: map[string]interface{}{"main_package": "./cmd/api"},
+		}, "${SERVICE_NAME} ./cmd/api"},
 		{"python", "python", &LanguageConfig{Type: "python"}, "cp -r"},
 		{"java maven", "java", &LanguageConfig{Type: "java"}, "mvn package"},
 		{"java gradle", "java", &LanguageConfig{
@@ -76,3 +80,61 @@ func TestResolveBuildCommand(t *testing.T) {
 		assert.Empty(t, ResolveBuildCommand(cfg))
 	})
 }
+
+func TestGoVersionLdflags(t *testing.T) {
+	t.Run("default package is main", func(t *testing.T) {
+		flags := GoVersionLdflags(&LanguageConfig{Type: "go"})
+		assert.Equal(t, "-X main.Version=${VERSION:-dev} -X main.Revision=${VCS_REF:-unknown} -X main.BuildDate=${BUILD_DATE:-unknown}", flags)
+	})
+
+	t.Run("custom package and variable paths", func(t *testing.T) {
+		flags := GoVersionLdflags(&LanguageConfig{
+			Type: "go",
+			Config: map[string]interface{}{
+				"version_package": "github.com/org/svc/internal/version",
+				"revision_var":    "github.com/org/svc/internal/version.Commit",
+			},
+		})
+		assert.Contains(t, flags, "-X github.com/org/svc/internal/version.Version=${VERSION:-dev}")
+		assert.Contains(t, flags, "-X github.com/org/svc/internal/version.Commit=${VCS_REF:-unknown}")
+		assert.Contains(t, flags, "-X github.com/org/svc/internal/version.BuildDate=${BUILD_DATE:-unknown}")
+	})
+
+	t.Run("default go build command stamps version", func(t *testing.T) {
+		cmd := DefaultBuildCommand("go", &LanguageConfig{Type: "go"})
+		assert.Contains(t, cmd, `-ldflags="-s -w -X main.Version=${VERSION:-dev}`)
+	})
+}
+
+func TestResolveTestAndLintCommand(t *testing.T) {
+	// 默认命令由语言策略注册，这里用桩替代
+	previous := checkCommandProvider
+	t.Cleanup(func() { checkCommandProvider = previous })
+	RegisterCheckCommandProvider(func(lang *LanguageConfig) (string, string) {
+		if lang.Type == "go" {
+			return "go test ./...", "go vet ./..."
+		}
+		return "pytest", ""
+	})
+
+	cfg := &ServiceConfig{Language: LanguageConfig{Type: "go"}}
+	assert.Equal(t, "go test ./...", ResolveTestCommand(cfg))
+	assert.Equal(t, "go vet ./...", ResolveLintCommand(cfg))
+
+	cfg.Build.Commands.Test = "go test -race ./..."
+	cfg.Build.Commands.Lint = "golangci-lint run"
+	assert.Equal(t, "go test -race ./...", ResolveTestCommand(cfg))
+	assert.Equal(t, "golangci-lint run", ResolveLintCommand(cfg))
+
+	// python 没有默认 lint 命令
+	assert.Empty(t, ResolveLintCommand(&ServiceConfig{Language: LanguageConfig{Type: "python"}}))
+
+	// build.skip_stages 关闭 stage，显式配置的命令也不再生效
+	cfg.Build.SkipStages = []string{StageTest, StageLint}
+	assert.Empty(t, ResolveTestCommand(cfg))
+	assert.Empty(t, ResolveLintCommand(cfg))
+
+	// 未注册 provider 时没有默认命令
+	checkCommandProvider = nil
+	assert.Empty(t, ResolveTestCommand(&ServiceConfig{Language: LanguageConfig{Type: "go"}}))
+}
@@ -0,0 +1,178 @@
+package config
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================
+// 显式字段记录与默认值层
+// bool 等零值字段无法区分“未设置”与“显式设置为零值”，
+// Loader 解析时记录 service.yaml 中实际出现的字段，默认值只作用于未出现的字段
+// ============================================
+
+// FieldSet service.yaml 中显式出现的字段路径集合
+// 路径格式与 svcgen config get 一致，如 "runtime.healthcheck.enabled"、"service.ports[0].expose"
+type FieldSet map[string]bool
+
+// Has 判断字段是否在配置文件中显式设置
+func (s FieldSet) Has(path string) bool {
+	return s[path]
+}
+
+// collectFieldSet 遍历 yaml 节点树，记录所有出现的字段路径
+// 值为空（null 或空字符串）的字段视为未设置
+func collectFieldSet(node *yaml.Node) FieldSet {
+	set := FieldSet{}
+	var walk func(n *yaml.Node, path []editSegment)
+	walk = func(n *yaml.Node, path []editSegment) {
+		if n.Kind == yaml.AliasNode {
+			n = n.Alias
+		}
+		if n.Kind == yaml.ScalarNode && (n.Tag == "!!null" || n.Value == "") {
+			return
+		}
+		if len(path) > 0 {
+			set[formatEditPath(path)] = true
+		}
+		switch n.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				walk(n.Content[i+1], append(path[:len(path):len(path)], editSegment{kind: segmentKey, key: n.Content[i].Value}))
+			}
+		case yaml.SequenceNode:
+			for i, item := range n.Content {
+				walk(item, append(path[:len(path):len(path)], editSegment{kind: segmentIndex, index: i}))
+			}
+		}
+	}
+	walk(node, nil)
+	return set
+}
+
+// FieldDefault 声明式默认值：字段未在 service.yaml 中显式设置时生效
+type FieldDefault struct {
+	// Path 字段路径
+	Path string
+	// Description 默认值说明（svcgen config resolved 中展示）
+	Description string
+	// Apply 计算默认值并写入配置，返回写入的值；ok 为 false 表示该默认值不适用
+	Apply func(cfg *ServiceConfig) (value interface{}, ok bool)
+}
+
+// AppliedDefault 加载时实际生效的默认值
+type AppliedDefault struct {
+	Path        string
+	Value       interface{}
+	Description string
+}
+
+// fieldDefaults 默认值按顺序生效，后面的默认值可以依赖前面的结果
+var fieldDefaults = []FieldDefault{
+	{
+		Path:        "service.deploy_dir",
+		Description: "default deploy directory",
+		Apply: func(cfg *ServiceConfig) (interface{}, bool) {
+			cfg.Service.DeployDir = "/usr/local/services"
+			return cfg.Service.DeployDir, true
+		},
+	},
+	{
+		Path:        "build.dependency_files.auto_detect",
+		Description: "detect dependency files by language when no files are listed",
+		Apply: func(cfg *ServiceConfig) (interface{}, bool) {
+			if len(cfg.Build.DependencyFiles.Files) > 0 {
+				return nil, false
+			}
+			cfg.Build.DependencyFiles.AutoDetect = true
+			return true, true
+		},
+	},
+	{
+		Path:        "runtime.healthcheck.enabled",
+		Description: "healthchecks are opt-in",
+		Apply: func(cfg *ServiceConfig) (interface{}, bool) {
+			if !cfg.Explicit.Has("runtime.healthcheck") {
+				return nil, false
+			}
+			cfg.Runtime.Healthcheck.Enabled = false
+			return false, true
+		},
+	},
+	{
+		Path:        "local_dev.kubernetes.enabled",
+		Description: "kubernetes manifests are opt-in",
+		Apply: func(cfg *ServiceConfig) (interface{}, bool) {
+			cfg.LocalDev.Kubernetes.Enabled = false
+			return false, true
+		},
+	},
+}
+
+// IsSet 判断字段是否在配置文件中显式设置（未经 Loader 加载的配置始终返回 false）
+func (c *ServiceConfig) IsSet(path string) bool {
+	return c.Explicit.Has(path)
+}
+
+// applyDefaults 为未显式设置的字段填充默认值，并记录生效的默认值
+func applyDefaults(config *ServiceConfig) {
+	config.Defaults = nil
+	for _, d := range fieldDefaults {
+		if config.Explicit.Has(d.Path) {
+			continue
+		}
+		if value, ok := d.Apply(config); ok {
+			config.Defaults = append(config.Defaults, AppliedDefault{Path: d.Path, Value: value, Description: d.Description})
+		}
+	}
+}
+
+// decodeConfig 解析（已迁移的）配置内容，记录显式字段并应用默认值
+func decodeConfig(content []byte) (*ServiceConfig, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+
+	var config ServiceConfig
+	config.Explicit = FieldSet{}
+	if len(doc.Content) > 0 {
+		if err := doc.Content[0].Decode(&config); err != nil {
+			return nil, err
+		}
+		config.Explicit = collectFieldSet(doc.Content[0])
+	}
+	applyDefaults(&config)
+	return &config, nil
+}
+
+// MarshalResolved 输出应用默认值后的完整配置，由默认值填充的字段带 "# default" 注释
+func MarshalResolved(cfg *ServiceConfig) ([]byte, error) {
+	var root yaml.Node
+	if err := root.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	for _, d := range cfg.Defaults {
+		if node := resolvedNode(&root, d.Path); node != nil {
+			node.LineComment = "default: " + d.Description
+		}
+	}
+	return encodeDocument(&yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&root}})
+}
+
+// resolvedNode 按字段路径查找节点，未找到时返回 nil
+func resolvedNode(root *yaml.Node, path string) *yaml.Node {
+	segments, err := parseEditPath(path)
+	if err != nil {
+		return nil
+	}
+	node := root
+	for _, seg := range segments {
+		if node, err = seg.lookup(node); err != nil || node == nil {
+			return nil
+		}
+	}
+	return node
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const defaultsBase = `
+service:
+  name: demo
+  ports:
+    - name: http
+      port: 8080
+      protocol: TCP
+language:
+  type: go
+`
+
+func loadDefaultsConfig(t *testing.T, extra string) *ServiceConfig {
+	t.Helper()
+	cfg, err := LoadFromBytes([]byte(defaultsBase + extra))
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestCollectFieldSet(t *testing.T) {
+	cfg := loadDefaultsConfig(t, `
+runtime:
+  healthcheck:
+    enabled:
+    type: ""
+local_dev:
+  compose:
+    labels:
+      app.tier: backend
+`)
+
+	assert.True(t, cfg.IsSet("service.ports[0].port"))
+	assert.True(t, cfg.IsSet("service.ports[0]"))
+	assert.True(t, cfg.IsSet(`local_dev.compose.labels["app.tier"]`))
+	assert.True(t, cfg.IsSet("runtime.healthcheck"))
+	assert.False(t, cfg.IsSet("runtime.healthcheck.enabled"), "null values are not set")
+	assert.False(t, cfg.IsSet("runtime.healthcheck.type"), "empty strings are not set")
+	assert.False(t, cfg.IsSet("service.ports[0].expose"))
+	assert.False(t, (&ServiceConfig{}).IsSet("service.name"), "configs built in code have no explicit fields")
+}
+
+func TestApplyDefaults_DependencyFiles(t *testing.T) {
+	cfg := loadDefaultsConfig(t, "")
+	assert.True(t, cfg.Build.DependencyFiles.AutoDetect)
+
+	cfg = loadDefaultsConfig(t, "build:\n  dependency_files:\n    auto_detect: false\n")
+	assert.False(t, cfg.Build.DependencyFiles.AutoDetect, "explicit false is kept even without files")
+
+	cfg = loadDefaultsConfig(t, "build:\n  dependency_files:\n    files: [go.mod]\n")
+	assert.False(t, cfg.Build.DependencyFiles.AutoDetect, "listed files disable auto-detection")
+}
+
+func TestApplyDefaults_Healthcheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		enabled bool
+	}{
+		{"section missing", "", false},
+		{"section without enabled", "runtime:\n  healthcheck:\n    type: tcp\n", false},
+		{"explicitly disabled", "runtime:\n  healthcheck:\n    enabled: false\n    type: tcp\n", false},
+		{"explicitly enabled", "runtime:\n  healthcheck:\n    enabled: true\n", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := loadDefaultsConfig(t, tt.yaml)
+			assert.Equal(t, tt.enabled, cfg.Runtime.Healthcheck.Enabled)
+		})
+	}
+}
+
+func TestApplyDefaults_LocalKubernetes(t *testing.T) {
+	assert.False(t, loadDefaultsConfig(t, "local_dev:\n  kubernetes:\n    namespace: dev\n").LocalDev.Kubernetes.Enabled)
+}
+
+func TestApplyDefaults_Recorded(t *testing.T) {
+	cfg := loadDefaultsConfig(t, "runtime:\n  healthcheck:\n    type: tcp\n")
+
+	paths := make([]string, 0, len(cfg.Defaults))
+	for _, d := range cfg.Defaults {
+		paths = append(paths, d.Path)
+	}
+	assert.Equal(t, []string{
+		"service.deploy_dir",
+		"build.dependency_files.auto_detect",
+		"runtime.healthcheck.enabled",
+		"local_dev.kubernetes.enabled",
+	}, paths)
+	assert.Equal(t, "/usr/local/services", cfg.Defaults[0].Value)
+
+	cfg = loadDefaultsConfig(t, "build:\n  dependency_files:\n    auto_detect: true\n")
+	for _, d := range cfg.Defaults {
+		assert.NotEqual(t, "build.dependency_files.auto_detect", d.Path, "explicit fields are not defaulted")
+	}
+}
+
+func TestMarshalResolved(t *testing.T) {
+	cfg := loadDefaultsConfig(t, "runtime:\n  healthcheck:\n    type: tcp\n")
+
+	out, err := MarshalResolved(cfg)
+	require.NoError(t, err)
+	text := string(out)
+	assert.Contains(t, text, "deploy_dir: /usr/local/services # default: default deploy directory")
+	assert.Contains(t, text, "enabled: false # default: healthchecks are opt-in")
+	assert.Contains(t, text, "type: tcp\n")
+	assert.Contains(t, text, "name: demo\n")
+}
+
+func TestValidator_AutoDetectDisabledWithoutFiles(t *testing.T) {
+	cfg := loadDefaultsConfig(t, "build:\n  dependency_files:\n    auto_detect: false\n")
+	v := NewValidator(cfg)
+	_ = v.Validate()
+	assert.Contains(t, v.Warnings(), "build.dependency_files.auto_detect is false and no files are listed; no dependency files will be copied before the build")
+}
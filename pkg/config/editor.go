@@ -0,0 +1,442 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Editor 基于 yaml.Node 的 service.yaml 编辑器，修改时保留注释与字段顺序
+//
+// 路径语法（点分隔）：
+//
+//	build.builder_image                       mapping 字段
+//	service.ports[0].port                     列表下标
+//	plugins.items[name=agent].required        按字段值选择列表中的 mapping
+//	local_dev.compose.labels["app.tier"]      含点号的键
+//
+// 标量替换标量、向已有的块 mapping 添加字段、向已有的块列表追加项时直接改写原文对应位置；
+// 其余修改重新编码整个文档（注释与顺序保留，缩进会被规范化）
+type Editor struct {
+	data []byte
+	doc  *yaml.Node
+	root *yaml.Node
+}
+
+// NewEditor 解析配置内容
+func NewEditor(data []byte) (*Editor, error) {
+	e := &Editor{}
+	if err := e.reset(data); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Bytes 返回编辑后的内容
+func (e *Editor) Bytes() []byte {
+	return e.data
+}
+
+// Get 返回路径对应的节点
+func (e *Editor) Get(path string) (*yaml.Node, error) {
+	segments, err := parseEditPath(path)
+	if err != nil {
+		return nil, err
+	}
+	node := e.root
+	for i, seg := range segments {
+		child, err := seg.lookup(node)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", formatEditPath(segments[:i+1]), err)
+		}
+		if child == nil {
+			return nil, fmt.Errorf("%s: not found", formatEditPath(segments[:i+1]))
+		}
+		node = child
+	}
+	return node, nil
+}
+
+// Set 设置路径对应的值，缺失的中间 mapping 会自动创建
+// 已有值为字符串时，数字 / 布尔等形式的新值仍按字符串写入（如 language.version: "1.20"）
+func (e *Editor) Set(path string, value *yaml.Node) error {
+	parent, last, created, err := e.parent(path)
+	if err != nil {
+		return err
+	}
+
+	existing, err := last.lookup(parent)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if existing != nil {
+		value = keepScalarType(existing, value)
+		if existing.Kind == yaml.ScalarNode && value.Kind == yaml.ScalarNode {
+			if text, ok := renderScalar(value); ok {
+				if data, ok := replaceScalarText(e.data, existing, text); ok {
+					return e.reset(data)
+				}
+			}
+		}
+		value.LineComment = existing.LineComment
+		value.HeadComment = existing.HeadComment
+		value.FootComment = existing.FootComment
+	}
+
+	if err := last.assign(parent, value); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	if created == nil && existing == nil {
+		created = &insertion{parent: parent, key: parent.Content[len(parent.Content)-2], value: value}
+	}
+	return e.commit(created)
+}
+
+// Add 向路径对应的列表追加一项，列表不存在时创建
+func (e *Editor) Add(path string, item *yaml.Node) error {
+	parent, last, created, err := e.parent(path)
+	if err != nil {
+		return err
+	}
+
+	list, err := last.lookup(parent)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	switch {
+	case list == nil:
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if err := last.assign(parent, list); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if created == nil {
+			created = &insertion{parent: parent, key: parent.Content[len(parent.Content)-2], value: list}
+		}
+	case list.Kind == yaml.ScalarNode && list.Tag == "!!null":
+		list = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if err := last.assign(parent, list); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	case list.Kind != yaml.SequenceNode:
+		return fmt.Errorf("%s: not a list", path)
+	}
+
+	if len(list.Content) == 0 {
+		// 空的 flow 列表（如 volumes: []）追加后改为块格式
+		list.Style = 0
+	}
+	list.Content = append(list.Content, item)
+	if created == nil {
+		created = &insertion{parent: list, value: item}
+	}
+	return e.commit(created)
+}
+
+// parent 定位路径最后一段的父节点，缺失的中间 mapping 会被创建
+// created 记录第一个新建的中间字段（用于就地插入），没有新建时为 nil
+func (e *Editor) parent(path string) (*yaml.Node, editSegment, *insertion, error) {
+	segments, err := parseEditPath(path)
+	if err != nil {
+		return nil, editSegment{}, nil, err
+	}
+
+	var created *insertion
+	node := e.root
+	for i, seg := range segments[:len(segments)-1] {
+		child, err := seg.lookup(node)
+		if err != nil {
+			return nil, editSegment{}, nil, fmt.Errorf("%s: %w", formatEditPath(segments[:i+1]), err)
+		}
+		if child == nil || (child.Kind == yaml.ScalarNode && child.Tag == "!!null") {
+			if seg.kind != segmentKey {
+				return nil, editSegment{}, nil, fmt.Errorf("%s: not found", formatEditPath(segments[:i+1]))
+			}
+			existed := child != nil
+			child = newMappingNode()
+			mappingSet(node, seg.key, child)
+			if created == nil && !existed {
+				created = &insertion{parent: node, key: node.Content[len(node.Content)-2], value: child}
+			}
+		}
+		node = child
+	}
+	return node, segments[len(segments)-1], created, nil
+}
+
+// commit 应用节点树的修改：能就地插入时只改动插入位置，否则重新编码整个文档
+func (e *Editor) commit(ins *insertion) error {
+	if ins != nil {
+		if data, ok := spliceInsertion(e.data, *ins); ok {
+			return e.reset(data)
+		}
+	}
+	return e.rewrite()
+}
+
+// reset 用新内容重新解析文档（原位改写后节点位置信息需要刷新）
+func (e *Editor) reset(data []byte) error {
+	doc, root, err := parseDocument(data)
+	if err != nil {
+		return err
+	}
+	e.data, e.doc, e.root = data, doc, root
+	return nil
+}
+
+// rewrite 重新编码修改后的节点树
+func (e *Editor) rewrite() error {
+	content, err := encodeDocument(e.doc)
+	if err != nil {
+		return err
+	}
+	return e.reset(restoreSectionSpacing(e.data, content))
+}
+
+// keepScalarType 已有值为字符串而新值被解析为数字 / 布尔时，按字符串写入；字符串沿用原有引号风格
+func keepScalarType(existing, value *yaml.Node) *yaml.Node {
+	if existing.Kind != yaml.ScalarNode || value.Kind != yaml.ScalarNode || existing.Tag != "!!str" {
+		return value
+	}
+	if value.Tag != "!!str" && value.Tag != "!!null" {
+		value = newStringNode(value.Value)
+	}
+	if value.Tag == "!!str" && value.Style == 0 {
+		value.Style = existing.Style &^ (yaml.LiteralStyle | yaml.FoldedStyle)
+	}
+	return value
+}
+
+// ParseValue 将命令行参数解析为 YAML 节点
+// 标量（数字、布尔等）与 flow 列表 / 映射按 YAML 解析，其余（如 "@builders.go_1.23"、"echo a: b"）作为字符串
+func ParseValue(text string) *yaml.Node {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(text), &doc); err != nil || len(doc.Content) == 0 {
+		return newStringNode(text)
+	}
+	node := doc.Content[0]
+	switch {
+	case node.Kind == yaml.ScalarNode && (node.Value != strings.TrimSpace(text) || node.LineComment != ""):
+		// 带注释、引号或首尾空白的文本按原样作为字符串
+		if node.Tag != "!!str" || node.Style == 0 {
+			return newStringNode(text)
+		}
+	case node.Kind != yaml.ScalarNode && node.Style&yaml.FlowStyle == 0:
+		return newStringNode(text)
+	}
+	clearPositions(node)
+	return node
+}
+
+// ParseItem 解析列表项：flow 形式（"{...}" / "[...]"）、"key=value,key2=value2" 形式的 mapping，或单个值
+func ParseItem(text string) (*yaml.Node, error) {
+	trimmed := strings.TrimSpace(text)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var doc yaml.Node
+		if err := yaml.Unmarshal([]byte(trimmed), &doc); err != nil {
+			return nil, fmt.Errorf("invalid item %q: %w", text, err)
+		}
+		node := doc.Content[0]
+		clearPositions(node)
+		node.Style = 0
+		return node, nil
+	}
+	if !strings.Contains(text, "=") {
+		return ParseValue(text), nil
+	}
+
+	item := newMappingNode()
+	for _, pair := range strings.Split(text, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid item %q: expected key=value pairs separated by commas", text)
+		}
+		if mappingIndex(item, key) >= 0 {
+			return nil, fmt.Errorf("invalid item %q: duplicate key %s", text, key)
+		}
+		item.Content = append(item.Content, newStringNode(key), ParseValue(strings.TrimSpace(value)))
+	}
+	return item, nil
+}
+
+// FormatNode 格式化节点用于输出：标量输出原值，其余输出 YAML
+func FormatNode(node *yaml.Node) (string, error) {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, nil
+	}
+	out, err := encodeDocument(node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// clearPositions 清除节点位置信息（来自独立解析的节点不能用于原文定位）
+func clearPositions(node *yaml.Node) {
+	node.Line, node.Column = 0, 0
+	for _, child := range node.Content {
+		clearPositions(child)
+	}
+}
+
+// ============================================
+// 路径解析
+// ============================================
+
+type segmentKind int
+
+const (
+	segmentKey   segmentKind = iota // mapping 字段
+	segmentIndex                    // 列表下标
+	segmentMatch                    // 列表中 field=value 的 mapping
+)
+
+type editSegment struct {
+	kind  segmentKind
+	key   string // segmentKey: 字段名；segmentMatch: 匹配字段
+	value string // segmentMatch: 匹配值
+	index int
+}
+
+// lookup 在 node 中查找该段，不存在时返回 (nil, nil)，类型不匹配时返回错误
+func (s editSegment) lookup(node *yaml.Node) (*yaml.Node, error) {
+	switch s.kind {
+	case segmentKey:
+		if node.Kind != yaml.MappingNode {
+			return nil, fmt.Errorf("not a mapping")
+		}
+		return mappingValue(node, s.key), nil
+	case segmentIndex:
+		if node.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("not a list")
+		}
+		if s.index >= len(node.Content) {
+			return nil, fmt.Errorf("index %d out of range (%d items)", s.index, len(node.Content))
+		}
+		return node.Content[s.index], nil
+	default:
+		if node.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("not a list")
+		}
+		var found *yaml.Node
+		for _, item := range node.Content {
+			if v := mappingValue(item, s.key); v != nil && v.Kind == yaml.ScalarNode && v.Value == s.value {
+				if found != nil {
+					return nil, fmt.Errorf("several items match %s=%s", s.key, s.value)
+				}
+				found = item
+			}
+		}
+		return found, nil
+	}
+}
+
+// assign 在 parent 中设置该段的值
+func (s editSegment) assign(parent, value *yaml.Node) error {
+	if s.kind == segmentKey {
+		if parent.Kind != yaml.MappingNode {
+			return fmt.Errorf("not a mapping")
+		}
+		mappingSet(parent, s.key, value)
+		return nil
+	}
+
+	existing, err := s.lookup(parent)
+	if err != nil {
+		return err
+	}
+	for i, item := range parent.Content {
+		if item == existing {
+			parent.Content[i] = value
+			return nil
+		}
+	}
+	return fmt.Errorf("not found (use add to append list items)")
+}
+
+// parseEditPath 解析编辑路径
+func parseEditPath(path string) ([]editSegment, error) {
+	var segments []editSegment
+	invalid := func(reason string) error {
+		return fmt.Errorf("invalid path %q: %s", path, reason)
+	}
+
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			if i == 0 || i == len(path)-1 || path[i+1] == '.' {
+				return nil, invalid("empty key")
+			}
+			i++
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, invalid("missing ]")
+			}
+			inner := path[i+1 : i+end]
+			if strings.HasPrefix(inner, `"`) {
+				// ["a.b"] 中的键可能包含 ]，按引号结束位置重新定位
+				closing := quotedEnd(path, i+1, '"')
+				if closing < 0 || closing >= len(path) || path[closing] != ']' {
+					return nil, invalid("unterminated quoted key")
+				}
+				key, err := strconv.Unquote(path[i+1 : closing])
+				if err != nil {
+					return nil, invalid("bad quoted key")
+				}
+				segments = append(segments, editSegment{kind: segmentKey, key: key})
+				i = closing + 1
+				continue
+			}
+			if field, value, ok := strings.Cut(inner, "="); ok {
+				if field == "" {
+					return nil, invalid("empty selector field")
+				}
+				segments = append(segments, editSegment{kind: segmentMatch, key: field, value: value})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, invalid("list index must be a non-negative number or field=value")
+				}
+				segments = append(segments, editSegment{kind: segmentIndex, index: index})
+			}
+			i += end + 1
+		default:
+			end := strings.IndexAny(path[i:], ".[")
+			if end < 0 {
+				end = len(path) - i
+			}
+			segments = append(segments, editSegment{kind: segmentKey, key: path[i : i+end]})
+			i += end
+		}
+	}
+	if len(segments) == 0 {
+		return nil, invalid("empty path")
+	}
+	return segments, nil
+}
+
+// formatEditPath 将路径段格式化回字符串（用于错误信息）
+func formatEditPath(segments []editSegment) string {
+	var b strings.Builder
+	for _, seg := range segments {
+		switch seg.kind {
+		case segmentKey:
+			if strings.ContainsAny(seg.key, ".[]") {
+				b.WriteString("[" + strconv.Quote(seg.key) + "]")
+				continue
+			}
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			b.WriteString(seg.key)
+		case segmentIndex:
+			fmt.Fprintf(&b, "[%d]", seg.index)
+		default:
+			fmt.Fprintf(&b, "[%s=%s]", seg.key, seg.value)
+		}
+	}
+	return b.String()
+}
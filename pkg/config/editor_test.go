@@ -0,0 +1,213 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+const editorSample = `# 服务配置
+service:
+  name: demo # 服务名称
+  ports:
+    - name: http
+      port: 8080
+
+    - name: metrics
+      port: 9090
+
+language:
+  type: go
+  version: "1.23"
+
+build:
+  dependencies:
+    system_pkgs:
+      - git
+      # 自定义包
+  commands:
+    build: |
+      go build ./...
+
+plugins:
+  items: []
+`
+
+func newSampleEditor(t *testing.T) *Editor {
+	t.Helper()
+	editor, err := NewEditor([]byte(editorSample))
+	require.NoError(t, err)
+	return editor
+}
+
+func TestParseEditPath(t *testing.T) {
+	segments, err := parseEditPath(`service.ports[name=http].port`)
+	require.NoError(t, err)
+	require.Len(t, segments, 4)
+	assert.Equal(t, editSegment{kind: segmentMatch, key: "name", value: "http"}, segments[2])
+
+	segments, err = parseEditPath(`local_dev.compose.labels["app.tier"]`)
+	require.NoError(t, err)
+	assert.Equal(t, editSegment{kind: segmentKey, key: "app.tier"}, segments[3])
+	assert.Equal(t, `local_dev.compose.labels["app.tier"]`, formatEditPath(segments))
+
+	segments, err = parseEditPath(`runtime.startup.env[1]`)
+	require.NoError(t, err)
+	assert.Equal(t, "runtime.startup.env[1]", formatEditPath(segments))
+
+	for _, path := range []string{"", ".a", "a..b", "a.", "a[", "a[-1]", "a[x]", `a["b]`, "a[=b]"} {
+		_, err := parseEditPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestEditor_Get(t *testing.T) {
+	editor := newSampleEditor(t)
+
+	node, err := editor.Get("service.ports[name=metrics].port")
+	require.NoError(t, err)
+	assert.Equal(t, "9090", node.Value)
+
+	node, err = editor.Get("service.ports[0]")
+	require.NoError(t, err)
+	text, err := FormatNode(node)
+	require.NoError(t, err)
+	assert.Equal(t, "name: http\nport: 8080", text)
+
+	_, err = editor.Get("service.missing")
+	assert.ErrorContains(t, err, "service.missing: not found")
+	_, err = editor.Get("service.ports[5]")
+	assert.ErrorContains(t, err, "out of range")
+	_, err = editor.Get("service.name.first")
+	assert.ErrorContains(t, err, "not a mapping")
+}
+
+func TestEditor_SetScalarKeepsLayout(t *testing.T) {
+	editor := newSampleEditor(t)
+
+	require.NoError(t, editor.Set("service.name", ParseValue("api")))
+	require.NoError(t, editor.Set("service.ports[name=metrics].port", ParseValue("9100")))
+	require.NoError(t, editor.Set("language.version", ParseValue("1.24")))
+
+	expected := editorSample
+	expected = replaceOnce(t, expected, "name: demo # 服务名称", "name: api # 服务名称")
+	expected = replaceOnce(t, expected, "port: 9090", "port: 9100")
+	expected = replaceOnce(t, expected, `version: "1.23"`, `version: "1.24"`)
+	assert.Equal(t, expected, string(editor.Bytes()))
+
+	node, err := editor.Get("language.version")
+	require.NoError(t, err)
+	assert.Equal(t, "!!str", node.Tag, "existing string keeps its type")
+}
+
+func TestEditor_SetNewKey(t *testing.T) {
+	editor := newSampleEditor(t)
+
+	require.NoError(t, editor.Set("service.deploy_dir", ParseValue("/opt/services")))
+	require.NoError(t, editor.Set("runtime.startup.command", ParseValue("./demo")))
+
+	expected := replaceOnce(t, editorSample, "      port: 9090\n", "      port: 9090\n  deploy_dir: /opt/services\n")
+	expected += "\nruntime:\n  startup:\n    command: ./demo\n"
+	assert.Equal(t, expected, string(editor.Bytes()))
+}
+
+func TestEditor_Add(t *testing.T) {
+	editor := newSampleEditor(t)
+
+	port, err := ParseItem("name=grpc,port=9000,protocol=TCP")
+	require.NoError(t, err)
+	require.NoError(t, editor.Add("service.ports", port))
+	pkg, err := ParseItem("jq")
+	require.NoError(t, err)
+	require.NoError(t, editor.Add("build.dependencies.system_pkgs", pkg))
+
+	expected := replaceOnce(t, editorSample, "      port: 9090\n",
+		"      port: 9090\n\n    - name: grpc\n      port: 9000\n      protocol: TCP\n")
+	expected = replaceOnce(t, expected, "      - git\n", "      - git\n      - jq\n")
+	assert.Equal(t, expected, string(editor.Bytes()))
+
+	// 空的 flow 列表改为块格式
+	plugin, err := ParseItem("{name: agent, required: true}")
+	require.NoError(t, err)
+	require.NoError(t, editor.Add("plugins.items", plugin))
+	node, err := editor.Get("plugins.items[name=agent].required")
+	require.NoError(t, err)
+	assert.Equal(t, "true", node.Value)
+	assert.Contains(t, string(editor.Bytes()), "  items:\n    - name: agent\n      required: true\n")
+	assert.Contains(t, string(editor.Bytes()), "# 自定义包", "comments survive a rewrite")
+
+	assert.ErrorContains(t, editor.Add("service.name", pkg), "not a list")
+}
+
+func TestEditor_SetErrors(t *testing.T) {
+	editor := newSampleEditor(t)
+
+	assert.ErrorContains(t, editor.Set("service.ports[3].port", ParseValue("1")), "out of range")
+	assert.ErrorContains(t, editor.Set("service.ports[name=grpc].port", ParseValue("1")), "not found")
+	assert.ErrorContains(t, editor.Set("language.version.major", ParseValue("1")), "not a mapping")
+	assert.Equal(t, editorSample, string(editor.Bytes()), "failed edits leave the document untouched")
+
+	_, err := NewEditor([]byte("- a\n- b\n"))
+	assert.ErrorContains(t, err, "top level must be a mapping")
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		text  string
+		tag   string
+		value string
+	}{
+		{"8080", "!!int", "8080"},
+		{"true", "!!bool", "true"},
+		{"api", "!!str", "api"},
+		{`"8080"`, "!!str", "8080"},
+		{"@builders.go_1.23", "!!str", "@builders.go_1.23"},
+		{"echo a: b", "!!str", "echo a: b"},
+		{"make # build", "!!str", "make # build"},
+		{"- a", "!!str", "- a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			node := ParseValue(tt.text)
+			assert.Equal(t, yaml.ScalarNode, node.Kind)
+			assert.Equal(t, tt.tag, node.Tag)
+			assert.Equal(t, tt.value, node.Value)
+		})
+	}
+
+	node := ParseValue("[a, b]")
+	assert.Equal(t, yaml.SequenceNode, node.Kind)
+	assert.Len(t, node.Content, 2)
+}
+
+func TestParseItem(t *testing.T) {
+	item, err := ParseItem("name=grpc, port=9000")
+	require.NoError(t, err)
+	text, err := FormatNode(item)
+	require.NoError(t, err)
+	assert.Equal(t, "name: grpc\nport: 9000", text)
+
+	item, err = ParseItem("{name: grpc, port: 9000}")
+	require.NoError(t, err)
+	assert.Equal(t, yaml.Style(0), item.Style, "flow items are written in block style")
+
+	item, err = ParseItem("git")
+	require.NoError(t, err)
+	assert.Equal(t, "git", item.Value)
+
+	_, err = ParseItem("name=a,=b")
+	assert.Error(t, err)
+	_, err = ParseItem("name=a,name=b")
+	assert.ErrorContains(t, err, "duplicate key name")
+	_, err = ParseItem("{name: ")
+	assert.Error(t, err)
+}
+
+func replaceOnce(t *testing.T, s, old, new string) string {
+	t.Helper()
+	require.Contains(t, s, old)
+	return strings.Replace(s, old, new, 1)
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ExternalGeneratorPrefix 外部生成器可执行文件的名称前缀（svcgen-gen-<name>，类似 protoc-gen-<name>）
+const ExternalGeneratorPrefix = "svcgen-gen-"
+
+// externalGeneratorName 外部生成器名称（可执行文件名去掉 svcgen-gen- 前缀）
+var externalGeneratorName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// GeneratorsConfig 外部生成器配置
+// 外部生成器是独立的可执行文件，从 stdin 读取 JSON 请求（解析后的配置、变量和路径），
+// 向 stdout 输出要生成的文件列表，生成的文件与内置文件一样写入输出目录
+//
+//	generators:
+//	  discover: true                     # 同时运行 PATH 上所有 svcgen-gen-* 可执行文件
+//	  items:
+//	    - name: catalog                  # 运行 PATH 上的 svcgen-gen-catalog
+//	    - name: deploy
+//	      command: ./tools/gen-deploy    # 相对 service.yaml 所在目录
+//	      args: ["--env", "prod"]
+//	      options:                       # 原样传给生成器（请求中的 options）
+//	        team: infra
+type GeneratorsConfig struct {
+	// Discover 为 true 时运行 PATH 上所有 svcgen-gen-* 可执行文件（items 中已列出的除外）
+	Discover bool `yaml:"discover,omitempty"`
+	// Items 显式配置的外部生成器，按顺序运行
+	Items []ExternalGeneratorConfig `yaml:"items,omitempty"`
+}
+
+// ExternalGeneratorConfig 单个外部生成器
+type ExternalGeneratorConfig struct {
+	// Name 生成器名称，command 为空时运行 PATH 上的 svcgen-gen-<name>
+	Name string `yaml:"name"`
+	// Command 可执行文件路径（含路径分隔符时相对 service.yaml 所在目录）
+	Command string `yaml:"command,omitempty"`
+	// Args 额外的命令行参数
+	Args []string `yaml:"args,omitempty"`
+	// Options 生成器自定义参数，原样放入请求
+	Options map[string]interface{} `yaml:"options,omitempty"`
+}
+
+// IsEmpty 检查是否未配置任何外部生成器
+func (g *GeneratorsConfig) IsEmpty() bool {
+	return !g.Discover && len(g.Items) == 0
+}
+
+// Executable 返回生成器要执行的命令（command 为空时为 svcgen-gen-<name>）
+func (g *ExternalGeneratorConfig) Executable() string {
+	if g.Command != "" {
+		return g.Command
+	}
+	return ExternalGeneratorPrefix + g.Name
+}
+
+// ResolvePaths 将含路径分隔符的相对 command 转换为基于 baseDir 的路径
+// 不含路径分隔符的 command 保持不变，运行时在 PATH 中查找
+func (g *GeneratorsConfig) ResolvePaths(baseDir string) {
+	for i := range g.Items {
+		command := g.Items[i].Command
+		if command != "" && !filepath.IsAbs(command) && strings.ContainsRune(filepath.ToSlash(command), '/') {
+			g.Items[i].Command = filepath.Join(baseDir, command)
+		}
+	}
+}
+
+// Validate 检查外部生成器名称
+func (g *GeneratorsConfig) Validate() []string {
+	var errors []string
+	seen := map[string]bool{}
+	for i, item := range g.Items {
+		switch {
+		case item.Name == "":
+			errors = append(errors, fmt.Sprintf("generators.items[%d].name is required", i))
+		case !externalGeneratorName.MatchString(item.Name):
+			errors = append(errors, fmt.Sprintf("generators.items[%d].name %q must match [a-z0-9][a-z0-9_-]*", i, item.Name))
+		case seen[item.Name]:
+			errors = append(errors, fmt.Sprintf("generators.items[%d].name %q is duplicated", i, item.Name))
+		}
+		seen[item.Name] = true
+	}
+	return errors
+}
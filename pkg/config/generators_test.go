@@ -0,0 +1,59 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternalGeneratorConfig_Executable(t *testing.T) {
+	assert.Equal(t, "svcgen-gen-catalog", (&ExternalGeneratorConfig{Name: "catalog"}).Executable())
+	assert.Equal(t, "./tools/gen", (&ExternalGeneratorConfig{Name: "catalog", Command: "./tools/gen"}).Executable())
+}
+
+func TestGeneratorsConfig_ResolvePaths(t *testing.T) {
+	cfg := GeneratorsConfig{Items: []ExternalGeneratorConfig{
+		{Name: "local", Command: "tools/gen-local"},
+		{Name: "abs", Command: "/usr/local/bin/gen-abs"},
+		{Name: "path", Command: "gen-on-path"},
+		{Name: "default"},
+	}}
+	cfg.ResolvePaths("/project")
+
+	assert.Equal(t, filepath.Join("/project", "tools/gen-local"), cfg.Items[0].Command)
+	assert.Equal(t, "/usr/local/bin/gen-abs", cfg.Items[1].Command)
+	assert.Equal(t, "gen-on-path", cfg.Items[2].Command)
+	assert.Empty(t, cfg.Items[3].Command)
+}
+
+func TestGeneratorsConfig_Validate(t *testing.T) {
+	cfg := GeneratorsConfig{Items: []ExternalGeneratorConfig{
+		{Name: "catalog"},
+		{Name: ""},
+		{Name: "Bad Name"},
+		{Name: "catalog"},
+	}}
+	errs := cfg.Validate()
+	require.Len(t, errs, 3)
+	assert.Contains(t, errs[0], "generators.items[1].name is required")
+	assert.Contains(t, errs[1], `generators.items[2].name "Bad Name" must match`)
+	assert.Contains(t, errs[2], `generators.items[3].name "catalog" is duplicated`)
+
+	assert.Empty(t, (&GeneratorsConfig{Discover: true}).Validate())
+}
+
+func TestLoader_ResolvesGeneratorCommands(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "service.yaml")
+	content := "service:\n  name: demo\ngenerators:\n  items:\n    - name: deploy\n      command: ./tools/gen-deploy\n      options:\n        team: infra\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+
+	cfg, err := NewLoader(path).Load()
+	require.NoError(t, err)
+	require.Len(t, cfg.Generators.Items, 1)
+	assert.Equal(t, filepath.Join(dir, "tools/gen-deploy"), cfg.Generators.Items[0].Command)
+	assert.Equal(t, "infra", cfg.Generators.Items[0].Options["team"])
+}
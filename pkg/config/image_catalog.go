@@ -0,0 +1,143 @@
+package config
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EmbeddedCatalogPrefix 内置镜像目录的导入前缀，如 "embedded:org"
+const EmbeddedCatalogPrefix = "embedded:"
+
+//go:embed catalogs/*.yaml
+var embeddedCatalogs embed.FS
+
+// catalogNamespacePattern 目录命名空间格式（作为预设名前缀，如 "org/go_1.23"）
+var catalogNamespacePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ImageCatalog 共享的镜像预设目录（由 base_images.imports 引入）
+// 目录中的预设以 "<namespace>/<name>" 形式合并到 base_images，如 @builders.org/go_1.23
+type ImageCatalog struct {
+	Namespace   string                     `yaml:"namespace"`
+	Description string                     `yaml:"description,omitempty"`
+	Builders    map[string]ArchImageConfig `yaml:"builders,omitempty"`
+	Runtimes    map[string]ArchImageConfig `yaml:"runtimes,omitempty"`
+}
+
+// ListEmbeddedCatalogs 列出所有内置目录的导入地址（排序）
+func ListEmbeddedCatalogs() []string {
+	entries, err := embeddedCatalogs.ReadDir("catalogs")
+	if err != nil {
+		return nil
+	}
+	sources := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		sources = append(sources, EmbeddedCatalogPrefix+strings.TrimSuffix(entry.Name(), ".yaml"))
+	}
+	sort.Strings(sources)
+	return sources
+}
+
+// LoadImageCatalog 加载镜像目录
+// source 为 "embedded:<name>" 时读取内置目录，否则按本地路径读取（相对路径基于 baseDir）
+func LoadImageCatalog(source, baseDir string) (*ImageCatalog, error) {
+	var data []byte
+	var err error
+	if name, ok := strings.CutPrefix(source, EmbeddedCatalogPrefix); ok {
+		data, err = embeddedCatalogs.ReadFile(path.Join("catalogs", name+".yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("embedded catalog '%s' not found (available: %s)",
+				name, strings.Join(ListEmbeddedCatalogs(), ", "))
+		}
+	} else {
+		file := source
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(baseDir, file)
+		}
+		data, err = os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read image catalog: %w", err)
+		}
+	}
+
+	var catalog ImageCatalog
+	if err := yaml.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse image catalog %s: %w", source, err)
+	}
+	if !catalogNamespacePattern.MatchString(catalog.Namespace) {
+		return nil, fmt.Errorf("image catalog %s: namespace %q is invalid (expected lowercase letters, digits, '_' or '-')",
+			source, catalog.Namespace)
+	}
+	return &catalog, nil
+}
+
+// ResolveImports 加载 imports 中的目录并合并到 Builders / Runtimes（只执行一次）
+// 预设名加上目录命名空间前缀；同名预设（包括两个目录使用相同命名空间）视为冲突
+func (b *BaseImagesConfig) ResolveImports(baseDir string) error {
+	if b.importsResolved {
+		return nil
+	}
+
+	builderOrigins := make(map[string]string, len(b.Builders))
+	runtimeOrigins := make(map[string]string, len(b.Runtimes))
+	for name := range b.Builders {
+		if strings.Contains(name, "/") {
+			return fmt.Errorf("base_images.builders.%s: '/' is reserved for imported catalog namespaces", name)
+		}
+		builderOrigins[name] = "base_images"
+	}
+	for name := range b.Runtimes {
+		if strings.Contains(name, "/") {
+			return fmt.Errorf("base_images.runtimes.%s: '/' is reserved for imported catalog namespaces", name)
+		}
+		runtimeOrigins[name] = "base_images"
+	}
+
+	for _, source := range b.Imports {
+		catalog, err := LoadImageCatalog(source, baseDir)
+		if err != nil {
+			return fmt.Errorf("base_images.imports: %w", err)
+		}
+		if err := mergeCatalogPresets(&b.Builders, &b.importedBuilders, builderOrigins, catalog, catalog.Builders, source, "builder"); err != nil {
+			return err
+		}
+		if err := mergeCatalogPresets(&b.Runtimes, &b.importedRuntimes, runtimeOrigins, catalog, catalog.Runtimes, source, "runtime"); err != nil {
+			return err
+		}
+	}
+
+	b.importsResolved = true
+	return nil
+}
+
+// mergeCatalogPresets 将目录中的一类预设合并到 dst，imported 记录合并进来的预设名，
+// origins 记录每个预设的来源用于冲突提示
+func mergeCatalogPresets(dst *map[string]ArchImageConfig, imported *map[string]bool, origins map[string]string,
+	catalog *ImageCatalog, presets map[string]ArchImageConfig, source, kind string) error {
+	if len(presets) == 0 {
+		return nil
+	}
+	if *dst == nil {
+		*dst = make(map[string]ArchImageConfig, len(presets))
+	}
+	if *imported == nil {
+		*imported = make(map[string]bool, len(presets))
+	}
+	for name, images := range presets {
+		qualified := catalog.Namespace + "/" + name
+		if origin, exists := origins[qualified]; exists {
+			return fmt.Errorf("base_images.imports: %s preset '%s' from %s conflicts with %s", kind, qualified, source, origin)
+		}
+		origins[qualified] = source
+		(*dst)[qualified] = images
+		(*imported)[qualified] = true
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func writeCatalog(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestListEmbeddedCatalogs(t *testing.T) {
+	assert.Contains(t, ListEmbeddedCatalogs(), "embedded:org")
+}
+
+func TestBaseImages_ResolveImports(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "team.yaml", `
+namespace: team
+builders:
+  go_1.23:
+    amd64: "registry.example.com/go:1.23-amd64"
+    arm64: "registry.example.com/go:1.23-arm64"
+runtimes:
+  minimal:
+    amd64: "registry.example.com/minimal:1"
+    arm64: "registry.example.com/minimal:1"
+`)
+
+	baseImages := BaseImagesConfig{
+		Imports: []string{"embedded:org", "team.yaml"},
+		Builders: map[string]ArchImageConfig{
+			"local": {AMD64: "golang:1.23", ARM64: "golang:1.23"},
+		},
+	}
+	require.NoError(t, baseImages.ResolveImports(dir))
+	// 重复调用不会重复合并
+	require.NoError(t, baseImages.ResolveImports(dir))
+
+	builder, err := baseImages.GetBuilder("team/go_1.23")
+	require.NoError(t, err)
+	assert.Equal(t, "registry.example.com/go:1.23-arm64", builder.ARM64)
+
+	_, err = baseImages.GetRuntime("org/alpine_3.18")
+	require.NoError(t, err)
+
+	builders := baseImages.ListBuilders()
+	assert.Equal(t, "local", builders[0])
+	assert.Contains(t, builders, "org/go_1.23")
+	assert.IsIncreasing(t, builders)
+
+	// 预设引用解析
+	spec := NewImageSpec("@runtimes.team/minimal")
+	runtime, err := spec.Resolve(&baseImages, "runtimes")
+	require.NoError(t, err)
+	assert.Equal(t, "registry.example.com/minimal:1", runtime.AMD64)
+}
+
+func TestBaseImages_ResolveImports_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeCatalog(t, dir, "org-copy.yaml", `
+namespace: org
+builders:
+  go_1.23:
+    amd64: "golang:1.23"
+    arm64: "golang:1.23"
+`)
+	writeCatalog(t, dir, "bad.yaml", "namespace: Bad/Name\n")
+
+	tests := []struct {
+		name       string
+		baseImages BaseImagesConfig
+		wantErr    string
+	}{
+		{
+			name:       "conflicting namespaced presets",
+			baseImages: BaseImagesConfig{Imports: []string{"embedded:org", "org-copy.yaml"}},
+			wantErr:    "builder preset 'org/go_1.23' from org-copy.yaml conflicts with embedded:org",
+		},
+		{
+			name:       "unknown embedded catalog",
+			baseImages: BaseImagesConfig{Imports: []string{"embedded:missing"}},
+			wantErr:    "embedded catalog 'missing' not found",
+		},
+		{
+			name:       "missing local catalog",
+			baseImages: BaseImagesConfig{Imports: []string{"missing.yaml"}},
+			wantErr:    "failed to read image catalog",
+		},
+		{
+			name:       "invalid namespace",
+			baseImages: BaseImagesConfig{Imports: []string{"bad.yaml"}},
+			wantErr:    "namespace \"Bad/Name\" is invalid",
+		},
+		{
+			name: "local preset uses reserved separator",
+			baseImages: BaseImagesConfig{Runtimes: map[string]ArchImageConfig{
+				"org/alpine": {AMD64: "alpine", ARM64: "alpine"},
+			}},
+			wantErr: "'/' is reserved",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.baseImages.ResolveImports(dir), tt.wantErr)
+		})
+	}
+}
+
+func TestBaseImages_MarshalSkipsImportedPresets(t *testing.T) {
+	baseImages := BaseImagesConfig{
+		Imports: []string{"embedded:org"},
+		Builders: map[string]ArchImageConfig{
+			"local": {AMD64: "golang:1.23", ARM64: "golang:1.23"},
+		},
+	}
+	require.NoError(t, baseImages.ResolveImports("."))
+
+	data, err := yaml.Marshal(&ServiceConfig{BaseImages: baseImages})
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "embedded:org")
+	assert.Contains(t, string(data), "local:")
+	assert.NotContains(t, string(data), "org/go_1.23")
+}
+
+func TestLoader_ImportsRelativeToConfig(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "shared"), 0755))
+	writeCatalog(t, filepath.Join(dir, "shared"), "images.yaml", `
+namespace: shared
+runtimes:
+  base:
+    amd64: "registry.example.com/base:1"
+    arm64: "registry.example.com/base:1"
+`)
+	writeCatalog(t, dir, "service.yaml", `
+base_images:
+  imports: ["shared/images.yaml"]
+service:
+  name: demo
+language:
+  type: go
+build:
+  runtime_image: "@runtimes.shared/base"
+`)
+
+	cfg, err := NewLoader(filepath.Join(dir, "service.yaml")).Load()
+	require.NoError(t, err)
+
+	runtime, err := ResolveRuntimeImageWithDefaults(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "registry.example.com/base:1", runtime.AMD64)
+}
@@ -29,8 +29,46 @@ func HasDefaultImages(langType string) bool {
 }
 
 // ResolveBuilderImageWithDefaults 解析构建镜像，支持自动推导
-// 优先级：用户显式配置 > 按语言推导
+// 优先级：用户显式配置 > 按语言推导；结果会应用 registry_mirrors 改写，
+// 存在 images.lock.yaml 时返回 image@sha256:... 引用
 func ResolveBuilderImageWithDefaults(cfg *ServiceConfig) (ArchImageConfig, error) {
+	images, err := resolveBuilderImage(cfg)
+	if err != nil {
+		return ArchImageConfig{}, err
+	}
+	return cfg.ImageLock.PinArch(images), nil
+}
+
+// ResolveRuntimeImageWithDefaults 解析运行时镜像，支持自动推导
+// 结果会应用 registry_mirrors 改写，存在 images.lock.yaml 时返回 image@sha256:... 引用
+func ResolveRuntimeImageWithDefaults(cfg *ServiceConfig) (ArchImageConfig, error) {
+	images, err := resolveRuntimeImage(cfg)
+	if err != nil {
+		return ArchImageConfig{}, err
+	}
+	return cfg.ImageLock.PinArch(images), nil
+}
+
+// resolveBuilderImage 解析构建镜像并应用镜像源改写（不应用 digest 锁定）
+func resolveBuilderImage(cfg *ServiceConfig) (ArchImageConfig, error) {
+	images, err := resolveBuilderImageSpec(cfg)
+	if err != nil {
+		return ArchImageConfig{}, err
+	}
+	return cfg.RegistryMirrors.RewriteArch(images), nil
+}
+
+// resolveRuntimeImage 解析运行时镜像并应用镜像源改写（不应用 digest 锁定）
+func resolveRuntimeImage(cfg *ServiceConfig) (ArchImageConfig, error) {
+	images, err := resolveRuntimeImageSpec(cfg)
+	if err != nil {
+		return ArchImageConfig{}, err
+	}
+	return cfg.RegistryMirrors.RewriteArch(images), nil
+}
+
+// resolveBuilderImageSpec 解析构建镜像的原始引用
+func resolveBuilderImageSpec(cfg *ServiceConfig) (ArchImageConfig, error) {
 	if !cfg.Build.BuilderImage.IsEmpty() {
 		return cfg.Build.BuilderImage.Resolve(&cfg.BaseImages, "builders")
 	}
@@ -42,11 +80,17 @@ func ResolveBuilderImageWithDefaults(cfg *ServiceConfig) (ArchImageConfig, error
 	return ArchImageConfig{AMD64: image, ARM64: image}, nil
 }
 
-// ResolveRuntimeImageWithDefaults 解析运行时镜像，支持自动推导
-func ResolveRuntimeImageWithDefaults(cfg *ServiceConfig) (ArchImageConfig, error) {
+// resolveRuntimeImageSpec 解析运行时镜像的原始引用
+func resolveRuntimeImageSpec(cfg *ServiceConfig) (ArchImageConfig, error) {
 	if !cfg.Build.RuntimeImage.IsEmpty() {
 		return cfg.Build.RuntimeImage.Resolve(&cfg.BaseImages, "runtimes")
 	}
+	// shell-less 模式下，静态编译语言默认使用 distroless 运行时
+	if cfg.Runtime.ShellLess {
+		if image, ok := defaultShellLessRuntimeImages[cfg.Language.Type]; ok {
+			return ArchImageConfig{AMD64: image, ARM64: image}, nil
+		}
+	}
 	// 未指定，按语言推导
 	image, err := DefaultRuntimeImage(cfg.Language.Type, &cfg.Language)
 	if err != nil {
@@ -61,16 +105,16 @@ func ResolveRuntimeImageWithDefaults(cfg *ServiceConfig) (ArchImageConfig, error
 
 var defaultBuilderImageFuncs = map[string]func(cfg *LanguageConfig) string{
 	"go": func(cfg *LanguageConfig) string {
-		version := cfg.GetString("go_version", "1.23")
+		version := cfg.GetVersion()
 		return fmt.Sprintf("golang:%s-alpine", version)
 	},
 	"python": func(cfg *LanguageConfig) string {
-		version := cfg.GetString("python_version", "3.12")
+		version := cfg.GetVersion()
 		return fmt.Sprintf("python:%s-slim", version)
 	},
 	"java": func(cfg *LanguageConfig) string {
 		buildTool := cfg.GetString("build_tool", "maven")
-		jdkVersion := cfg.GetString("jdk_version", "21")
+		jdkVersion := cfg.GetVersion()
 		if buildTool == "gradle" {
 			gradleVersion := cfg.GetString("gradle_version", "8")
 			return fmt.Sprintf("gradle:%s-jdk%s", gradleVersion, jdkVersion)
@@ -78,11 +122,11 @@ var defaultBuilderImageFuncs = map[string]func(cfg *LanguageConfig) string{
 		return fmt.Sprintf("maven:3-eclipse-temurin-%s", jdkVersion)
 	},
 	"nodejs": func(cfg *LanguageConfig) string {
-		version := cfg.GetString("node_version", "20")
+		version := cfg.GetVersion()
 		return fmt.Sprintf("node:%s-alpine", version)
 	},
 	"rust": func(cfg *LanguageConfig) string {
-		version := cfg.GetString("rust_version", "1.78")
+		version := cfg.GetVersion()
 		return fmt.Sprintf("rust:%s-alpine", version)
 	},
 }
@@ -94,17 +138,17 @@ var defaultRuntimeImageFuncs = map[string]func(cfg *LanguageConfig) string{
 	},
 	"python": func(cfg *LanguageConfig) string {
 		// Python 运行时需要 Python 环境
-		version := cfg.GetString("python_version", "3.12")
+		version := cfg.GetVersion()
 		return fmt.Sprintf("python:%s-slim", version)
 	},
 	"java": func(cfg *LanguageConfig) string {
 		// Java 运行时只需要 JRE
-		jdkVersion := cfg.GetString("jdk_version", "21")
+		jdkVersion := cfg.GetVersion()
 		return fmt.Sprintf("eclipse-temurin:%s-jre-alpine", jdkVersion)
 	},
 	"nodejs": func(cfg *LanguageConfig) string {
 		// Node.js 运行时需要 Node 环境
-		version := cfg.GetString("node_version", "20")
+		version := cfg.GetVersion()
 		return fmt.Sprintf("node:%s-alpine", version)
 	},
 	"rust": func(cfg *LanguageConfig) string {
@@ -112,3 +156,9 @@ var defaultRuntimeImageFuncs = map[string]func(cfg *LanguageConfig) string{
 		return "alpine:3.19"
 	},
 }
+
+// defaultShellLessRuntimeImages shell-less 模式下的默认运行时镜像（仅静态编译语言）
+var defaultShellLessRuntimeImages = map[string]string{
+	"go":   "gcr.io/distroless/static-debian12",
+	"rust": "gcr.io/distroless/static-debian12",
+}
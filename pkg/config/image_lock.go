@@ -0,0 +1,125 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ImageLockFileName 镜像 digest 锁定文件名（与 service.yaml 同目录）
+const ImageLockFileName = "images.lock.yaml"
+
+// ImageLock 镜像 digest 锁定文件（由 svcgen images lock 生成）
+// 生成时所有镜像引用都会被替换为 image@sha256:... 形式，避免 latest 等可变 tag 漂移
+type ImageLock struct {
+	// Images 镜像引用 → manifest digest（如 "alpine:3.19" → "sha256:..."）
+	Images map[string]string `yaml:"images"`
+}
+
+// LoadImageLock 读取锁定文件，文件不存在时返回 (nil, nil)
+func LoadImageLock(path string) (*ImageLock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read image lock file: %w", err)
+	}
+
+	var lock ImageLock
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse image lock file %s: %w", path, err)
+	}
+	for ref, digest := range lock.Images {
+		if !strings.HasPrefix(digest, "sha256:") {
+			return nil, fmt.Errorf("invalid digest for %s in %s: %q", ref, path, digest)
+		}
+	}
+	return &lock, nil
+}
+
+// Save 写入锁定文件（map 按 key 排序输出，结果稳定）
+func (l *ImageLock) Save(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return fmt.Errorf("failed to marshal image lock: %w", err)
+	}
+
+	content := "# Generated by `svcgen images lock`. DO NOT EDIT.\n" +
+		"# Re-run the command to refresh digests after changing images.\n" + string(data)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write image lock file: %w", err)
+	}
+	return nil
+}
+
+// Pin 返回锁定后的镜像引用（image@sha256:...）
+// 未锁定、已带 digest 或 scratch 时原样返回
+func (l *ImageLock) Pin(ref string) string {
+	if l == nil || ref == "" || strings.Contains(ref, "@") {
+		return ref
+	}
+	digest, ok := l.Images[ref]
+	if !ok {
+		return ref
+	}
+	return ref + "@" + digest
+}
+
+// PinArch 对两个架构的镜像分别应用 Pin
+func (l *ImageLock) PinArch(images ArchImageConfig) ArchImageConfig {
+	return ArchImageConfig{
+		AMD64: l.Pin(images.AMD64),
+		ARM64: l.Pin(images.ARM64),
+	}
+}
+
+// ImageLockPath 返回 service.yaml 对应的锁定文件路径
+func ImageLockPath(configPath string) string {
+	return filepath.Join(filepath.Dir(configPath), ImageLockFileName)
+}
+
+// LockableImages 收集需要锁定的全部镜像引用（去重、排序）
+// 包括生效的构建/运行时镜像（含按语言推导的默认值）以及 base_images 中的所有预设
+// 解析时忽略已有的锁定信息，返回应用 registry_mirrors 改写后的引用（与生成时 Pin 查找的 key 一致）
+func LockableImages(cfg *ServiceConfig) ([]string, error) {
+	builder, err := resolveBuilderImage(cfg)
+	if err != nil {
+		return nil, err
+	}
+	runtime, err := resolveRuntimeImage(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(map[string]struct{})
+	add := func(images ArchImageConfig) {
+		for _, ref := range []string{images.AMD64, images.ARM64} {
+			// scratch 不是真实镜像；已带 digest 的引用无需再锁定
+			if ref == "" || ref == "scratch" || strings.Contains(ref, "@") {
+				continue
+			}
+			set[ref] = struct{}{}
+		}
+	}
+
+	add(builder)
+	add(runtime)
+	for _, images := range cfg.BaseImages.Builders {
+		add(cfg.RegistryMirrors.RewriteArch(images))
+	}
+	for _, images := range cfg.BaseImages.Runtimes {
+		add(cfg.RegistryMirrors.RewriteArch(images))
+	}
+
+	refs := make([]string, 0, len(set))
+	for ref := range set {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs, nil
+}
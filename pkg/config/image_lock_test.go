@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageLock_Pin(t *testing.T) {
+	lock := &ImageLock{Images: map[string]string{"alpine:3.19": "sha256:aaa"}}
+
+	assert.Equal(t, "alpine:3.19@sha256:aaa", lock.Pin("alpine:3.19"))
+	assert.Equal(t, "golang:1.23", lock.Pin("golang:1.23"), "unlocked image is returned as-is")
+	assert.Equal(t, "alpine@sha256:bbb", lock.Pin("alpine@sha256:bbb"), "digest reference is returned as-is")
+
+	var nilLock *ImageLock
+	assert.Equal(t, "alpine:3.19", nilLock.Pin("alpine:3.19"))
+}
+
+func TestImageLock_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ImageLockFileName)
+
+	lock := &ImageLock{Images: map[string]string{
+		"golang:1.23-alpine": "sha256:bbb",
+		"alpine:3.19":        "sha256:aaa",
+	}}
+	require.NoError(t, lock.Save(path))
+
+	loaded, err := LoadImageLock(path)
+	require.NoError(t, err)
+	assert.Equal(t, lock.Images, loaded.Images)
+
+	missing, err := LoadImageLock(filepath.Join(t.TempDir(), ImageLockFileName))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	require.NoError(t, os.WriteFile(path, []byte("images:\n  alpine:3.19: latest\n"), 0644))
+	_, err = LoadImageLock(path)
+	assert.ErrorContains(t, err, "invalid digest")
+}
+
+func TestLockableImages(t *testing.T) {
+	cfg := &ServiceConfig{
+		Language: LanguageConfig{Type: "go"},
+		BaseImages: BaseImagesConfig{
+			Runtimes: map[string]ArchImageConfig{
+				"minimal": {AMD64: "mirrors.example.com/os/minimal:latest", ARM64: "mirrors.example.com/os/minimal-arm:latest"},
+			},
+		},
+		// 已有锁定信息不影响收集结果
+		ImageLock: &ImageLock{Images: map[string]string{"alpine:3.19": "sha256:aaa"}},
+	}
+
+	refs, err := LockableImages(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"alpine:3.19",
+		"golang:1.23-alpine",
+		"mirrors.example.com/os/minimal-arm:latest",
+		"mirrors.example.com/os/minimal:latest",
+	}, refs)
+}
+
+func TestResolveImagesWithDefaults_AppliesLock(t *testing.T) {
+	cfg := &ServiceConfig{
+		Language:  LanguageConfig{Type: "go"},
+		ImageLock: &ImageLock{Images: map[string]string{"golang:1.23-alpine": "sha256:bbb"}},
+	}
+
+	builder, err := ResolveBuilderImageWithDefaults(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "golang:1.23-alpine@sha256:bbb", builder.AMD64)
+	assert.Equal(t, "golang:1.23-alpine@sha256:bbb", builder.ARM64)
+
+	runtime, err := ResolveRuntimeImageWithDefaults(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "alpine:3.19", runtime.AMD64, "runtime image not in lock stays unpinned")
+}
+
+func TestLoader_LoadsImageLock(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "service.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("service:\n  name: demo\nlanguage:\n  type: go\n"), 0644))
+
+	cfg, err := NewLoader(configPath).Load()
+	require.NoError(t, err)
+	assert.Nil(t, cfg.ImageLock)
+
+	lock := &ImageLock{Images: map[string]string{"alpine:3.19": "sha256:aaa"}}
+	require.NoError(t, lock.Save(ImageLockPath(configPath)))
+
+	cfg, err = NewLoader(configPath).Load()
+	require.NoError(t, err)
+	require.NotNil(t, cfg.ImageLock)
+	assert.Equal(t, "sha256:aaa", cfg.ImageLock.Images["alpine:3.19"])
+}
@@ -0,0 +1,291 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 镜像策略违规级别
+const (
+	PolicySeverityWarning = "warning"
+	PolicySeverityError   = "error"
+)
+
+// ImagePolicyConfig 镜像策略配置
+// 规则可以直接写在 service.yaml 中，也可以通过 file 引用团队共享的策略文件（两者合并）
+//
+//	image_policy:
+//	  file: ../policy/images.yaml
+//	  severity: error
+//	  forbid_latest: true
+//	  allowed_registries: [mirrors.tencent.com]
+//	  allowed_runtime_images: [mirrors.tencent.com/tencentos/*]
+//	  require_arch_complete: true
+type ImagePolicyConfig struct {
+	// File 策略文件路径（相对 service.yaml），内容为 ImagePolicyRules
+	File             string `yaml:"file,omitempty"`
+	ImagePolicyRules `yaml:",inline"`
+}
+
+// ImagePolicyRules 镜像策略规则
+type ImagePolicyRules struct {
+	// Severity 违规级别：warning（默认）| error；svcgen validate --strict 时一律视为 error
+	Severity string `yaml:"severity,omitempty"`
+	// ForbidLatest 禁止 :latest 或未写 tag 的镜像（已锁定 digest 的引用不受限制）
+	ForbidLatest bool `yaml:"forbid_latest,omitempty"`
+	// AllowedRegistries 允许的 registry（或 registry/路径 前缀），如 "mirrors.tencent.com"、"docker.io/library"
+	AllowedRegistries []string `yaml:"allowed_registries,omitempty"`
+	// AllowedRuntimeImages 允许的运行时镜像（支持 * 通配），不写 tag 时匹配所有 tag
+	AllowedRuntimeImages []string `yaml:"allowed_runtime_images,omitempty"`
+	// RequireArchComplete 要求所有镜像同时配置 amd64 与 arm64
+	RequireArchComplete bool `yaml:"require_arch_complete,omitempty"`
+}
+
+// PolicyViolation 一条镜像策略违规
+type PolicyViolation struct {
+	Subject  string // 配置位置，如 build.runtime_image (arm64)
+	Image    string
+	Rule     string // 规则名，如 forbid_latest
+	Message  string
+	Severity string
+}
+
+// String 返回可读的违规描述
+func (p PolicyViolation) String() string {
+	if p.Image == "" {
+		return fmt.Sprintf("%s: %s (image_policy.%s)", p.Subject, p.Message, p.Rule)
+	}
+	return fmt.Sprintf("%s: %s %s (image_policy.%s)", p.Subject, p.Image, p.Message, p.Rule)
+}
+
+// IsEmpty 检查是否未配置任何规则
+func (p *ImagePolicyConfig) IsEmpty() bool {
+	return !p.ForbidLatest && !p.RequireArchComplete &&
+		len(p.AllowedRegistries) == 0 && len(p.AllowedRuntimeImages) == 0
+}
+
+// Validate 验证策略配置本身
+func (p *ImagePolicyConfig) Validate() error {
+	switch p.Severity {
+	case "", PolicySeverityWarning, PolicySeverityError:
+	default:
+		return fmt.Errorf("severity '%s' is not valid (valid: warning, error)", p.Severity)
+	}
+	for _, pattern := range p.AllowedRuntimeImages {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("allowed_runtime_images: invalid pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// ResolveFile 加载 file 引用的策略文件并与内联规则合并（相对路径基于 baseDir）
+// 列表规则取并集，开关规则任一开启即生效，内联 severity 优先
+func (p *ImagePolicyConfig) ResolveFile(baseDir string) error {
+	if p.File == "" {
+		return nil
+	}
+	file := p.File
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(baseDir, file)
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read image policy file: %w", err)
+	}
+	var rules ImagePolicyRules
+	if err := yaml.Unmarshal(data, &rules); err != nil {
+		return fmt.Errorf("failed to parse image policy file %s: %w", p.File, err)
+	}
+
+	if p.Severity == "" {
+		p.Severity = rules.Severity
+	}
+	p.ForbidLatest = p.ForbidLatest || rules.ForbidLatest
+	p.RequireArchComplete = p.RequireArchComplete || rules.RequireArchComplete
+	p.AllowedRegistries = append(rules.AllowedRegistries, p.AllowedRegistries...)
+	p.AllowedRuntimeImages = append(rules.AllowedRuntimeImages, p.AllowedRuntimeImages...)
+	p.File = ""
+	return nil
+}
+
+// policySubject 一个待检查的镜像配置位置
+type policySubject struct {
+	name    string
+	images  ArchImageConfig
+	runtime bool // 是否为生效的运行时镜像（检查 allowed_runtime_images）
+}
+
+// EvaluateImagePolicy 按 image_policy 检查所有生效镜像
+// 包括构建/运行时镜像（含语言默认值、镜像源改写与 digest 锁定）、base_images 中本地定义的预设，
+// 以及 imports 引入且被 builder_image / runtime_image 引用的目录预设（未引用的目录预设由目录维护方负责）
+// 镜像引用本身无法解析时只跳过该引用（由 Validator 的引用校验报告）
+func EvaluateImagePolicy(cfg *ServiceConfig) []PolicyViolation {
+	policy := &cfg.ImagePolicy
+	if policy.IsEmpty() {
+		return nil
+	}
+	severity := policy.Severity
+	if severity == "" {
+		severity = PolicySeverityWarning
+	}
+
+	var subjects []policySubject
+	if builder, err := ResolveBuilderImageWithDefaults(cfg); err == nil {
+		subjects = append(subjects, policySubject{name: "build.builder_image", images: builder})
+	}
+	if runtime, err := ResolveRuntimeImageWithDefaults(cfg); err == nil {
+		subjects = append(subjects, policySubject{name: "build.runtime_image", images: runtime, runtime: true})
+	}
+	usedBuilder := referencedPreset(&cfg.Build.BuilderImage, "builders")
+	for _, name := range cfg.BaseImages.ListBuilders() {
+		if cfg.BaseImages.importedBuilders[name] && name != usedBuilder {
+			continue
+		}
+		subjects = append(subjects, policySubject{
+			name:   "base_images.builders." + name,
+			images: cfg.ImageLock.PinArch(cfg.RegistryMirrors.RewriteArch(cfg.BaseImages.Builders[name])),
+		})
+	}
+	usedRuntime := referencedPreset(&cfg.Build.RuntimeImage, "runtimes")
+	for _, name := range cfg.BaseImages.ListRuntimes() {
+		if cfg.BaseImages.importedRuntimes[name] && name != usedRuntime {
+			continue
+		}
+		subjects = append(subjects, policySubject{
+			name:   "base_images.runtimes." + name,
+			images: cfg.ImageLock.PinArch(cfg.RegistryMirrors.RewriteArch(cfg.BaseImages.Runtimes[name])),
+		})
+	}
+
+	var violations []PolicyViolation
+	for _, subject := range subjects {
+		for _, v := range policy.evaluate(subject) {
+			v.Severity = severity
+			violations = append(violations, v)
+		}
+	}
+	return violations
+}
+
+// referencedPreset 返回镜像配置引用的 category 预设名；不是（合法的）预设引用时返回空字符串
+func referencedPreset(spec *ImageSpec, category string) string {
+	if spec.Kind() != ImageSpecPreset {
+		return ""
+	}
+	refCategory, name, err := parsePresetRef(spec.String())
+	if err != nil || refCategory != category {
+		return ""
+	}
+	return name
+}
+
+// evaluate 检查单个配置位置；两个架构使用同一镜像时只报告一次
+func (p *ImagePolicyConfig) evaluate(subject policySubject) []PolicyViolation {
+	var violations []PolicyViolation
+
+	if p.RequireArchComplete && (subject.images.AMD64 == "" || subject.images.ARM64 == "") {
+		violations = append(violations, PolicyViolation{
+			Subject: subject.name,
+			Rule:    "require_arch_complete",
+			Message: "must define both amd64 and arm64 images",
+		})
+	}
+
+	archs := []struct{ arch, ref string }{{"amd64", subject.images.AMD64}, {"arm64", subject.images.ARM64}}
+	if subject.images.AMD64 == subject.images.ARM64 {
+		archs = archs[:1]
+	}
+	for _, a := range archs {
+		if a.ref == "" {
+			continue
+		}
+		name := subject.name
+		if len(archs) > 1 {
+			name = fmt.Sprintf("%s (%s)", subject.name, a.arch)
+		}
+		violations = append(violations, p.evaluateImage(name, a.ref, subject.runtime)...)
+	}
+	return violations
+}
+
+// evaluateImage 对单个镜像引用应用各条规则
+func (p *ImagePolicyConfig) evaluateImage(subject, ref string, runtime bool) []PolicyViolation {
+	var violations []PolicyViolation
+	add := func(rule, message string) {
+		violations = append(violations, PolicyViolation{Subject: subject, Image: ref, Rule: rule, Message: message})
+	}
+
+	if runtime && len(p.AllowedRuntimeImages) > 0 && !matchesRuntimeImage(ref, p.AllowedRuntimeImages) {
+		add("allowed_runtime_images", "is not an approved runtime image")
+	}
+	// scratch 不是真实镜像，不参与 tag / registry 规则
+	if ref == "scratch" {
+		return violations
+	}
+
+	repo, tag, digest := splitImageRef(canonicalImageRef(ref))
+	if p.ForbidLatest && digest == "" && (tag == "" || tag == "latest") {
+		add("forbid_latest", "uses the :latest tag (pin an explicit tag or run svcgen images lock)")
+	}
+	if len(p.AllowedRegistries) > 0 && !matchesRegistry(repo, p.AllowedRegistries) {
+		add("allowed_registries", fmt.Sprintf("is not from an approved registry (allowed: %s)", strings.Join(p.AllowedRegistries, ", ")))
+	}
+	return violations
+}
+
+// matchesRegistry 检查规范化后的仓库路径是否以某个允许的 registry（或路径前缀）开头
+func matchesRegistry(repo string, allowed []string) bool {
+	for _, registry := range allowed {
+		if hasBoundaryPrefix(repo, canonicalImagePrefix(registry), "/") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesRuntimeImage 检查镜像是否匹配允许列表
+// 模式不含 tag 时只比较仓库路径，含 tag 时比较 仓库:tag（均支持 * 通配）
+func matchesRuntimeImage(ref string, patterns []string) bool {
+	if ref == "scratch" {
+		for _, pattern := range patterns {
+			if pattern == "scratch" {
+				return true
+			}
+		}
+		return false
+	}
+
+	repo, tag, _ := splitImageRef(canonicalImageRef(ref))
+	for _, pattern := range patterns {
+		if pattern == "scratch" {
+			continue
+		}
+		patternRepo, patternTag, _ := splitImageRef(canonicalImagePrefix(pattern))
+		if ok, _ := path.Match(patternRepo, repo); !ok {
+			continue
+		}
+		if patternTag == "" {
+			return true
+		}
+		if ok, _ := path.Match(patternTag, tag); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// splitImageRef 将规范化的镜像引用拆分为 仓库路径、tag、digest
+// registry 端口中的 ":" 不会被当作 tag 分隔符
+func splitImageRef(ref string) (repo, tag, digest string) {
+	repo, digest, _ = strings.Cut(ref, "@")
+	if idx := strings.LastIndex(repo, ":"); idx > strings.LastIndex(repo, "/") {
+		repo, tag = repo[:idx], repo[idx+1:]
+	}
+	return repo, tag, digest
+}
@@ -0,0 +1,165 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func policyViolationStrings(violations []PolicyViolation) string {
+	lines := make([]string, 0, len(violations))
+	for _, v := range violations {
+		lines = append(lines, v.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestEvaluateImagePolicy(t *testing.T) {
+	cfg := &ServiceConfig{
+		Language: LanguageConfig{Type: "go"},
+		Build: BuildConfig{
+			BuilderImage: NewImageSpec("mirrors.tencent.com/build/go:1.23"),
+			RuntimeImage: NewImageSpecPerArch("mirrors.tencent.com/os/minimal:latest", "docker.io/alpine"),
+		},
+		BaseImages: BaseImagesConfig{
+			Builders: map[string]ArchImageConfig{
+				"approved": {AMD64: "mirrors.tencent.com/build/go:1.22", ARM64: "mirrors.tencent.com/build/go:1.22"},
+			},
+		},
+		ImagePolicy: ImagePolicyConfig{ImagePolicyRules: ImagePolicyRules{
+			ForbidLatest:         true,
+			AllowedRegistries:    []string{"mirrors.tencent.com"},
+			AllowedRuntimeImages: []string{"mirrors.tencent.com/os/*"},
+		}},
+	}
+
+	violations := EvaluateImagePolicy(cfg)
+	output := policyViolationStrings(violations)
+
+	assert.Contains(t, output, "build.runtime_image (amd64): mirrors.tencent.com/os/minimal:latest uses the :latest tag")
+	assert.Contains(t, output, "build.runtime_image (arm64): docker.io/alpine uses the :latest tag")
+	assert.Contains(t, output, "build.runtime_image (arm64): docker.io/alpine is not from an approved registry")
+	assert.Contains(t, output, "build.runtime_image (arm64): docker.io/alpine is not an approved runtime image")
+	assert.NotContains(t, output, "build.builder_image")
+	assert.NotContains(t, output, "base_images.builders.approved")
+	for _, v := range violations {
+		assert.Equal(t, PolicySeverityWarning, v.Severity)
+	}
+}
+
+func TestEvaluateImagePolicy_LockedLatestIsAllowed(t *testing.T) {
+	cfg := &ServiceConfig{
+		Language:    LanguageConfig{Type: "go"},
+		Build:       BuildConfig{RuntimeImage: NewImageSpec("alpine:latest")},
+		ImageLock:   &ImageLock{Images: map[string]string{"alpine:latest": "sha256:aaa"}},
+		ImagePolicy: ImagePolicyConfig{ImagePolicyRules: ImagePolicyRules{ForbidLatest: true, Severity: PolicySeverityError}},
+	}
+
+	assert.Empty(t, EvaluateImagePolicy(cfg))
+
+	cfg.ImageLock = nil
+	violations := EvaluateImagePolicy(cfg)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "forbid_latest", violations[0].Rule)
+	assert.Equal(t, PolicySeverityError, violations[0].Severity)
+}
+
+func TestEvaluateImagePolicy_ReferencedCatalogPresets(t *testing.T) {
+	cfg := &ServiceConfig{
+		Language: LanguageConfig{Type: "go"},
+		Build: BuildConfig{
+			BuilderImage: NewImageSpec("@builders.org/go_1.22"),
+			RuntimeImage: NewImageSpec("@runtimes.missing"),
+		},
+		BaseImages:  BaseImagesConfig{Imports: []string{"embedded:org"}},
+		ImagePolicy: ImagePolicyConfig{ImagePolicyRules: ImagePolicyRules{AllowedRegistries: []string{"mirrors.tencent.com"}}},
+	}
+	require.NoError(t, cfg.BaseImages.ResolveImports(t.TempDir()))
+
+	output := policyViolationStrings(EvaluateImagePolicy(cfg))
+	assert.Contains(t, output, "base_images.builders.org/go_1.22: docker.io/golang:1.22 is not from an approved registry")
+	assert.Contains(t, output, "build.builder_image: docker.io/golang:1.22 is not from an approved registry")
+	assert.NotContains(t, output, "org/python_3.11", "unreferenced catalog presets are left to the catalog owner")
+	assert.NotContains(t, output, "build.runtime_image", "unresolvable references are skipped")
+}
+
+func TestMatchesRuntimeImage(t *testing.T) {
+	tests := []struct {
+		ref      string
+		patterns []string
+		want     bool
+	}{
+		{"alpine:3.19", []string{"alpine"}, true},
+		{"alpine:3.19", []string{"docker.io/library/alpine:3.*"}, true},
+		{"alpine:3.18", []string{"alpine:3.19"}, false},
+		{"registry.local:5000/os/base:1", []string{"registry.local:5000/os/*"}, true},
+		{"gcr.io/distroless/static-debian12", []string{"gcr.io/distroless/*"}, true},
+		{"scratch", []string{"gcr.io/distroless/*"}, false},
+		{"scratch", []string{"scratch"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			assert.Equal(t, tt.want, matchesRuntimeImage(tt.ref, tt.patterns))
+		})
+	}
+}
+
+func TestImagePolicy_ResolveFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "policy.yaml"), []byte(`
+severity: error
+forbid_latest: true
+allowed_registries: [mirrors.tencent.com]
+`), 0644))
+
+	policy := ImagePolicyConfig{
+		File:             "policy.yaml",
+		ImagePolicyRules: ImagePolicyRules{Severity: PolicySeverityWarning, AllowedRegistries: []string{"ghcr.io"}},
+	}
+	require.NoError(t, policy.ResolveFile(dir))
+
+	assert.True(t, policy.ForbidLatest)
+	assert.Equal(t, PolicySeverityWarning, policy.Severity, "inline severity wins")
+	assert.Equal(t, []string{"mirrors.tencent.com", "ghcr.io"}, policy.AllowedRegistries)
+
+	missing := ImagePolicyConfig{File: "missing.yaml"}
+	assert.ErrorContains(t, missing.ResolveFile(dir), "failed to read image policy file")
+}
+
+func TestValidator_ImagePolicy(t *testing.T) {
+	newConfig := func() *ServiceConfig {
+		return &ServiceConfig{
+			Service:  ServiceInfo{Name: "demo"},
+			Language: LanguageConfig{Type: "go"},
+			Build:    BuildConfig{RuntimeImage: NewImageSpec("alpine:latest")},
+			Runtime:  RuntimeConfig{Startup: StartupConfig{Command: "./demo"}},
+			ImagePolicy: ImagePolicyConfig{ImagePolicyRules: ImagePolicyRules{
+				ForbidLatest: true,
+			}},
+		}
+	}
+
+	validator := NewValidator(newConfig())
+	require.NoError(t, validator.Validate())
+	require.Len(t, validator.Warnings(), 1)
+	assert.Contains(t, validator.Warnings()[0], "image_policy.forbid_latest")
+
+	err := NewValidator(newConfig()).WithStrict(true).Validate()
+	assert.ErrorContains(t, err, "alpine:latest uses the :latest tag")
+
+	// 无关的校验错误不影响镜像策略检查
+	cfg := newConfig()
+	cfg.Service.Name = ""
+	err = NewValidator(cfg).WithStrict(true).Validate()
+	assert.ErrorContains(t, err, "service.name is required")
+	assert.ErrorContains(t, err, "alpine:latest uses the :latest tag")
+
+	cfg = newConfig()
+	cfg.ImagePolicy.Severity = "fatal"
+	assert.ErrorContains(t, NewValidator(cfg).Validate(), "image_policy: severity 'fatal' is not valid")
+}
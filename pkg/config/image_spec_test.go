@@ -299,6 +299,16 @@ func TestImageSpec_MarshalYAML(t *testing.T) {
 		require.NoError(t, err)
 		assert.Equal(t, "{}\n", string(data))
 	})
+
+	t.Run("omitempty keeps non-empty spec", func(t *testing.T) {
+		original := struct {
+			Image ImageSpec `yaml:"image,omitempty"`
+		}{Image: NewImageSpec("golang:1.23")}
+
+		data, err := yaml.Marshal(&original)
+		require.NoError(t, err)
+		assert.Equal(t, "image: golang:1.23\n", string(data))
+	})
 }
 
 // ============================================
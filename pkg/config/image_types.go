@@ -2,14 +2,49 @@ package config
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 )
 
 // BaseImagesConfig 基础镜像配置（顶层配置）
 // 仅在使用 @builders.* / @runtimes.* 预设引用时需要配置
 type BaseImagesConfig struct {
+	// Imports 引入的共享镜像目录（本地路径或 "embedded:<name>"），预设以 <namespace>/<name> 合并
+	Imports  []string                   `yaml:"imports,omitempty"`
 	Builders map[string]ArchImageConfig `yaml:"builders,omitempty"` // 构建镜像预设
 	Runtimes map[string]ArchImageConfig `yaml:"runtimes,omitempty"` // 运行时镜像预设
+
+	// importsResolved 标记 imports 已合并，避免重复合并导致误报冲突
+	importsResolved bool
+	// importedBuilders / importedRuntimes 记录从目录合并进来的预设名，序列化时不写回
+	importedBuilders map[string]bool
+	importedRuntimes map[string]bool
+}
+
+// MarshalYAML 只输出 imports 与本地定义的预设（合并进来的目录预设不写回 service.yaml）
+func (b BaseImagesConfig) MarshalYAML() (interface{}, error) {
+	type plain struct {
+		Imports  []string                   `yaml:"imports,omitempty"`
+		Builders map[string]ArchImageConfig `yaml:"builders,omitempty"`
+		Runtimes map[string]ArchImageConfig `yaml:"runtimes,omitempty"`
+	}
+	local := func(presets map[string]ArchImageConfig, imported map[string]bool) map[string]ArchImageConfig {
+		if len(imported) == 0 {
+			return presets
+		}
+		result := make(map[string]ArchImageConfig, len(presets))
+		for name, images := range presets {
+			if !imported[name] {
+				result[name] = images
+			}
+		}
+		return result
+	}
+	return plain{
+		Imports:  b.Imports,
+		Builders: local(b.Builders, b.importedBuilders),
+		Runtimes: local(b.Runtimes, b.importedRuntimes),
+	}, nil
 }
 
 // IsEmpty 判断是否未配置任何预设
@@ -38,13 +73,9 @@ func (b *BaseImagesConfig) Validate() error {
 func (b *BaseImagesConfig) GetBuilder(name string) (ArchImageConfig, error) {
 	img, ok := b.Builders[name]
 	if !ok {
-		available := make([]string, 0, len(b.Builders))
-		for k := range b.Builders {
-			available = append(available, k)
-		}
 		return ArchImageConfig{}, fmt.Errorf(
 			"builder preset '%s' not found. Available: %v",
-			name, available,
+			name, b.ListBuilders(),
 		)
 	}
 	return img, nil
@@ -54,33 +85,30 @@ func (b *BaseImagesConfig) GetBuilder(name string) (ArchImageConfig, error) {
 func (b *BaseImagesConfig) GetRuntime(name string) (ArchImageConfig, error) {
 	img, ok := b.Runtimes[name]
 	if !ok {
-		available := make([]string, 0, len(b.Runtimes))
-		for k := range b.Runtimes {
-			available = append(available, k)
-		}
 		return ArchImageConfig{}, fmt.Errorf(
 			"runtime preset '%s' not found. Available: %v",
-			name, available,
+			name, b.ListRuntimes(),
 		)
 	}
 	return img, nil
 }
 
-// ListBuilders 列出所有构建镜像预设名称
+// ListBuilders 列出所有构建镜像预设名称（排序）
 func (b *BaseImagesConfig) ListBuilders() []string {
-	names := make([]string, 0, len(b.Builders))
-	for name := range b.Builders {
-		names = append(names, name)
-	}
-	return names
+	return sortedPresetNames(b.Builders)
 }
 
-// ListRuntimes 列出所有运行时镜像预设名称
+// ListRuntimes 列出所有运行时镜像预设名称（排序）
 func (b *BaseImagesConfig) ListRuntimes() []string {
-	names := make([]string, 0, len(b.Runtimes))
-	for name := range b.Runtimes {
+	return sortedPresetNames(b.Runtimes)
+}
+
+func sortedPresetNames(presets map[string]ArchImageConfig) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -278,6 +306,11 @@ func (s ImageSpec) MarshalYAML() (interface{}, error) {
 	return s.raw, nil
 }
 
+// IsZero 供 yaml omitempty 判断（raw 为私有字段，yaml.v3 默认会把 ImageSpec 视为零值而省略）
+func (s ImageSpec) IsZero() bool {
+	return s.raw == nil
+}
+
 // parsePresetRef 解析预设引用格式，返回 (category, name)
 func parsePresetRef(ref string) (category string, name string, err error) {
 	if !strings.HasPrefix(ref, "@") {
@@ -0,0 +1,250 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// languageVersionSpec 语言版本规格
+type languageVersionSpec struct {
+	// legacyKey 旧版 language.config 中的版本键（仍然兼容）
+	legacyKey string
+	// defaultVersion 未指定版本时使用的默认版本
+	defaultVersion string
+	// supported 已知版本（升序）：第一个为最低支持版本，比最后一个更新的版本会给出警告
+	supported []string
+}
+
+// languageVersions 各语言的已知版本表（决定最低支持版本与默认构建/运行时镜像的 tag）
+var languageVersions = map[string]languageVersionSpec{
+	"go": {
+		legacyKey:      "go_version",
+		defaultVersion: "1.23",
+		supported:      []string{"1.20", "1.21", "1.22", "1.23", "1.24"},
+	},
+	"python": {
+		legacyKey:      "python_version",
+		defaultVersion: "3.12",
+		supported:      []string{"3.8", "3.9", "3.10", "3.11", "3.12", "3.13"},
+	},
+	"java": {
+		legacyKey:      "jdk_version",
+		defaultVersion: "21",
+		supported:      []string{"8", "11", "17", "21"},
+	},
+	"nodejs": {
+		legacyKey:      "node_version",
+		defaultVersion: "20",
+		supported:      []string{"16", "18", "20", "22"},
+	},
+	"rust": {
+		legacyKey:      "rust_version",
+		defaultVersion: "1.78",
+		supported: []string{"1.70", "1.71", "1.72", "1.73", "1.74", "1.75", "1.76", "1.77",
+			"1.78", "1.79", "1.80", "1.81", "1.82", "1.83"},
+	},
+}
+
+// GetVersion 返回生效的语言版本
+// 优先级：language.version > language.config 中的旧版本键（如 go_version）> 默认版本
+func (l *LanguageConfig) GetVersion() string {
+	if l.Version != "" {
+		return l.Version
+	}
+	spec, ok := languageVersions[l.Type]
+	if !ok {
+		return ""
+	}
+	return l.GetString(spec.legacyKey, spec.defaultVersion)
+}
+
+// SupportedLanguageVersions 返回指定语言的已知版本列表
+func SupportedLanguageVersions(langType string) []string {
+	return languageVersions[langType].supported
+}
+
+// ValidateVersion 校验语言版本格式，以及 language.version 与旧版本键是否冲突
+// 版本表之外的版本（更旧或更新）不视为错误（见 VersionWarning）
+func (l *LanguageConfig) ValidateVersion() error {
+	spec, ok := languageVersions[l.Type]
+	if !ok {
+		return nil
+	}
+
+	legacy := l.GetString(spec.legacyKey, "")
+	if l.Version != "" && legacy != "" && l.Version != legacy {
+		return fmt.Errorf("language.version '%s' conflicts with language.config.%s '%s'", l.Version, spec.legacyKey, legacy)
+	}
+
+	version := l.GetVersion()
+	if !versionFormat.MatchString(version) {
+		return fmt.Errorf("language.version '%s' is not a valid %s version (expected e.g. %s)", version, l.Type, spec.defaultVersion)
+	}
+	return nil
+}
+
+// VersionWarning 语言版本超出版本表范围（低于最低版本或比最新版本还新）时返回警告，否则返回空字符串
+// 默认镜像直接使用该版本作为 tag，svcgen 无法确认镜像是否存在
+func (l *LanguageConfig) VersionWarning() string {
+	spec, ok := languageVersions[l.Type]
+	if !ok || l.ValidateVersion() != nil {
+		return ""
+	}
+	version := l.GetVersion()
+	if oldest := spec.supported[0]; compareVersions(version, oldest) < 0 {
+		return fmt.Sprintf("language.version '%s' is older than the %s versions svcgen supports (minimum: %s); default images will use it as-is",
+			version, l.Type, oldest)
+	}
+	latest := spec.supported[len(spec.supported)-1]
+	if compareVersions(truncateVersion(version, latest), latest) <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("language.version '%s' is newer than the %s versions svcgen knows (latest: %s); default images will use it as-is",
+		version, l.Type, latest)
+}
+
+// versionFormat 数字版本号：major[.minor[.patch]]
+var versionFormat = regexp.MustCompile(`^\d+(\.\d+){0,2}$`)
+
+// truncateVersion 将版本号截取为与 ref 相同的段数（如 "1.25.3" 按 "1.24" 截取为 "1.25"）
+func truncateVersion(version, ref string) string {
+	parts := strings.Split(version, ".")
+	if n := strings.Count(ref, ".") + 1; len(parts) > n {
+		parts = parts[:n]
+	}
+	return strings.Join(parts, ".")
+}
+
+// ============================================
+// 项目文件中的版本一致性检查
+// ============================================
+
+var (
+	goModGoDirective        = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+(?:\.\d+)?)\s*$`)
+	goModToolchainDirective = regexp.MustCompile(`(?m)^toolchain\s+go(\d+\.\d+(?:\.\d+)?)\s*$`)
+	rustToolchainChannel    = regexp.MustCompile(`(?m)^\s*channel\s*=\s*"([^"]+)"`)
+)
+
+// CheckLanguageVersionFiles 检查项目中的版本文件与生效语言版本是否一致，返回警告列表
+//   - go:     go.mod 的 go / toolchain 指令要求的版本不能高于 language.version
+//   - python: .python-version
+//   - nodejs: .nvmrc / .node-version（比较主版本）
+//   - rust:   rust-toolchain.toml / rust-toolchain
+func CheckLanguageVersionFiles(lang *LanguageConfig, projectDir string) []string {
+	version := lang.GetVersion()
+	if version == "" {
+		return nil
+	}
+
+	var warnings []string
+	mismatch := func(file, found string) {
+		warnings = append(warnings, fmt.Sprintf(
+			"%s requires %s %s, but language.version resolves to %s",
+			file, lang.Type, found, version))
+	}
+
+	switch lang.Type {
+	case "go":
+		data, err := os.ReadFile(filepath.Join(projectDir, "go.mod"))
+		if err != nil {
+			return nil
+		}
+		for _, re := range []*regexp.Regexp{goModGoDirective, goModToolchainDirective} {
+			if m := re.FindSubmatch(data); m != nil && compareVersions(majorMinor(string(m[1])), majorMinor(version)) > 0 {
+				mismatch("go.mod", string(m[1]))
+			}
+		}
+
+	case "python":
+		if found := readVersionFile(projectDir, ".python-version"); found != "" && majorMinor(found) != majorMinor(version) {
+			mismatch(".python-version", found)
+		}
+
+	case "nodejs":
+		for _, file := range []string{".nvmrc", ".node-version"} {
+			found := strings.TrimPrefix(readVersionFile(projectDir, file), "v")
+			// lts/* 等别名无法静态比较
+			if found != "" && isNumericVersion(found) && majorOf(found) != majorOf(version) {
+				mismatch(file, found)
+			}
+		}
+
+	case "rust":
+		found := ""
+		if data, err := os.ReadFile(filepath.Join(projectDir, "rust-toolchain.toml")); err == nil {
+			if m := rustToolchainChannel.FindSubmatch(data); m != nil {
+				found = string(m[1])
+			}
+		} else {
+			found = readVersionFile(projectDir, "rust-toolchain")
+		}
+		// stable / nightly 等渠道名无法静态比较
+		if found != "" && isNumericVersion(found) && majorMinor(found) != majorMinor(version) {
+			mismatch("rust-toolchain", found)
+		}
+	}
+	return warnings
+}
+
+// readVersionFile 读取版本文件的第一行非注释内容
+func readVersionFile(dir, name string) string {
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			return line
+		}
+	}
+	return ""
+}
+
+// majorMinor 截取版本号的 major.minor 部分
+func majorMinor(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+func majorOf(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
+
+func isNumericVersion(version string) bool {
+	_, err := strconv.Atoi(majorOf(version))
+	return err == nil
+}
+
+// compareVersions 按数字逐段比较版本号
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
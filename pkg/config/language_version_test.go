@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestLanguageConfig_GetVersion(t *testing.T) {
+	tests := []struct {
+		name string
+		lang LanguageConfig
+		want string
+	}{
+		{"explicit version", LanguageConfig{Type: "go", Version: "1.22"}, "1.22"},
+		{"legacy key", LanguageConfig{Type: "java", Config: map[string]interface{}{"jdk_version": "17"}}, "17"},
+		{"version wins over legacy key", LanguageConfig{Type: "nodejs", Version: "22", Config: map[string]interface{}{"node_version": "18"}}, "22"},
+		{"default", LanguageConfig{Type: "python"}, "3.12"},
+		{"unknown language", LanguageConfig{Type: "cobol"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.lang.GetVersion())
+		})
+	}
+}
+
+func TestLanguageConfig_VersionFromYAML(t *testing.T) {
+	var cfg ServiceConfig
+	require.NoError(t, yaml.Unmarshal([]byte("language:\n  type: go\n  version: 1.20\n"), &cfg))
+	assert.Equal(t, "1.20", cfg.Language.Version)
+
+	builder, err := DefaultBuilderImage("go", &cfg.Language)
+	require.NoError(t, err)
+	assert.Equal(t, "golang:1.20-alpine", builder)
+}
+
+func TestLanguageConfig_ValidateVersion(t *testing.T) {
+	assert.NoError(t, (&LanguageConfig{Type: "go", Version: "1.23.4"}).ValidateVersion())
+	assert.NoError(t, (&LanguageConfig{Type: "rust"}).ValidateVersion(), "default version is supported")
+
+	err := (&LanguageConfig{Type: "go", Version: "go1.23"}).ValidateVersion()
+	assert.ErrorContains(t, err, "language.version 'go1.23' is not a valid go version")
+
+	for _, lang := range []LanguageConfig{
+		{Type: "go", Version: "1.9"},
+		{Type: "go", Config: map[string]interface{}{"go_version": "1.19"}},
+		{Type: "nodejs", Config: map[string]interface{}{"node_version": "14"}},
+		{Type: "python", Config: map[string]interface{}{"python_version": "3.7"}},
+		{Type: "go", Version: "1.26"},
+		{Type: "nodejs", Version: "24"},
+		{Type: "python", Version: "3.14.1"},
+		{Type: "rust", Version: "1.90"},
+	} {
+		assert.NoError(t, lang.ValidateVersion(), "older and newer %s versions are accepted", lang.Type)
+	}
+
+	err = (&LanguageConfig{Type: "python", Version: "3.11", Config: map[string]interface{}{"python_version": "3.12"}}).ValidateVersion()
+	assert.ErrorContains(t, err, "conflicts with language.config.python_version")
+}
+
+func TestLanguageConfig_VersionWarning(t *testing.T) {
+	assert.Empty(t, (&LanguageConfig{Type: "go", Version: "1.24.3"}).VersionWarning())
+	assert.Empty(t, (&LanguageConfig{Type: "java"}).VersionWarning(), "default version is known")
+	assert.Empty(t, (&LanguageConfig{Type: "go", Version: "go1.23"}).VersionWarning(), "invalid versions are reported as errors")
+
+	assert.Equal(t,
+		"language.version '1.9' is older than the go versions svcgen supports (minimum: 1.20); default images will use it as-is",
+		(&LanguageConfig{Type: "go", Version: "1.9"}).VersionWarning())
+	assert.Contains(t, (&LanguageConfig{Type: "nodejs", Config: map[string]interface{}{"node_version": "14"}}).VersionWarning(), "minimum: 16")
+
+	assert.Equal(t,
+		"language.version '1.26' is newer than the go versions svcgen knows (latest: 1.24); default images will use it as-is",
+		(&LanguageConfig{Type: "go", Version: "1.26"}).VersionWarning())
+	assert.Contains(t, (&LanguageConfig{Type: "nodejs", Version: "24.1.0"}).VersionWarning(), "latest: 22")
+
+	cfg := &ServiceConfig{Language: LanguageConfig{Type: "go", Version: "1.26"}}
+	builder, err := DefaultBuilderImage("go", &cfg.Language)
+	require.NoError(t, err)
+	assert.Equal(t, "golang:1.26-alpine", builder)
+}
+
+func TestCheckLanguageVersionFiles(t *testing.T) {
+	write := func(t *testing.T, dir, name, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	t.Run("go.mod requires newer go", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "go.mod", "module demo\n\ngo 1.24.1\n\ntoolchain go1.24.2\n")
+
+		warnings := CheckLanguageVersionFiles(&LanguageConfig{Type: "go", Version: "1.23"}, dir)
+		require.Len(t, warnings, 2)
+		assert.Contains(t, warnings[0], "go.mod requires go 1.24.1")
+
+		assert.Empty(t, CheckLanguageVersionFiles(&LanguageConfig{Type: "go", Version: "1.24"}, dir))
+	})
+
+	t.Run("older go.mod is fine", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "go.mod", "module demo\n\ngo 1.21\n")
+		assert.Empty(t, CheckLanguageVersionFiles(&LanguageConfig{Type: "go"}, dir))
+	})
+
+	t.Run("python-version", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, ".python-version", "3.11.4\n")
+		assert.Len(t, CheckLanguageVersionFiles(&LanguageConfig{Type: "python"}, dir), 1)
+		assert.Empty(t, CheckLanguageVersionFiles(&LanguageConfig{Type: "python", Version: "3.11"}, dir))
+	})
+
+	t.Run("nvmrc", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, ".nvmrc", "v18.19.0\n")
+		assert.Len(t, CheckLanguageVersionFiles(&LanguageConfig{Type: "nodejs", Version: "20"}, dir), 1)
+
+		write(t, dir, ".nvmrc", "lts/*\n")
+		assert.Empty(t, CheckLanguageVersionFiles(&LanguageConfig{Type: "nodejs", Version: "20"}, dir))
+	})
+
+	t.Run("rust-toolchain.toml", func(t *testing.T) {
+		dir := t.TempDir()
+		write(t, dir, "rust-toolchain.toml", "[toolchain]\nchannel = \"1.80.1\"\n")
+		assert.Len(t, CheckLanguageVersionFiles(&LanguageConfig{Type: "rust"}, dir), 1)
+		assert.Empty(t, CheckLanguageVersionFiles(&LanguageConfig{Type: "rust", Version: "1.80"}, dir))
+	})
+}
+
+func TestValidator_LanguageVersionFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module demo\n\ngo 1.24\n"), 0644))
+
+	cfg := &ServiceConfig{
+		Service:  ServiceInfo{Name: "demo"},
+		Language: LanguageConfig{Type: "go", Version: "1.23"},
+		Runtime:  RuntimeConfig{Startup: StartupConfig{Command: "./demo"}},
+	}
+
+	validator := NewValidator(cfg).WithProjectDir(dir)
+	require.NoError(t, validator.Validate())
+	assert.Len(t, validator.Warnings(), 1)
+
+	assert.ErrorContains(t, NewValidator(cfg).WithProjectDir(dir).WithStrict(true).Validate(), "go.mod requires go 1.24")
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"gopkg.in/yaml.v3"
 )
@@ -25,32 +26,77 @@ func (l *Loader) Load() (*ServiceConfig, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
+	return l.LoadBytes(data)
+}
+
+// LoadBytes parses configuration content as if it were stored at the loader's path
+// (imports, the image policy file and the image lock are resolved next to it)
+func (l *Loader) LoadBytes(data []byte) (*ServiceConfig, error) {
+	// Upgrade older schema versions in memory before decoding
+	migration, err := Migrate(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", l.configPath, err)
+	}
 
-	var config ServiceConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	// Decode, recording explicitly set fields, and apply default values
+	config, err := decodeConfig(migration.Content)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	config.Migration = migration
+
+	// Merge imported image catalogs (relative paths are based on the config directory)
+	if err := config.BaseImages.ResolveImports(filepath.Dir(l.configPath)); err != nil {
+		return nil, err
+	}
+
+	// Merge the shared image policy file (optional)
+	if err := config.ImagePolicy.ResolveFile(filepath.Dir(l.configPath)); err != nil {
+		return nil, err
+	}
 
-	// Apply default values
-	applyDefaults(&config)
+	// Template overrides are relative to the config directory
+	config.Templates.ResolveDir(filepath.Dir(l.configPath))
+	config.Generators.ResolvePaths(filepath.Dir(l.configPath))
+
+	// Load image digest lock file next to the config (optional)
+	lock, err := LoadImageLock(ImageLockPath(l.configPath))
+	if err != nil {
+		return nil, err
+	}
+	config.ImageLock = lock
 
-	return &config, nil
+	return config, nil
 }
 
 // LoadFromBytes loads configuration from byte slice
 func LoadFromBytes(data []byte) (*ServiceConfig, error) {
-	var config ServiceConfig
-	if err := yaml.Unmarshal(data, &config); err != nil {
+	migration, err := Migrate(data)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := decodeConfig(migration.Content)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	config.Migration = migration
 
-	// Apply default values
-	applyDefaults(&config)
+	// Merge imported image catalogs (relative paths are based on the working directory)
+	if err := config.BaseImages.ResolveImports("."); err != nil {
+		return nil, err
+	}
+	if err := config.ImagePolicy.ResolveFile("."); err != nil {
+		return nil, err
+	}
+	config.Templates.ResolveDir(".")
+	config.Generators.ResolvePaths(".")
 
-	return &config, nil
+	return config, nil
 }
 
 // Save writes the configuration to a YAML file
+// Note: the file is rewritten from the struct, so comments are lost; use Editor to modify an existing file
 func (l *Loader) Save(config *ServiceConfig) error {
 	data, err := yaml.Marshal(config)
 	if err != nil {
@@ -63,20 +109,3 @@ func (l *Loader) Save(config *ServiceConfig) error {
 
 	return nil
 }
-
-// applyDefaults applies default values to the configuration
-func applyDefaults(config *ServiceConfig) {
-	// Default deploy directory
-	if config.Service.DeployDir == "" {
-		config.Service.DeployDir = "/usr/local/services"
-	}
-
-	// Default auto_detect for dependency files
-	// Note: YAML unmarshaling sets bool to false by default
-	// We need to check if it was explicitly set or not
-	// Since we can't distinguish between "not set" and "false" with bool,
-	// we treat missing field as "true" by checking if Files is also empty
-	if !config.Build.DependencyFiles.AutoDetect && len(config.Build.DependencyFiles.Files) == 0 {
-		config.Build.DependencyFiles.AutoDetect = true
-	}
-}
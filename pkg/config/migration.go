@@ -0,0 +1,407 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CurrentSchemaVersion service.yaml 的当前 schema 版本（metadata.template_version）
+const CurrentSchemaVersion = "2.1.0"
+
+// unversionedSchemaVersion 未声明 metadata.template_version 的配置按最早的已知 schema 处理
+// 迁移步骤都是幂等的，已经是新格式的配置只会补上版本号
+const unversionedSchemaVersion = "2.0.0"
+
+// MigrationDiagnostic 迁移过程中的一条说明（改写了什么，或需要手动处理什么）
+type MigrationDiagnostic struct {
+	// Path 字段路径，如 "build.output_dir"
+	Path    string
+	Message string
+}
+
+// String 格式化为报告行
+func (d MigrationDiagnostic) String() string {
+	return d.Path + ": " + d.Message
+}
+
+// Migration 一个 schema 迁移步骤（From → To）
+// Apply 直接修改 yaml 节点树（顶层 mapping），以保留注释与字段顺序
+type Migration struct {
+	From        string
+	To          string
+	Description string
+	Apply       func(root *yaml.Node) []MigrationDiagnostic
+}
+
+// migrations 已注册的迁移步骤（按 From 排序）
+var migrations []Migration
+
+// RegisterMigration 注册迁移步骤
+func RegisterMigration(m Migration) {
+	migrations = append(migrations, m)
+	sort.SliceStable(migrations, func(i, j int) bool {
+		return compareVersions(migrations[i].From, migrations[j].From) < 0
+	})
+}
+
+func init() {
+	RegisterMigration(Migration{
+		From:        "2.0.0",
+		To:          "2.1.0",
+		Description: "rewrite pre-ImageSpec layout (build.output_dir, build.system_dependencies, plugin list, healthcheck.http, arch image maps)",
+		Apply:       migrateLegacyLayout,
+	})
+}
+
+// MigrationResult 迁移结果
+type MigrationResult struct {
+	// FromVersion 检测到的 schema 版本
+	FromVersion string
+	// ToVersion 迁移后的 schema 版本（未迁移时与 FromVersion 相同）
+	ToVersion string
+	// Applied 依次执行的迁移步骤
+	Applied []Migration
+	// Diagnostics 迁移说明；只补版本号时为空
+	Diagnostics []MigrationDiagnostic
+	// Content 迁移后的 YAML（未迁移时为原始内容）
+	Content []byte
+}
+
+// Changed 是否执行了迁移步骤
+func (r *MigrationResult) Changed() bool {
+	return len(r.Applied) > 0
+}
+
+// DetectSchemaVersion 返回配置内容的 schema 版本
+func DetectSchemaVersion(data []byte) (string, error) {
+	_, root, err := parseDocument(data)
+	if err != nil {
+		return "", err
+	}
+	return schemaVersion(root)
+}
+
+// Migrate 检测 schema 版本并依次执行所有更高版本的迁移步骤
+// 配置已是当前版本时原样返回内容；版本高于当前支持的版本时报错
+func Migrate(data []byte) (*MigrationResult, error) {
+	doc, root, err := parseDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	version, err := schemaVersion(root)
+	if err != nil {
+		return nil, err
+	}
+	if compareVersions(version, CurrentSchemaVersion) > 0 {
+		return nil, fmt.Errorf("metadata.template_version %s is newer than the schema supported by this svcgen (%s); upgrade svcgen",
+			version, CurrentSchemaVersion)
+	}
+
+	result := &MigrationResult{FromVersion: version, ToVersion: version, Content: data}
+	for _, m := range migrations {
+		if compareVersions(m.To, result.ToVersion) <= 0 {
+			continue
+		}
+		result.Diagnostics = append(result.Diagnostics, m.Apply(root)...)
+		result.Applied = append(result.Applied, m)
+		result.ToVersion = m.To
+	}
+	if !result.Changed() {
+		return result, nil
+	}
+
+	// 只需更新版本号时直接改写该行，避免重新编码整个文件带来的格式变化
+	if len(result.Diagnostics) == 0 {
+		if content, ok := replaceSchemaVersion(data, root, result.ToVersion); ok {
+			result.Content = content
+			return result, nil
+		}
+	}
+
+	versionNode := newStringNode(result.ToVersion)
+	versionNode.Style = yaml.DoubleQuotedStyle
+	mappingSet(mappingEnsure(root, "metadata"), "template_version", versionNode)
+
+	content, err := encodeDocument(doc)
+	if err != nil {
+		return nil, err
+	}
+	result.Content = restoreSectionSpacing(data, content)
+	return result, nil
+}
+
+// schemaVersion 读取 metadata.template_version 并规范为 major.minor.patch
+func schemaVersion(root *yaml.Node) (string, error) {
+	node := lookupNode(root, "metadata", "template_version")
+	if node == nil || strings.TrimSpace(node.Value) == "" {
+		return unversionedSchemaVersion, nil
+	}
+
+	raw := strings.TrimPrefix(strings.TrimSpace(node.Value), "v")
+	parts := strings.Split(raw, ".")
+	if len(parts) > 3 {
+		return "", fmt.Errorf("metadata.template_version: invalid schema version %q", node.Value)
+	}
+	for _, part := range parts {
+		if _, err := strconv.Atoi(part); err != nil {
+			return "", fmt.Errorf("metadata.template_version: invalid schema version %q", node.Value)
+		}
+	}
+	for len(parts) < 3 {
+		parts = append(parts, "0")
+	}
+	return strings.Join(parts, "."), nil
+}
+
+// replaceSchemaVersion 在原文中原位替换 metadata.template_version 的值
+// 字段不存在时在 metadata 下（或文件末尾）追加；无法定位时返回 false
+func replaceSchemaVersion(data []byte, root *yaml.Node, version string) ([]byte, bool) {
+	quoted := strconv.Quote(version)
+	if node := lookupNode(root, "metadata", "template_version"); node != nil {
+		return replaceScalarText(data, node, quoted)
+	}
+
+	metadata := mappingValue(root, "metadata")
+	if metadata == nil {
+		content := strings.TrimRight(string(data), "\n")
+		return []byte(content + "\n\nmetadata:\n  template_version: " + quoted + "\n"), true
+	}
+	if metadata.Kind != yaml.MappingNode || len(metadata.Content) == 0 || metadata.Style&yaml.FlowStyle != 0 {
+		return nil, false
+	}
+	lines := strings.Split(string(data), "\n")
+	first := metadata.Content[0]
+	if first.Line < 1 || first.Line > len(lines) {
+		return nil, false
+	}
+	entry := strings.Repeat(" ", first.Column-1) + "template_version: " + quoted
+	lines = append(lines[:first.Line-1], append([]string{entry}, lines[first.Line-1:]...)...)
+	return []byte(strings.Join(lines, "\n")), true
+}
+
+// ============================================
+// 2.0.0 → 2.1.0
+// ============================================
+
+// legacyPluginInstallDir 旧格式插件未声明 install_dir 时使用的目录
+const legacyPluginInstallDir = "/plugins"
+
+// migrateLegacyLayout 将 ImageSpec 之前的旧格式改写为当前结构
+func migrateLegacyLayout(root *yaml.Node) []MigrationDiagnostic {
+	var diags []MigrationDiagnostic
+	diags = append(diags, migrateBuildOutputDir(root)...)
+	diags = append(diags, migrateBuildSystemDependencies(root)...)
+	diags = append(diags, migrateImageMaps(root)...)
+	diags = append(diags, migratePluginList(root)...)
+	diags = append(diags, migrateHealthcheckHTTP(root)...)
+	return diags
+}
+
+// migrateBuildOutputDir build.output_dir 已不再读取（产物固定输出到 ${BUILD_OUTPUT_DIR}）
+func migrateBuildOutputDir(root *yaml.Node) []MigrationDiagnostic {
+	if mappingDelete(mappingValue(root, "build"), "output_dir") == nil {
+		return nil
+	}
+	return []MigrationDiagnostic{{
+		Path:    "build.output_dir",
+		Message: "removed; build commands write artifacts to ${BUILD_OUTPUT_DIR}",
+	}}
+}
+
+// migrateBuildSystemDependencies build.system_dependencies.build.packages → build.dependencies.system_pkgs
+func migrateBuildSystemDependencies(root *yaml.Node) []MigrationDiagnostic {
+	build := mappingValue(root, "build")
+	legacy := mappingValue(build, "system_dependencies")
+	if legacy == nil {
+		return nil
+	}
+
+	packages := lookupNode(legacy, "build", "packages")
+	var diags []MigrationDiagnostic
+	for i := 0; i+1 < len(legacy.Content); i += 2 {
+		if key := legacy.Content[i].Value; key != "build" {
+			diags = append(diags, MigrationDiagnostic{
+				Path:    "build.system_dependencies." + key,
+				Message: "removed; runtime packages belong in runtime.system_dependencies.packages",
+			})
+		}
+	}
+
+	if mappingIndex(build, "dependencies") < 0 {
+		mappingRename(build, "system_dependencies", "dependencies")
+		deps := newMappingNode()
+		if packages != nil {
+			deps.Content = append(deps.Content, newStringNode("system_pkgs"), packages)
+		}
+		mappingSet(build, "dependencies", deps)
+	} else {
+		mappingDelete(build, "system_dependencies")
+		if packages != nil {
+			deps := mappingEnsure(build, "dependencies")
+			existing := mappingValue(deps, "system_pkgs")
+			if existing == nil || existing.Kind != yaml.SequenceNode {
+				mappingSet(deps, "system_pkgs", packages)
+			} else {
+				seen := map[string]bool{}
+				for _, item := range existing.Content {
+					seen[item.Value] = true
+				}
+				for _, item := range packages.Content {
+					if !seen[item.Value] {
+						existing.Content = append(existing.Content, item)
+					}
+				}
+			}
+		}
+	}
+
+	if packages != nil {
+		diags = append(diags, MigrationDiagnostic{
+			Path:    "build.system_dependencies.build.packages",
+			Message: "moved to build.dependencies.system_pkgs",
+		})
+	}
+	return diags
+}
+
+// migrateImageMaps 规范按架构的镜像映射
+// 旧格式使用 x86_64 / aarch64 等键名，会被静默解析为空镜像；两个架构相同时合并为 multi-arch 字符串
+func migrateImageMaps(root *yaml.Node) []MigrationDiagnostic {
+	var diags []MigrationDiagnostic
+	build := mappingValue(root, "build")
+	for _, key := range []string{"builder_image", "runtime_image"} {
+		diags = append(diags, normalizeArchMap(build, key, "build."+key, true)...)
+	}
+
+	baseImages := mappingValue(root, "base_images")
+	for _, category := range []string{"builders", "runtimes"} {
+		presets := mappingValue(baseImages, category)
+		if presets == nil || presets.Kind != yaml.MappingNode {
+			continue
+		}
+		for i := 0; i+1 < len(presets.Content); i += 2 {
+			name := presets.Content[i].Value
+			diags = append(diags, normalizeArchMap(presets, name, "base_images."+category+"."+name, false)...)
+		}
+	}
+	return diags
+}
+
+// normalizeArchMap 规范 parent[key] 的架构键名；collapse 为 true 时将相同的两个架构合并为字符串
+func normalizeArchMap(parent *yaml.Node, key, path string, collapse bool) []MigrationDiagnostic {
+	images := mappingValue(parent, key)
+	if images == nil || images.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var diags []MigrationDiagnostic
+	for i := 0; i+1 < len(images.Content); i += 2 {
+		arch := images.Content[i].Value
+		normalized := normalizeArch(arch)
+		switch {
+		case normalized == arch && (arch == "amd64" || arch == "arm64"):
+		case (normalized == "amd64" || normalized == "arm64") && mappingRename(images, arch, normalized):
+			diags = append(diags, MigrationDiagnostic{
+				Path:    path + "." + arch,
+				Message: "renamed to " + normalized,
+			})
+		default:
+			diags = append(diags, MigrationDiagnostic{
+				Path:    path + "." + arch,
+				Message: "unknown architecture key is ignored; use amd64 / arm64",
+			})
+		}
+	}
+
+	amd64, arm64 := mappingValue(images, "amd64"), mappingValue(images, "arm64")
+	if collapse && len(images.Content) == 4 && amd64 != nil && arm64 != nil &&
+		amd64.Kind == yaml.ScalarNode && amd64.Value != "" && amd64.Value == arm64.Value {
+		image := newStringNode(amd64.Value)
+		image.LineComment = images.LineComment
+		mappingSet(parent, key, image)
+		diags = append(diags, MigrationDiagnostic{
+			Path:    path,
+			Message: "identical amd64 / arm64 images collapsed to a multi-arch image reference",
+		})
+	}
+	return diags
+}
+
+// migratePluginList 旧格式 plugins 为列表，每个插件单独声明 install_dir
+// 当前格式为 plugins.install_dir（共用）+ plugins.items
+func migratePluginList(root *yaml.Node) []MigrationDiagnostic {
+	list := mappingValue(root, "plugins")
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	var diags []MigrationDiagnostic
+	installDir := ""
+	for i, item := range list.Content {
+		dir := mappingDelete(item, "install_dir")
+		if dir == nil || dir.Value == "" {
+			continue
+		}
+		if installDir == "" {
+			installDir = dir.Value
+		} else if dir.Value != installDir {
+			diags = append(diags, MigrationDiagnostic{
+				Path:    fmt.Sprintf("plugins[%d].install_dir", i),
+				Message: fmt.Sprintf("%s differs from %s; all plugins now share plugins.install_dir", dir.Value, installDir),
+			})
+		}
+	}
+	if installDir == "" {
+		installDir = legacyPluginInstallDir
+		diags = append(diags, MigrationDiagnostic{
+			Path:    "plugins.install_dir",
+			Message: "no plugin declared install_dir; set to " + legacyPluginInstallDir,
+		})
+	}
+
+	plugins := newMappingNode()
+	plugins.Content = append(plugins.Content,
+		newStringNode("install_dir"), newStringNode(installDir),
+		newStringNode("items"), list,
+	)
+	mappingSet(root, "plugins", plugins)
+
+	return append(diags, MigrationDiagnostic{
+		Path:    "plugins",
+		Message: "plugin list moved to plugins.items",
+	})
+}
+
+// migrateHealthcheckHTTP runtime.healthcheck.http.{path,port} → runtime.healthcheck.{path,port}
+func migrateHealthcheckHTTP(root *yaml.Node) []MigrationDiagnostic {
+	healthcheck := lookupNode(root, "runtime", "healthcheck")
+	legacy := mappingValue(healthcheck, "http")
+	if legacy == nil || legacy.Kind != yaml.MappingNode {
+		return nil
+	}
+	mappingDelete(healthcheck, "http")
+
+	diags := []MigrationDiagnostic{{
+		Path:    "runtime.healthcheck.http",
+		Message: "path / port moved to runtime.healthcheck",
+	}}
+	for i := 0; i+1 < len(legacy.Content); i += 2 {
+		key, value := legacy.Content[i].Value, legacy.Content[i+1]
+		switch key {
+		case "path", "port":
+			if mappingIndex(healthcheck, key) < 0 {
+				mappingSet(healthcheck, key, value)
+			}
+		default:
+			diags = append(diags, MigrationDiagnostic{
+				Path:    "runtime.healthcheck.http." + key,
+				Message: "removed; probe timings are configured in local_dev.compose.healthcheck",
+			})
+		}
+	}
+	return diags
+}
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyConfigYAML = `# Legacy layout
+service:
+  name: demo
+  ports:
+    - name: http
+      port: 8080
+      protocol: TCP
+
+language:
+  type: go
+
+build:
+  # per-arch images
+  builder_image:
+    x86_64: "golang:1.23-alpine"
+    aarch64: "golang:1.23-alpine"
+  runtime_image:
+    amd64: "alpine:3.19"
+    arm64: "arm64v8/alpine:3.19"
+  system_dependencies:
+    build:
+      packages:
+        - git
+        - make
+  commands:
+    build: go build -o ${BUILD_OUTPUT_DIR}/bin/demo .
+  output_dir: dist
+
+# plugins
+plugins:
+  - name: selfMonitor
+    download_url: "https://example.com/install.sh"
+    install_dir: /tce
+    install_command: sh install.sh
+  - name: agent
+    download_url: "https://example.com/agent.sh"
+    install_dir: /opt/agent
+    install_command: sh agent.sh
+
+runtime:
+  healthcheck:
+    enabled: true
+    type: http
+    http:
+      path: /health
+      port: 8080
+      timeout: 3
+  startup:
+    command: exec demo
+
+metadata:
+  template_version: "2.0.0"
+  generator: svcgen
+`
+
+func TestMigrate_LegacyLayout(t *testing.T) {
+	result, err := Migrate([]byte(legacyConfigYAML))
+	require.NoError(t, err)
+
+	assert.True(t, result.Changed())
+	assert.Equal(t, "2.0.0", result.FromVersion)
+	assert.Equal(t, CurrentSchemaVersion, result.ToVersion)
+
+	var report []string
+	for _, d := range result.Diagnostics {
+		report = append(report, d.String())
+	}
+	joined := strings.Join(report, "\n")
+	assert.Contains(t, joined, "build.output_dir: removed")
+	assert.Contains(t, joined, "build.builder_image.x86_64: renamed to amd64")
+	assert.Contains(t, joined, "build.builder_image: identical amd64 / arm64 images collapsed")
+	assert.Contains(t, joined, "plugins[1].install_dir: /opt/agent differs from /tce")
+	assert.Contains(t, joined, "runtime.healthcheck.http.timeout: removed")
+
+	content := string(result.Content)
+	assert.Contains(t, content, "# Legacy layout")
+	assert.Contains(t, content, "# per-arch images")
+	assert.Contains(t, content, "# plugins")
+	assert.Contains(t, content, "\n\nlanguage:", "blank lines between sections are kept")
+	assert.NotContains(t, content, "output_dir")
+	assert.NotContains(t, content, "system_dependencies")
+	assert.Contains(t, content, `template_version: "2.1.0"`)
+
+	cfg, err := LoadFromBytes(result.Content)
+	require.NoError(t, err)
+	assert.Equal(t, ImageSpecDirect, cfg.Build.BuilderImage.Kind())
+	assert.Equal(t, "golang:1.23-alpine", cfg.Build.BuilderImage.String())
+	assert.Equal(t, ImageSpecPerArch, cfg.Build.RuntimeImage.Kind())
+	assert.Equal(t, []string{"git", "make"}, cfg.Build.Dependencies.SystemPkgs)
+	assert.Equal(t, "/tce", cfg.Plugins.InstallDir)
+	require.Len(t, cfg.Plugins.Items, 2)
+	assert.Equal(t, "agent", cfg.Plugins.Items[1].Name)
+	assert.Equal(t, "/health", cfg.Runtime.Healthcheck.Path)
+	assert.Equal(t, 8080, cfg.Runtime.Healthcheck.Port)
+	assert.NoError(t, NewValidator(cfg).Validate())
+
+	// 迁移后再次执行为空操作
+	again, err := Migrate(result.Content)
+	require.NoError(t, err)
+	assert.False(t, again.Changed())
+	assert.Equal(t, result.Content, again.Content)
+}
+
+func TestMigrate_VersionOnly(t *testing.T) {
+	input := "service:\n  name: demo # keep\n\nmetadata:\n  template_version: '2.0' # schema\n  generator: svcgen\n"
+
+	result, err := Migrate([]byte(input))
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", result.FromVersion)
+	assert.Empty(t, result.Diagnostics)
+	assert.Equal(t, strings.Replace(input, "'2.0'", `"2.1.0"`, 1), string(result.Content),
+		"only the version is rewritten in place")
+}
+
+func TestMigrate_Unversioned(t *testing.T) {
+	result, err := Migrate([]byte("service:\n  name: demo\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "2.0.0", result.FromVersion)
+	assert.Equal(t, "service:\n  name: demo\n\nmetadata:\n  template_version: \"2.1.0\"\n", string(result.Content))
+
+	result, err = Migrate([]byte("service:\n  name: demo\nmetadata:\n  generator: svcgen\n"))
+	require.NoError(t, err)
+	assert.Contains(t, string(result.Content), "metadata:\n  template_version: \"2.1.0\"\n  generator: svcgen\n")
+}
+
+func TestMigrate_CurrentVersionUnchanged(t *testing.T) {
+	input := []byte("build:\n  output_dir: dist\nmetadata:\n  template_version: \"2.1.0\"\n")
+
+	result, err := Migrate(input)
+	require.NoError(t, err)
+	assert.False(t, result.Changed())
+	assert.Equal(t, input, result.Content)
+}
+
+func TestMigrate_Errors(t *testing.T) {
+	_, err := Migrate([]byte("metadata:\n  template_version: \"9.0.0\"\n"))
+	assert.ErrorContains(t, err, "newer than the schema supported")
+
+	_, err = Migrate([]byte("metadata:\n  template_version: latest\n"))
+	assert.ErrorContains(t, err, "invalid schema version")
+
+	_, err = Migrate([]byte("- a\n- b\n"))
+	assert.ErrorContains(t, err, "top level must be a mapping")
+}
+
+func TestMigrate_BuildDependenciesMerged(t *testing.T) {
+	input := `build:
+  dependencies:
+    system_pkgs: [git]
+  system_dependencies:
+    build:
+      packages: [git, gcc]
+    runtime:
+      packages: [tzdata]
+`
+	result, err := Migrate([]byte(input))
+	require.NoError(t, err)
+
+	cfg, err := LoadFromBytes(result.Content)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"git", "gcc"}, cfg.Build.Dependencies.SystemPkgs)
+	assert.Contains(t, result.Diagnostics, MigrationDiagnostic{
+		Path:    "build.system_dependencies.runtime",
+		Message: "removed; runtime packages belong in runtime.system_dependencies.packages",
+	})
+}
+
+func TestMigrate_PluginListWithoutInstallDir(t *testing.T) {
+	result, err := Migrate([]byte("plugins:\n  - name: a\n    install_command: x\n"))
+	require.NoError(t, err)
+
+	cfg, err := LoadFromBytes(result.Content)
+	require.NoError(t, err)
+	assert.Equal(t, legacyPluginInstallDir, cfg.Plugins.InstallDir)
+	require.Len(t, cfg.Plugins.Items, 1)
+}
+
+func TestDetectSchemaVersion(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"service:\n  name: a\n", "2.0.0"},
+		{"metadata:\n  template_version: \"2.1.0\"\n", "2.1.0"},
+		{"metadata:\n  template_version: v2.1\n", "2.1.0"},
+		{"metadata:\n  template_version: 2\n", "2.0.0"},
+	}
+	for _, tt := range tests {
+		version, err := DetectSchemaVersion([]byte(tt.input))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, version, tt.input)
+	}
+}
+
+func TestLoader_MigratesInMemory(t *testing.T) {
+	cfg, err := LoadFromBytes([]byte(legacyConfigYAML))
+	require.NoError(t, err)
+
+	require.NotNil(t, cfg.Migration)
+	assert.True(t, cfg.Migration.Changed())
+	assert.NotEmpty(t, cfg.Migration.Diagnostics)
+	assert.Equal(t, "/tce", cfg.Plugins.InstallDir)
+	assert.Equal(t, CurrentSchemaVersion, cfg.Metadata.TemplateVersion)
+}
@@ -0,0 +1,247 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MirrorProfileNone 特殊 profile 名，表示禁用全部镜像源改写（如 --mirror-profile=none）
+const MirrorProfileNone = "none"
+
+// dockerHubRegistry Docker Hub 的规范 registry 名（镜像规则中的隐式默认 registry）
+const dockerHubRegistry = "docker.io"
+
+// RegistryMirrorsConfig 镜像源改写配置
+// 用于在 docker.io / github.com 等不可达的环境中，统一把镜像和插件下载地址改写到内部镜像源
+//
+//	registry_mirrors:
+//	  profile: cn                              # 当前生效的 profile（可被 --mirror-profile 覆盖）
+//	  images:                                  # 默认规则（所有 profile 共享）
+//	    docker.io: mirrors.tencent.com
+//	  profiles:
+//	    cn:
+//	      download_urls:
+//	        github.com: ghproxy.example.com/github.com
+type RegistryMirrorsConfig struct {
+	// Profile 当前生效的 profile，"none" 表示禁用改写
+	Profile string `yaml:"profile,omitempty"`
+	// 默认规则
+	MirrorRules `yaml:",inline"`
+	// Profiles 命名规则集，选中后按 key 覆盖默认规则
+	Profiles map[string]MirrorRules `yaml:"profiles,omitempty"`
+}
+
+// MirrorRules 一组改写规则（前缀 → 替换值）
+type MirrorRules struct {
+	// Images 镜像引用前缀改写，如 "docker.io" → "mirrors.tencent.com"
+	// 前缀按 docker.io/library/ 规范化后匹配，因此 "golang:1.23" 也会命中 "docker.io" 规则
+	Images map[string]string `yaml:"images,omitempty"`
+	// DownloadURLs 插件下载地址改写
+	// key 不含 "://" 时按 host 匹配（保留原 scheme），否则按 URL 前缀匹配
+	DownloadURLs map[string]string `yaml:"download_urls,omitempty"`
+}
+
+// IsEmpty 检查是否未配置任何规则
+func (m *RegistryMirrorsConfig) IsEmpty() bool {
+	return m.Profile == "" && len(m.Images) == 0 && len(m.DownloadURLs) == 0 && len(m.Profiles) == 0
+}
+
+// ListProfiles 返回所有 profile 名（排序）
+func (m *RegistryMirrorsConfig) ListProfiles() []string {
+	names := make([]string, 0, len(m.Profiles))
+	for name := range m.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Validate 验证镜像源配置
+func (m *RegistryMirrorsConfig) Validate() error {
+	if m.Profile != "" && m.Profile != MirrorProfileNone {
+		if _, ok := m.Profiles[m.Profile]; !ok {
+			return fmt.Errorf("profile '%s' not found (available: %s)",
+				m.Profile, strings.Join(append(m.ListProfiles(), MirrorProfileNone), ", "))
+		}
+	}
+
+	check := func(scope string, rules map[string]string) error {
+		for from, to := range rules {
+			if strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+				return fmt.Errorf("%s: rule %q → %q must have a non-empty prefix and replacement", scope, from, to)
+			}
+		}
+		return nil
+	}
+	if err := check("images", m.Images); err != nil {
+		return err
+	}
+	if err := check("download_urls", m.DownloadURLs); err != nil {
+		return err
+	}
+	for _, name := range m.ListProfiles() {
+		rules := m.Profiles[name]
+		if err := check(fmt.Sprintf("profiles.%s.images", name), rules.Images); err != nil {
+			return err
+		}
+		if err := check(fmt.Sprintf("profiles.%s.download_urls", name), rules.DownloadURLs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ActiveRules 返回当前生效的规则：默认规则 + 选中 profile 的规则（同 key 时 profile 优先）
+// profile 为 "none" 时返回空规则
+func (m *RegistryMirrorsConfig) ActiveRules() MirrorRules {
+	if m == nil || m.Profile == MirrorProfileNone {
+		return MirrorRules{}
+	}
+	profile := m.Profiles[m.Profile]
+	return MirrorRules{
+		Images:       mergeRules(m.Images, profile.Images),
+		DownloadURLs: mergeRules(m.DownloadURLs, profile.DownloadURLs),
+	}
+}
+
+// RewriteImage 按生效规则改写镜像引用，未命中规则时原样返回
+func (m *RegistryMirrorsConfig) RewriteImage(ref string) string {
+	return rewriteImage(ref, m.ActiveRules().Images)
+}
+
+// RewriteArch 对两个架构的镜像分别应用 RewriteImage
+func (m *RegistryMirrorsConfig) RewriteArch(images ArchImageConfig) ArchImageConfig {
+	return ArchImageConfig{
+		AMD64: m.RewriteImage(images.AMD64),
+		ARM64: m.RewriteImage(images.ARM64),
+	}
+}
+
+// RewriteDownloadURL 按生效规则改写插件下载地址，未命中规则时原样返回
+func (m *RegistryMirrorsConfig) RewriteDownloadURL(url string) string {
+	return rewriteDownloadURL(url, m.ActiveRules().DownloadURLs)
+}
+
+func mergeRules(base, override map[string]string) map[string]string {
+	if len(base) == 0 && len(override) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		merged[k] = v
+	}
+	return merged
+}
+
+// rewriteImage 将镜像引用规范化为 registry/repository 形式后按最长前缀匹配改写
+// 前缀必须在路径边界上命中（"docker.io/library/go" 不会匹配 "golang"）
+func rewriteImage(ref string, rules map[string]string) string {
+	if ref == "" || ref == "scratch" || len(rules) == 0 {
+		return ref
+	}
+
+	canonical := canonicalImageRef(ref)
+	bestFrom, bestTo := "", ""
+	for from, to := range rules {
+		prefix := canonicalImagePrefix(from)
+		if !hasBoundaryPrefix(canonical, prefix, "/:@") {
+			continue
+		}
+		if len(prefix) > len(bestFrom) {
+			bestFrom, bestTo = prefix, strings.TrimRight(to, "/")
+		}
+	}
+	if bestFrom == "" {
+		return ref
+	}
+	return bestTo + canonical[len(bestFrom):]
+}
+
+// canonicalImageRef 补全隐式的 Docker Hub registry 与 library/ 命名空间
+// 如 "golang:1.23" → "docker.io/library/golang:1.23"，"bitnami/redis" → "docker.io/bitnami/redis"
+func canonicalImageRef(ref string) string {
+	first, rest, hasSlash := strings.Cut(ref, "/")
+	if hasSlash && isRegistryHost(first) {
+		if first == "index.docker.io" || first == "registry-1.docker.io" {
+			first = dockerHubRegistry
+		}
+		if first == dockerHubRegistry && !strings.Contains(rest, "/") {
+			rest = "library/" + rest
+		}
+		return first + "/" + rest
+	}
+	if !hasSlash {
+		return dockerHubRegistry + "/library/" + ref
+	}
+	return dockerHubRegistry + "/" + ref
+}
+
+// canonicalImagePrefix 规范化规则前缀（"docker.io" 保持不变，"library/golang" → "docker.io/library/golang"）
+func canonicalImagePrefix(prefix string) string {
+	prefix = strings.TrimRight(prefix, "/")
+	first, _, _ := strings.Cut(prefix, "/")
+	if isRegistryHost(first) {
+		if first == "index.docker.io" || first == "registry-1.docker.io" {
+			return dockerHubRegistry + prefix[len(first):]
+		}
+		return prefix
+	}
+	return canonicalImageRef(prefix)
+}
+
+// isRegistryHost 判断引用的第一段是否为 registry 主机名（含 "." 或 ":" 或为 localhost）
+func isRegistryHost(s string) bool {
+	return s == "localhost" || strings.ContainsAny(s, ".:")
+}
+
+// rewriteDownloadURL 按最长前缀匹配改写下载地址
+func rewriteDownloadURL(url string, rules map[string]string) string {
+	if url == "" || len(rules) == 0 {
+		return url
+	}
+
+	scheme := ""
+	if idx := strings.Index(url, "://"); idx >= 0 {
+		scheme = url[:idx+3]
+	}
+
+	bestFrom, bestTo := "", ""
+	for from, to := range rules {
+		prefix, replacement := strings.TrimRight(from, "/"), strings.TrimRight(to, "/")
+		// 仅写 host 的规则沿用原 URL 的 scheme
+		if !strings.Contains(prefix, "://") {
+			if scheme == "" {
+				continue
+			}
+			prefix = scheme + prefix
+			if !strings.Contains(replacement, "://") {
+				replacement = scheme + replacement
+			}
+		}
+		if !hasBoundaryPrefix(url, prefix, "/?#") {
+			continue
+		}
+		if len(prefix) > len(bestFrom) {
+			bestFrom, bestTo = prefix, replacement
+		}
+	}
+	if bestFrom == "" {
+		return url
+	}
+	return bestTo + url[len(bestFrom):]
+}
+
+// hasBoundaryPrefix 检查 s 是否以 prefix 开头，且紧随其后的字符是边界字符（或已到结尾）
+func hasBoundaryPrefix(s, prefix, boundaries string) bool {
+	if prefix == "" || !strings.HasPrefix(s, prefix) {
+		return false
+	}
+	if len(s) == len(prefix) {
+		return true
+	}
+	return strings.ContainsRune(boundaries, rune(s[len(prefix)]))
+}
@@ -0,0 +1,143 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRegistryMirrors_RewriteImage(t *testing.T) {
+	mirrors := &RegistryMirrorsConfig{MirrorRules: MirrorRules{Images: map[string]string{
+		"docker.io":                "mirrors.tencent.com",
+		"docker.io/library/golang": "mirrors.tencent.com/go-mirror/golang",
+		"ghcr.io":                  "ghcr.example.com/",
+	}}}
+
+	tests := []struct {
+		name string
+		ref  string
+		want string
+	}{
+		{"implicit docker hub official image", "alpine:3.19", "mirrors.tencent.com/library/alpine:3.19"},
+		{"implicit docker hub namespaced image", "bitnami/redis:7", "mirrors.tencent.com/bitnami/redis:7"},
+		{"explicit docker.io without library", "docker.io/python:3.11-slim", "mirrors.tencent.com/library/python:3.11-slim"},
+		{"longest prefix wins", "golang:1.23-alpine", "mirrors.tencent.com/go-mirror/golang:1.23-alpine"},
+		{"prefix only on path boundary", "golangci/golangci-lint:v1", "mirrors.tencent.com/golangci/golangci-lint:v1"},
+		{"digest reference keeps digest", "alpine@sha256:abc", "mirrors.tencent.com/library/alpine@sha256:abc"},
+		{"other registry", "ghcr.io/org/app:1", "ghcr.example.com/org/app:1"},
+		{"unmatched registry unchanged", "mirrors.example.com/os/minimal:latest", "mirrors.example.com/os/minimal:latest"},
+		{"scratch unchanged", "scratch", "scratch"},
+		{"empty unchanged", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mirrors.RewriteImage(tt.ref))
+		})
+	}
+}
+
+func TestRegistryMirrors_RewriteDownloadURL(t *testing.T) {
+	mirrors := &RegistryMirrorsConfig{MirrorRules: MirrorRules{DownloadURLs: map[string]string{
+		"github.com":                   "ghproxy.example.com/github.com",
+		"https://example.com/releases": "http://cache.internal/releases",
+	}}}
+
+	assert.Equal(t, "https://ghproxy.example.com/github.com/org/tool/v1/tool.tar.gz",
+		mirrors.RewriteDownloadURL("https://github.com/org/tool/v1/tool.tar.gz"))
+	assert.Equal(t, "http://cache.internal/releases/v1/tool.sh",
+		mirrors.RewriteDownloadURL("https://example.com/releases/v1/tool.sh"))
+	assert.Equal(t, "https://github.company.com/tool.sh",
+		mirrors.RewriteDownloadURL("https://github.company.com/tool.sh"), "host must match exactly")
+	assert.Equal(t, "https://example.com/other.sh",
+		mirrors.RewriteDownloadURL("https://example.com/other.sh"))
+}
+
+func TestRegistryMirrors_Profiles(t *testing.T) {
+	data := `
+registry_mirrors:
+  profile: cn
+  images:
+    docker.io: mirrors.tencent.com
+    quay.io: quay.mirrors.tencent.com
+  profiles:
+    cn:
+      images:
+        docker.io: hub.mirrors.cn
+    offline:
+      images:
+        docker.io: registry.local:5000
+`
+	var cfg ServiceConfig
+	require.NoError(t, yaml.Unmarshal([]byte(data), &cfg))
+	mirrors := cfg.RegistryMirrors
+	require.NoError(t, mirrors.Validate())
+
+	// profile 规则覆盖同名默认规则，其余默认规则保留
+	assert.Equal(t, "hub.mirrors.cn/library/alpine:3.19", mirrors.RewriteImage("alpine:3.19"))
+	assert.Equal(t, "quay.mirrors.tencent.com/org/app:1", mirrors.RewriteImage("quay.io/org/app:1"))
+
+	mirrors.Profile = "offline"
+	assert.Equal(t, "registry.local:5000/library/alpine:3.19", mirrors.RewriteImage("alpine:3.19"))
+
+	mirrors.Profile = ""
+	assert.Equal(t, "mirrors.tencent.com/library/alpine:3.19", mirrors.RewriteImage("alpine:3.19"))
+
+	mirrors.Profile = MirrorProfileNone
+	assert.Equal(t, "alpine:3.19", mirrors.RewriteImage("alpine:3.19"))
+
+	mirrors.Profile = "unknown"
+	assert.ErrorContains(t, mirrors.Validate(), "profile 'unknown' not found")
+}
+
+func TestRegistryMirrors_Validate(t *testing.T) {
+	mirrors := RegistryMirrorsConfig{MirrorRules: MirrorRules{Images: map[string]string{"docker.io": ""}}}
+	assert.ErrorContains(t, mirrors.Validate(), "images")
+
+	mirrors = RegistryMirrorsConfig{Profiles: map[string]MirrorRules{
+		"cn": {DownloadURLs: map[string]string{"": "mirror.example.com"}},
+	}}
+	assert.ErrorContains(t, mirrors.Validate(), "profiles.cn.download_urls")
+}
+
+func TestResolveImages_WithRegistryMirrors(t *testing.T) {
+	cfg := &ServiceConfig{
+		Language: LanguageConfig{Type: "go"},
+		Build: BuildConfig{
+			RuntimeImage: NewImageSpec("@runtimes.alpine"),
+		},
+		BaseImages: BaseImagesConfig{
+			Runtimes: map[string]ArchImageConfig{
+				"alpine": {AMD64: "docker.io/alpine:3.18", ARM64: "docker.io/alpine:3.18"},
+			},
+		},
+		RegistryMirrors: RegistryMirrorsConfig{MirrorRules: MirrorRules{Images: map[string]string{
+			"docker.io": "mirrors.tencent.com",
+		}}},
+	}
+
+	// 按语言推导的默认镜像
+	builder, err := ResolveBuilderImageWithDefaults(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "mirrors.tencent.com/library/golang:1.23-alpine", builder.AMD64)
+
+	// 预设引用
+	runtime, err := ResolveRuntimeImageWithDefaults(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "mirrors.tencent.com/library/alpine:3.18", runtime.ARM64)
+
+	// 锁定文件以改写后的引用为 key
+	cfg.ImageLock = &ImageLock{Images: map[string]string{"mirrors.tencent.com/library/alpine:3.18": "sha256:aaa"}}
+	runtime, err = ResolveRuntimeImageWithDefaults(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "mirrors.tencent.com/library/alpine:3.18@sha256:aaa", runtime.AMD64)
+
+	refs, err := LockableImages(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"mirrors.tencent.com/library/alpine:3.18",
+		"mirrors.tencent.com/library/golang:1.23-alpine",
+	}, refs)
+}
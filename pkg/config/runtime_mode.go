@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 健康检查类型常量
+const (
+	HealthcheckTypeDefault = "default" // 脚本：检查进程是否存在
+	HealthcheckTypeCustom  = "custom"  // 脚本：用户自定义 custom_script
+	HealthcheckTypeHTTP    = "http"    // 原生探针：HTTP GET（不依赖容器内 shell）
+	HealthcheckTypeTCP     = "tcp"     // 原生探针：TCP 端口探测（不依赖容器内 shell）
+	HealthcheckTypeBinary  = "binary"  // 编译好的健康检查二进制（exec 形式调用）
+)
+
+// DefaultShellLessEntrypoint shell-less 模式下未配置 startup.command 时的默认启动命令
+const DefaultShellLessEntrypoint = "${SERVICE_BIN_DIR}/${SERVICE_NAME}"
+
+// shellMetaChars 需要 shell 才能解释的字符/片段，shell-less 模式下不允许出现在启动命令中
+var shellMetaChars = []string{"|", "&", ";", ">", "<", "`", "$(", "*", "?", "'", "\"", "\\"}
+
+// EffectiveType 返回健康检查类型（空值视为 default）
+func (h *HealthcheckConfig) EffectiveType() string {
+	if h.Type == "" {
+		return HealthcheckTypeDefault
+	}
+	return h.Type
+}
+
+// RequiresShell 判断健康检查是否依赖生成的 healthchk.sh（需要 /bin/sh）
+func (h *HealthcheckConfig) RequiresShell() bool {
+	switch h.EffectiveType() {
+	case HealthcheckTypeDefault, HealthcheckTypeCustom:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsNativeProbe 判断是否为原生探针（http / tcp），由编排平台执行而非容器内脚本
+func (h *HealthcheckConfig) IsNativeProbe() bool {
+	t := h.EffectiveType()
+	return t == HealthcheckTypeHTTP || t == HealthcheckTypeTCP
+}
+
+// ProbePort 返回原生探针使用的端口，未配置时回退到第一个服务端口
+func (h *HealthcheckConfig) ProbePort(ports []PortConfig) int {
+	if h.Port > 0 {
+		return h.Port
+	}
+	if len(ports) > 0 {
+		return ports[0].Port
+	}
+	return 0
+}
+
+// ProbePath 返回 HTTP 探针路径（默认 /）
+func (h *HealthcheckConfig) ProbePath() string {
+	if h.Path == "" {
+		return "/"
+	}
+	return h.Path
+}
+
+// ExecCommand 返回 binary 类型健康检查的 exec 形式命令
+func (h *HealthcheckConfig) ExecCommand() []string {
+	if h.Binary == "" {
+		return nil
+	}
+	return append([]string{h.Binary}, h.Args...)
+}
+
+// ExecArgs 将启动命令解析为 exec 形式参数（用于 shell-less 模式的 ENTRYPOINT）
+// 规则：
+//   - 忽略空行和注释行（包括 #!/bin/sh）
+//   - 只允许一条命令，可带前缀 exec
+//   - 不允许管道、重定向、引号等需要 shell 解释的语法
+//   - 未配置时使用 DefaultShellLessEntrypoint
+func (s *StartupConfig) ExecArgs() ([]string, error) {
+	var lines []string
+	for _, line := range strings.Split(s.Command, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return strings.Fields(DefaultShellLessEntrypoint), nil
+	}
+	if len(lines) > 1 {
+		return nil, fmt.Errorf("must be a single command without a shell, got %d lines", len(lines))
+	}
+
+	command := lines[0]
+	for _, meta := range shellMetaChars {
+		if strings.Contains(command, meta) {
+			return nil, fmt.Errorf("contains shell syntax %q which cannot run without a shell", meta)
+		}
+	}
+
+	args := strings.Fields(command)
+	if args[0] == "exec" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("command is empty")
+	}
+	if builtin := args[0]; builtin == "cd" || builtin == "export" || builtin == "set" || builtin == "source" || builtin == "." {
+		return nil, fmt.Errorf("shell builtin %q cannot run without a shell", builtin)
+	}
+
+	return args, nil
+}
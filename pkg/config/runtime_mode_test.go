@@ -0,0 +1,215 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestStartupConfig_ExecArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		want    []string
+		wantErr string
+	}{
+		{
+			name:    "empty command uses default binary",
+			command: "",
+			want:    []string{"${SERVICE_BIN_DIR}/${SERVICE_NAME}"},
+		},
+		{
+			name:    "single command with args",
+			command: "${SERVICE_BIN_DIR}/app --config ${CONFIG_DIR}/app.yaml",
+			want:    []string{"${SERVICE_BIN_DIR}/app", "--config", "${CONFIG_DIR}/app.yaml"},
+		},
+		{
+			name:    "exec prefix is stripped",
+			command: "exec ./bin/app",
+			want:    []string{"./bin/app"},
+		},
+		{
+			name:    "shebang and comments are ignored",
+			command: "#!/bin/sh\n# start service\n./bin/app\n",
+			want:    []string{"./bin/app"},
+		},
+		{
+			name:    "multiple commands are rejected",
+			command: "cd ${SERVICE_ROOT}\nexec ./bin/app",
+			wantErr: "single command",
+		},
+		{
+			name:    "pipes are rejected",
+			command: "./bin/app | tee app.log",
+			wantErr: "shell syntax",
+		},
+		{
+			name:    "command substitution is rejected",
+			command: "./bin/app --host $(hostname)",
+			wantErr: "shell syntax",
+		},
+		{
+			name:    "shell builtins are rejected",
+			command: "export FOO=bar",
+			wantErr: "shell builtin",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startup := StartupConfig{Command: tt.command}
+			got, err := startup.ExecArgs()
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExecArgs() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ExecArgs() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExecArgs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHealthcheckConfig_Helpers(t *testing.T) {
+	ports := []PortConfig{{Name: "http", Port: 8080, Protocol: "TCP"}}
+
+	tests := []struct {
+		name          string
+		hc            HealthcheckConfig
+		requiresShell bool
+		nativeProbe   bool
+		probePort     int
+	}{
+		{"empty type is default", HealthcheckConfig{}, true, false, 8080},
+		{"custom script", HealthcheckConfig{Type: "custom"}, true, false, 8080},
+		{"http probe with explicit port", HealthcheckConfig{Type: "http", Port: 9090}, false, true, 9090},
+		{"tcp probe", HealthcheckConfig{Type: "tcp"}, false, true, 8080},
+		{"binary", HealthcheckConfig{Type: "binary", Binary: "/bin/hc"}, false, false, 8080},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hc.RequiresShell(); got != tt.requiresShell {
+				t.Errorf("RequiresShell() = %v, want %v", got, tt.requiresShell)
+			}
+			if got := tt.hc.IsNativeProbe(); got != tt.nativeProbe {
+				t.Errorf("IsNativeProbe() = %v, want %v", got, tt.nativeProbe)
+			}
+			if got := tt.hc.ProbePort(ports); got != tt.probePort {
+				t.Errorf("ProbePort() = %v, want %v", got, tt.probePort)
+			}
+		})
+	}
+
+	hc := HealthcheckConfig{Type: "binary", Binary: "/bin/hc", Args: []string{"-addr", ":8080"}}
+	if got := hc.ExecCommand(); !reflect.DeepEqual(got, []string{"/bin/hc", "-addr", ":8080"}) {
+		t.Errorf("ExecCommand() = %v", got)
+	}
+	if got := (&HealthcheckConfig{}).ProbePath(); got != "/" {
+		t.Errorf("ProbePath() = %q, want /", got)
+	}
+}
+
+func TestValidator_ShellLess(t *testing.T) {
+	newConfig := func() *ServiceConfig {
+		return &ServiceConfig{
+			Service: ServiceInfo{
+				Name:  "static-service",
+				Ports: []PortConfig{{Name: "http", Port: 8080, Protocol: "TCP"}},
+			},
+			Language: LanguageConfig{Type: "go"},
+			Runtime: RuntimeConfig{
+				ShellLess: true,
+				Healthcheck: HealthcheckConfig{
+					Enabled: true,
+					Type:    "http",
+					Path:    "/healthz",
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *ServiceConfig)
+		wantErr string
+	}{
+		{
+			name:   "valid shell-less config without startup command",
+			modify: func(cfg *ServiceConfig) {},
+		},
+		{
+			name: "plugins require a shell",
+			modify: func(cfg *ServiceConfig) {
+				cfg.Plugins.InstallDir = "/plugins"
+				cfg.Plugins.Items = []PluginConfig{{
+					Name:           "agent",
+					DownloadURL:    NewStaticDownloadURL("https://example.com/agent.tar.gz"),
+					InstallCommand: "tar -xzf agent.tar.gz",
+				}}
+			},
+			wantErr: "plugins are not supported",
+		},
+		{
+			name: "runtime packages require rt_prepare",
+			modify: func(cfg *ServiceConfig) {
+				cfg.Runtime.SystemDependencies.Packages = []string{"ca-certificates"}
+			},
+			wantErr: "runtime.system_dependencies.packages",
+		},
+		{
+			name: "script healthcheck requires a shell",
+			modify: func(cfg *ServiceConfig) {
+				cfg.Runtime.Healthcheck.Type = "default"
+			},
+			wantErr: "requires a shell",
+		},
+		{
+			name: "shell startup script is rejected",
+			modify: func(cfg *ServiceConfig) {
+				cfg.Runtime.Startup.Command = "cd ${SERVICE_ROOT}\nexec ./bin/app"
+			},
+			wantErr: "runtime.startup.command",
+		},
+		{
+			name: "binary healthcheck requires binary path",
+			modify: func(cfg *ServiceConfig) {
+				cfg.Runtime.Healthcheck = HealthcheckConfig{Enabled: true, Type: "binary"}
+			},
+			wantErr: "runtime.healthcheck.binary is required",
+		},
+		{
+			name: "tcp probe requires a port",
+			modify: func(cfg *ServiceConfig) {
+				cfg.Service.Ports = nil
+				cfg.Runtime.Healthcheck = HealthcheckConfig{Enabled: true, Type: "tcp"}
+			},
+			wantErr: "runtime.healthcheck.port is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newConfig()
+			tt.modify(cfg)
+
+			err := NewValidator(cfg).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
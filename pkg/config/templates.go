@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// TemplatesConfig 生成模板配置
+type TemplatesConfig struct {
+	// OverrideDir 用户模板目录（相对路径基于 service.yaml 所在目录）
+	// 与内置模板相对路径相同的文件（如 docker/dockerfile/dockerfile_.tmpl）替换内置模板，
+	// 只包含 {{ define }} 块的文件只替换同名的块
+	OverrideDir string `yaml:"override_dir,omitempty"`
+}
+
+// ResolveDir 将相对的 override_dir 转换为基于 baseDir 的路径
+func (t *TemplatesConfig) ResolveDir(baseDir string) {
+	if t.OverrideDir != "" && !filepath.IsAbs(t.OverrideDir) {
+		t.OverrideDir = filepath.Join(baseDir, t.OverrideDir)
+	}
+}
+
+// Validate 检查模板目录是否存在
+func (t *TemplatesConfig) Validate() error {
+	if t.OverrideDir == "" {
+		return nil
+	}
+	info, err := os.Stat(t.OverrideDir)
+	if err != nil {
+		return fmt.Errorf("templates.override_dir: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("templates.override_dir: %s is not a directory", t.OverrideDir)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplatesConfig_ResolveDir(t *testing.T) {
+	cfg := TemplatesConfig{OverrideDir: "templates"}
+	cfg.ResolveDir("/project")
+	assert.Equal(t, filepath.Join("/project", "templates"), cfg.OverrideDir)
+
+	cfg = TemplatesConfig{OverrideDir: "/shared/templates"}
+	cfg.ResolveDir("/project")
+	assert.Equal(t, "/shared/templates", cfg.OverrideDir)
+
+	cfg = TemplatesConfig{}
+	cfg.ResolveDir("/project")
+	assert.Empty(t, cfg.OverrideDir)
+}
+
+func TestTemplatesConfig_Validate(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, (&TemplatesConfig{}).Validate())
+	assert.NoError(t, (&TemplatesConfig{OverrideDir: dir}).Validate())
+
+	err := (&TemplatesConfig{OverrideDir: filepath.Join(dir, "missing")}).Validate()
+	assert.ErrorContains(t, err, "templates.override_dir")
+
+	file := filepath.Join(dir, "file.tmpl")
+	require.NoError(t, os.WriteFile(file, []byte("x"), 0644))
+	assert.ErrorContains(t, (&TemplatesConfig{OverrideDir: file}).Validate(), "is not a directory")
+}
+
+func TestLoader_ResolvesTemplateDir(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "service.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("service:\n  name: demo\ntemplates:\n  override_dir: .svcgen/templates\n"), 0644))
+
+	cfg, err := NewLoader(path).Load()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, ".svcgen/templates"), cfg.Templates.OverrideDir)
+}
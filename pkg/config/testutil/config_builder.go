@@ -238,6 +238,30 @@ func (b *ConfigBuilder) WithCustomHealthcheck(script string) *ConfigBuilder {
 	return b
 }
 
+// WithNativeHealthcheck 设置原生探针健康检查（http / tcp）
+func (b *ConfigBuilder) WithNativeHealthcheck(hcType, path string, port int) *ConfigBuilder {
+	b.cfg.Runtime.Healthcheck.Enabled = true
+	b.cfg.Runtime.Healthcheck.Type = hcType
+	b.cfg.Runtime.Healthcheck.Path = path
+	b.cfg.Runtime.Healthcheck.Port = port
+	return b
+}
+
+// WithBinaryHealthcheck 设置编译好的健康检查二进制
+func (b *ConfigBuilder) WithBinaryHealthcheck(binary string, args ...string) *ConfigBuilder {
+	b.cfg.Runtime.Healthcheck.Enabled = true
+	b.cfg.Runtime.Healthcheck.Type = config.HealthcheckTypeBinary
+	b.cfg.Runtime.Healthcheck.Binary = binary
+	b.cfg.Runtime.Healthcheck.Args = args
+	return b
+}
+
+// WithShellLess 设置无 shell 运行时模式（distroless / scratch）
+func (b *ConfigBuilder) WithShellLess(enabled bool) *ConfigBuilder {
+	b.cfg.Runtime.ShellLess = enabled
+	return b
+}
+
 // ============================================
 // 本地开发配置
 // ============================================
@@ -266,6 +290,25 @@ func (b *ConfigBuilder) WithComposeLabel(key, value string) *ConfigBuilder {
 	return b
 }
 
+// WithCustomTarget 添加 Makefile 自定义 target
+func (b *ConfigBuilder) WithCustomTarget(name, description string, commands ...string) *ConfigBuilder {
+	b.cfg.Makefile.CustomTargets = append(b.cfg.Makefile.CustomTargets, config.CustomTarget{
+		Name:        name,
+		Description: description,
+		Commands:    commands,
+	})
+	return b
+}
+
+// WithVar 添加用户自定义变量（vars:）
+func (b *ConfigBuilder) WithVar(name, value string) *ConfigBuilder {
+	if b.cfg.Vars == nil {
+		b.cfg.Vars = make(map[string]string)
+	}
+	b.cfg.Vars[name] = value
+	return b
+}
+
 // ============================================
 // CI/CD 配置
 // ============================================
@@ -331,7 +374,7 @@ func (b *ConfigBuilder) BuildWithDefaults() *config.ServiceConfig {
 	}
 
 	if b.cfg.Metadata.TemplateVersion == "" {
-		b.cfg.Metadata.TemplateVersion = "2.0.0"
+		b.cfg.Metadata.TemplateVersion = config.CurrentSchemaVersion
 	}
 
 	if b.cfg.Metadata.Generator == "" {
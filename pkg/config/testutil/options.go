@@ -173,6 +173,13 @@ func WithCustomHealthcheckOpt(script string) ConfigOption {
 	}
 }
 
+// WithShellLessOpt 设置无 shell 运行时模式
+func WithShellLessOpt(enabled bool) ConfigOption {
+	return func(cfg *config.ServiceConfig) {
+		cfg.Runtime.ShellLess = enabled
+	}
+}
+
 // ============================================
 // 辅助函数
 // ============================================
@@ -18,7 +18,7 @@ func MinimalConfig() *config.ServiceConfig {
 		WithRuntimeImage("@runtimes.alpine_default").
 		WithBuildCommand("go build -o bin/app").
 		WithStartupCommand("exec ./bin/${SERVICE_NAME}").
-		WithMetadata("2.0.0", "svcgen").
+		WithMetadata(config.CurrentSchemaVersion, "svcgen").
 		BuildWithDefaults()
 }
 
@@ -40,7 +40,7 @@ func GoServiceConfig() *config.ServiceConfig {
 		WithRuntimePackages([]string{"ca-certificates", "tzdata"}).
 		WithStartupCommand("exec ./bin/${SERVICE_NAME}").
 		WithHealthcheck(true, "default").
-		WithMetadata("2.0.0", "svcgen").
+		WithMetadata(config.CurrentSchemaVersion, "svcgen").
 		BuildWithDefaults()
 }
 
@@ -60,7 +60,7 @@ func PythonServiceConfig() *config.ServiceConfig {
 		WithRuntimePackages([]string{"python3", "ca-certificates"}).
 		WithStartupCommand("python3 app.py").
 		WithHealthcheck(true, "default").
-		WithMetadata("2.0.0", "svcgen").
+		WithMetadata(config.CurrentSchemaVersion, "svcgen").
 		BuildWithDefaults()
 }
 
@@ -79,7 +79,7 @@ func JavaServiceConfig() *config.ServiceConfig {
 		WithRuntimePackages([]string{"ca-certificates"}).
 		WithStartupCommand("java -jar app.jar").
 		WithHealthcheck(true, "default").
-		WithMetadata("2.0.0", "svcgen").
+		WithMetadata(config.CurrentSchemaVersion, "svcgen").
 		BuildWithDefaults()
 }
 
@@ -107,7 +107,7 @@ curl -fsSL "${PLUGIN_DOWNLOAD_URL}" | bash -s "${PLUGIN_WORK_DIR}"`,
 			},
 			Required: true,
 		}).
-		WithMetadata("2.0.0", "svcgen").
+		WithMetadata(config.CurrentSchemaVersion, "svcgen").
 		BuildWithDefaults()
 }
 
@@ -125,7 +125,7 @@ func ConfigWithCustomHealthcheck() *config.ServiceConfig {
 		WithStartupCommand("exec ./bin/${SERVICE_NAME}").
 		WithCustomHealthcheck(`#!/bin/sh
 curl -f http://localhost:8080/health || exit 1`).
-		WithMetadata("2.0.0", "svcgen").
+		WithMetadata(config.CurrentSchemaVersion, "svcgen").
 		BuildWithDefaults()
 }
 
@@ -161,7 +161,7 @@ tar -xzf /tmp/jdk.tar.gz -C "${PLUGIN_WORK_DIR}/${PLUGIN_NAME}" --strip-componen
 			},
 			Required: false,
 		}).
-		WithMetadata("2.0.0", "svcgen").
+		WithMetadata(config.CurrentSchemaVersion, "svcgen").
 		BuildWithDefaults()
 }
 
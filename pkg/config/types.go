@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -10,6 +11,10 @@ type ServiceConfig struct {
 	// 基础镜像配置（顶层，与 service 同级）
 	// 仅在使用 @builders.* / @runtimes.* 预设引用时需要配置
 	BaseImages BaseImagesConfig `yaml:"base_images,omitempty"`
+	// 镜像源改写规则（顶层，作用于所有构建/运行时镜像与插件下载地址）
+	RegistryMirrors RegistryMirrorsConfig `yaml:"registry_mirrors,omitempty"`
+	// 镜像安全策略（svcgen validate 时检查所有生效镜像）
+	ImagePolicy ImagePolicyConfig `yaml:"image_policy,omitempty"`
 
 	Service  ServiceInfo    `yaml:"service"`
 	Language LanguageConfig `yaml:"language"`
@@ -20,6 +25,21 @@ type ServiceConfig struct {
 	Makefile MakefileConfig `yaml:"makefile,omitempty"`
 	Metadata MetadataConfig `yaml:"metadata"`
 	CI       CIConfig       `yaml:"ci,omitempty"`
+	// 用户模板覆盖（svcgen templates export 导出内置模板作为起点）
+	Templates TemplatesConfig `yaml:"templates,omitempty"`
+	// 用户自定义变量，值可以引用内置变量和其他用户变量（如 CONF_DIR: ${SERVICE_ROOT}/conf），
+	// 在模板（{{ .CONF_DIR }}）和命令、脚本、卷、Makefile 自定义目标（${CONF_DIR}）中可用
+	Vars map[string]string `yaml:"vars,omitempty"`
+	// 外部生成器（svcgen-gen-* 可执行文件），输出的文件与内置文件一起写入
+	Generators GeneratorsConfig `yaml:"generators,omitempty"`
+
+	// ImageLock 镜像 digest 锁定信息，由 Loader 从同目录的 images.lock.yaml 加载（不属于 service.yaml）
+	ImageLock *ImageLock `yaml:"-"`
+	// Migration 加载时执行的 schema 迁移（仅在内存中生效，svcgen migrate --write 写回文件）
+	Migration *MigrationResult `yaml:"-"`
+	// Explicit service.yaml 中显式设置的字段，Defaults 加载时由默认值填充的字段
+	Explicit FieldSet         `yaml:"-"`
+	Defaults []AppliedDefault `yaml:"-"`
 }
 
 // ServiceInfo contains basic service information
@@ -41,8 +61,11 @@ type PortConfig struct {
 
 // LanguageConfig contains language-specific settings
 type LanguageConfig struct {
-	Type   string                 `yaml:"type"`
-	Config map[string]interface{} `yaml:"config,omitempty"`
+	Type string `yaml:"type"`
+	// Version 语言版本（如 Go "1.23"、Java "21"），决定默认构建/运行时镜像
+	// 未指定时兼容 config 中的 go_version / python_version / jdk_version / node_version / rust_version
+	Version string                 `yaml:"version,omitempty"`
+	Config  map[string]interface{} `yaml:"config,omitempty"`
 }
 
 // GetString gets a string value from config with default
@@ -121,6 +144,21 @@ type BuildConfig struct {
 	RuntimeImage ImageSpec           `yaml:"runtime_image,omitempty"`
 	Dependencies DependenciesConfig  `yaml:"dependencies"`
 	Commands     BuildCommandsConfig `yaml:"commands"`
+	// 不生成的可选 Dockerfile stage（test / lint），同时跳过对应脚本与 Makefile 目标
+	SkipStages []string `yaml:"skip_stages,omitempty"`
+	// 运行时镜像的 OCI 标签（org.opencontainers.image.*）
+	ImageLabels ImageLabelsConfig `yaml:"image_labels,omitempty"`
+}
+
+// ImageLabelsConfig OCI 镜像标签配置
+// title / description 取自 service，revision / version / created 由构建参数注入
+type ImageLabelsConfig struct {
+	Source        string            `yaml:"source,omitempty"`        // 源码仓库地址
+	URL           string            `yaml:"url,omitempty"`           // 项目主页
+	Documentation string            `yaml:"documentation,omitempty"` // 文档地址
+	Vendor        string            `yaml:"vendor,omitempty"`
+	Licenses      string            `yaml:"licenses,omitempty"` // SPDX 表达式
+	Custom        map[string]string `yaml:"custom,omitempty"`   // 额外的自定义标签
 }
 
 // DependencyFilesConfig for dependency file detection
@@ -222,6 +260,10 @@ type BuildCommandsConfig struct {
 	PreBuild  string `yaml:"pre_build,omitempty"`
 	Build     string `yaml:"build"`
 	PostBuild string `yaml:"post_build,omitempty"`
+	// Test / Lint 在独立的 Dockerfile stage（test / lint）中执行，未配置时按语言推导默认命令
+	// 通过 build.skip_stages 关闭
+	Test string `yaml:"test,omitempty"`
+	Lint string `yaml:"lint,omitempty"`
 }
 
 // PluginsConfig for plugins configuration
@@ -259,15 +301,23 @@ type RuntimeConfig struct {
 	SystemDependencies RuntimeSystemDependenciesConfig `yaml:"system_dependencies,omitempty"`
 	Healthcheck        HealthcheckConfig               `yaml:"healthcheck"`
 	Startup            StartupConfig                   `yaml:"startup"`
-	// 控制是否生成运行时脚本的开关
-	GenerateScripts bool `yaml:"generate_scripts,omitempty"`
+	// ShellLess 无 shell 运行时模式（适用于 distroless / scratch 等不含 /bin/sh 的运行时镜像）
+	// 开启后：不生成 rt_prepare.sh / entrypoint.sh / healthchk.sh，
+	// ENTRYPOINT 直接指向二进制，环境变量以 ENV 写入镜像
+	ShellLess bool `yaml:"shell_less,omitempty"`
 }
 
 // HealthcheckConfig for health check settings
 type HealthcheckConfig struct {
 	Enabled      bool   `yaml:"enabled"`
-	Type         string `yaml:"type"`                    // default | custom
+	Type         string `yaml:"type"`                    // default | custom | http | tcp | binary
 	CustomScript string `yaml:"custom_script,omitempty"` // Required when type is 'custom'
+	// 原生探针配置（type 为 http / tcp 时使用）
+	Path string `yaml:"path,omitempty"` // HTTP 探针路径，默认 /
+	Port int    `yaml:"port,omitempty"` // 探针端口，默认取第一个服务端口
+	// 编译好的健康检查二进制（type 为 binary 时必需），以 exec 形式调用
+	Binary string   `yaml:"binary,omitempty"`
+	Args   []string `yaml:"args,omitempty"`
 }
 
 // StartupConfig for startup settings
@@ -410,6 +460,11 @@ func (d DownloadURLConfig) MarshalYAML() (interface{}, error) {
 	return d.value, nil
 }
 
+// MarshalJSON implements json.Marshaler (svcgen vars --format json)
+func (d DownloadURLConfig) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.value)
+}
+
 // IsStatic returns true if the download URL is a static string
 func (d *DownloadURLConfig) IsStatic() bool {
 	_, ok := d.value.(string)
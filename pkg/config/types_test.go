@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -117,6 +118,16 @@ func TestDownloadURLConfig_MarshalYAML_ArchMapping(t *testing.T) {
 	assert.Len(t, urls, 2)
 }
 
+func TestDownloadURLConfig_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(NewStaticDownloadURL("https://example.com/plugin.tar.gz"))
+	require.NoError(t, err)
+	assert.Equal(t, `"https://example.com/plugin.tar.gz"`, string(data))
+
+	data, err = json.Marshal(NewArchMappingDownloadURL(map[string]string{"x86_64": "https://example.com/x86_64.tar.gz"}))
+	require.NoError(t, err)
+	assert.Equal(t, `{"x86_64":"https://example.com/x86_64.tar.gz"}`, string(data))
+}
+
 func TestDownloadURLConfig_GetStaticURL_Error(t *testing.T) {
 	config := NewArchMappingDownloadURL(map[string]string{
 		"x86_64": "https://example.com/plugin.tar.gz",
@@ -2,23 +2,56 @@ package config
 
 import (
 	"fmt"
+	"regexp"
+	"sort"
 	"strings"
 )
 
+// userVariableName is a name that can be referenced as ${NAME} and {{ .NAME }}
+var userVariableName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // Validator validates service configuration
 type Validator struct {
-	config *ServiceConfig
-	errors []string
+	config   *ServiceConfig
+	errors   []string
+	warnings []string
+	// strict 为 true 时，镜像策略的 warning 级别违规也视为错误（用于 CI）
+	strict bool
+	// projectDir 项目根目录，设置后检查 go.mod / .nvmrc 等版本文件与 language.version 是否一致
+	projectDir string
 }
 
 // NewValidator creates a new configuration validator
 func NewValidator(config *ServiceConfig) *Validator {
 	return &Validator{
-		config: config,
-		errors: []string{},
+		config:   config,
+		errors:   []string{},
+		warnings: []string{},
 	}
 }
 
+// WithStrict enables strict mode: image policy warnings are reported as errors
+func (v *Validator) WithStrict(strict bool) *Validator {
+	v.strict = strict
+	return v
+}
+
+// WithProjectDir enables consistency checks between language.version and version files in the project
+func (v *Validator) WithProjectDir(dir string) *Validator {
+	v.projectDir = dir
+	return v
+}
+
+// Warnings returns non-fatal findings collected by the last Validate call
+func (v *Validator) Warnings() []string {
+	return v.warnings
+}
+
+// Errors returns the validation errors collected by the last Validate call
+func (v *Validator) Errors() []string {
+	return v.errors
+}
+
 // Validate performs comprehensive validation of the configuration
 func (v *Validator) Validate() error {
 	// 1. 验证基础镜像配置（必须先验证，因为后续会引用）
@@ -28,14 +61,20 @@ func (v *Validator) Validate() error {
 	v.validateService()
 	v.validateLanguage()
 
-	// 3. 验证镜像引用（依赖 base_images）
+	// 3. 验证镜像引用（依赖 base_images）并检查镜像策略
 	v.validateImageReferences()
+	v.validateImagePolicy()
+	v.validateLanguageVersionFiles()
 
 	// 4. 验证其他配置
+	v.validateRegistryMirrors()
 	v.validateBuild()
 	v.validatePlugins()
 	v.validateRuntime()
 	v.validateLocalDev()
+	v.validateTemplates()
+	v.validateVars()
+	v.validateGenerators()
 
 	if len(v.errors) > 0 {
 		return fmt.Errorf("configuration validation failed:\n  - %s", strings.Join(v.errors, "\n  - "))
@@ -81,6 +120,10 @@ func (v *Validator) validateLanguage() {
 		v.errors = append(v.errors, "language.type is required")
 	} else if !validLanguages[v.config.Language.Type] {
 		v.errors = append(v.errors, fmt.Sprintf("language.type '%s' is not supported (valid: go, python, nodejs, java, rust)", v.config.Language.Type))
+	} else if err := v.config.Language.ValidateVersion(); err != nil {
+		v.errors = append(v.errors, err.Error())
+	} else if warning := v.config.Language.VersionWarning(); warning != "" {
+		v.warnings = append(v.warnings, warning)
 	}
 
 	// Config is optional, no validation needed
@@ -139,6 +182,37 @@ func (v *Validator) validateImageReferences() {
 	}
 }
 
+// validateImagePolicy 按 image_policy 检查所有生效镜像
+// 无法解析的镜像引用由 EvaluateImagePolicy 跳过，其余镜像即使存在其他校验错误也照常检查
+func (v *Validator) validateImagePolicy() {
+	if err := v.config.ImagePolicy.Validate(); err != nil {
+		v.errors = append(v.errors, fmt.Sprintf("image_policy: %v", err))
+		return
+	}
+
+	for _, violation := range EvaluateImagePolicy(v.config) {
+		if v.strict || violation.Severity == PolicySeverityError {
+			v.errors = append(v.errors, violation.String())
+		} else {
+			v.warnings = append(v.warnings, violation.String())
+		}
+	}
+}
+
+// validateLanguageVersionFiles 检查项目版本文件与 language.version 的一致性（默认为警告）
+func (v *Validator) validateLanguageVersionFiles() {
+	if v.projectDir == "" {
+		return
+	}
+	for _, warning := range CheckLanguageVersionFiles(&v.config.Language, v.projectDir) {
+		if v.strict {
+			v.errors = append(v.errors, warning)
+		} else {
+			v.warnings = append(v.warnings, warning)
+		}
+	}
+}
+
 func (v *Validator) validateBuild() {
 	// 镜像验证已在 validateImageReferences 中完成
 
@@ -151,6 +225,51 @@ func (v *Validator) validateBuild() {
 			))
 		}
 	}
+
+	// auto_detect 显式关闭且未列出文件时，Dockerfile 不会复制任何依赖清单
+	deps := v.config.Build.DependencyFiles
+	if v.config.IsSet("build.dependency_files.auto_detect") && !deps.AutoDetect && len(deps.Files) == 0 {
+		v.warnings = append(v.warnings,
+			"build.dependency_files.auto_detect is false and no files are listed; no dependency files will be copied before the build")
+	}
+
+	for i, stage := range v.config.Build.SkipStages {
+		if stage != StageTest && stage != StageLint {
+			v.errors = append(v.errors, fmt.Sprintf("build.skip_stages[%d]: unknown stage '%s' (valid: %s)",
+				i, stage, strings.Join(OptionalStages, ", ")))
+		}
+	}
+}
+
+func (v *Validator) validateTemplates() {
+	if err := v.config.Templates.Validate(); err != nil {
+		v.errors = append(v.errors, err.Error())
+	}
+}
+
+// validateVars checks user variable names; collisions with built-in variables and
+// reference cycles are checked by the generator, which knows the built-in variables
+func (v *Validator) validateVars() {
+	names := make([]string, 0, len(v.config.Vars))
+	for name := range v.config.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !userVariableName.MatchString(name) {
+			v.errors = append(v.errors, fmt.Sprintf("vars.%s: variable names must match [A-Za-z_][A-Za-z0-9_]*", name))
+		}
+	}
+}
+
+func (v *Validator) validateGenerators() {
+	v.errors = append(v.errors, v.config.Generators.Validate()...)
+}
+
+func (v *Validator) validateRegistryMirrors() {
+	if err := v.config.RegistryMirrors.Validate(); err != nil {
+		v.errors = append(v.errors, fmt.Sprintf("registry_mirrors: %v", err))
+	}
 }
 
 func (v *Validator) validatePlugins() {
@@ -206,38 +325,83 @@ func (v *Validator) validatePlugins() {
 }
 
 func (v *Validator) validateRuntime() {
+	hc := &v.config.Runtime.Healthcheck
+
 	// Validate healthcheck configuration
-	if v.config.Runtime.Healthcheck.Enabled {
+	if hc.Enabled {
 		// Validate healthcheck type
 		validTypes := map[string]bool{
-			"default": true,
-			"custom":  true,
-			"":        true, // Empty defaults to "default"
+			HealthcheckTypeDefault: true,
+			HealthcheckTypeCustom:  true,
+			HealthcheckTypeHTTP:    true,
+			HealthcheckTypeTCP:     true,
+			HealthcheckTypeBinary:  true,
 		}
 
-		hcType := v.config.Runtime.Healthcheck.Type
-		if hcType == "" {
-			hcType = "default" // Set default value
-		}
+		hcType := hc.EffectiveType()
 
 		if !validTypes[hcType] {
-			v.errors = append(v.errors, fmt.Sprintf("runtime.healthcheck.type '%s' is not valid (valid: default, custom)", hcType))
+			v.errors = append(v.errors, fmt.Sprintf("runtime.healthcheck.type '%s' is not valid (valid: default, custom, http, tcp, binary)", hcType))
 		}
 
-		// Validate custom healthcheck requirements
-		if hcType == "custom" {
-			if v.config.Runtime.Healthcheck.CustomScript == "" {
+		switch hcType {
+		case HealthcheckTypeCustom:
+			// Validate custom healthcheck requirements
+			if hc.CustomScript == "" {
 				v.errors = append(v.errors, "runtime.healthcheck.custom_script is required when type is 'custom'")
 			}
+		case HealthcheckTypeHTTP, HealthcheckTypeTCP:
+			port := hc.ProbePort(v.config.Service.Ports)
+			if port <= 0 || port > 65535 {
+				v.errors = append(v.errors, fmt.Sprintf(
+					"runtime.healthcheck.port is required when type is '%s' and no service port is configured", hcType))
+			}
+			if hcType == HealthcheckTypeHTTP && hc.Path != "" && !strings.HasPrefix(hc.Path, "/") {
+				v.errors = append(v.errors, "runtime.healthcheck.path must start with '/'")
+			}
+		case HealthcheckTypeBinary:
+			if hc.Binary == "" {
+				v.errors = append(v.errors, "runtime.healthcheck.binary is required when type is 'binary'")
+			}
 		}
 	}
 
+	if v.config.Runtime.ShellLess {
+		v.validateShellLessRuntime()
+		return
+	}
+
 	// Validate startup command
 	if v.config.Runtime.Startup.Command == "" {
 		v.errors = append(v.errors, "runtime.startup.command is required")
 	}
 }
 
+// validateShellLessRuntime 验证 shell-less 运行时模式
+// 运行时镜像中没有 /bin/sh，所有依赖 shell 的配置组合都需要拒绝
+func (v *Validator) validateShellLessRuntime() {
+	if len(v.config.Plugins.Items) > 0 {
+		v.errors = append(v.errors,
+			"plugins are not supported when runtime.shell_less is enabled (plugins are installed by /plugins/install.sh, which requires a shell)")
+	}
+
+	if len(v.config.Runtime.SystemDependencies.Packages) > 0 {
+		v.errors = append(v.errors,
+			"runtime.system_dependencies.packages is not supported when runtime.shell_less is enabled (rt_prepare.sh requires a shell)")
+	}
+
+	hc := &v.config.Runtime.Healthcheck
+	if hc.Enabled && hc.RequiresShell() {
+		v.errors = append(v.errors, fmt.Sprintf(
+			"runtime.healthcheck.type '%s' requires a shell; use http, tcp or binary when runtime.shell_less is enabled",
+			hc.EffectiveType()))
+	}
+
+	if _, err := v.config.Runtime.Startup.ExecArgs(); err != nil {
+		v.errors = append(v.errors, fmt.Sprintf("runtime.startup.command: %v (runtime.shell_less is enabled)", err))
+	}
+}
+
 func (v *Validator) validateLocalDev() {
 	if v.config.LocalDev.Kubernetes.Enabled {
 		validVolumeTypes := map[string]bool{
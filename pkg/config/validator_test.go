@@ -264,7 +264,7 @@ func TestValidator_ValidateHealthcheck(t *testing.T) {
 			name: "invalid healthcheck type",
 			healthcheck: HealthcheckConfig{
 				Enabled: true,
-				Type:    "http",
+				Type:    "grpc",
 			},
 			wantErr: true,
 			errMsg:  "is not valid",
@@ -317,3 +317,72 @@ func TestValidator_ValidateHealthcheck(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_Vars(t *testing.T) {
+	cfg := &ServiceConfig{
+		Service:  ServiceInfo{Name: "demo"},
+		Language: LanguageConfig{Type: "go"},
+		Runtime:  RuntimeConfig{Startup: StartupConfig{Command: "./demo"}},
+		Vars: map[string]string{
+			"CONF_DIR":  "${SERVICE_ROOT}/conf",
+			"_private1": "x",
+			"bad-name":  "y",
+			"1ST":       "z",
+		},
+	}
+
+	err := NewValidator(cfg).Validate()
+	if err == nil {
+		t.Fatal("Validate() should reject invalid variable names")
+	}
+	for _, name := range []string{"vars.bad-name", "vars.1ST"} {
+		if !strings.Contains(err.Error(), name) {
+			t.Errorf("Validate() error %q should mention %s", err, name)
+		}
+	}
+	for _, name := range []string{"vars.CONF_DIR", "vars._private1"} {
+		if strings.Contains(err.Error(), name) {
+			t.Errorf("Validate() error %q should not mention %s", err, name)
+		}
+	}
+}
+
+func TestValidator_SkipStages(t *testing.T) {
+	cfg := &ServiceConfig{
+		Service:  ServiceInfo{Name: "demo"},
+		Language: LanguageConfig{Type: "go"},
+		Build:    BuildConfig{SkipStages: []string{"lint", "tests"}},
+		Runtime:  RuntimeConfig{Startup: StartupConfig{Command: "./demo"}},
+	}
+
+	err := NewValidator(cfg).Validate()
+	if err == nil {
+		t.Fatal("Validate() should reject unknown stages")
+	}
+	if !strings.Contains(err.Error(), "build.skip_stages[1]: unknown stage 'tests' (valid: test, lint)") {
+		t.Errorf("Validate() error %q should mention the unknown stage", err)
+	}
+	if strings.Contains(err.Error(), "skip_stages[0]") {
+		t.Errorf("Validate() error %q should accept lint", err)
+	}
+}
+
+func TestValidator_Generators(t *testing.T) {
+	cfg := &ServiceConfig{
+		Service:  ServiceInfo{Name: "demo"},
+		Language: LanguageConfig{Type: "go"},
+		Runtime:  RuntimeConfig{Startup: StartupConfig{Command: "./demo"}},
+		Generators: GeneratorsConfig{Items: []ExternalGeneratorConfig{
+			{Name: "catalog"},
+			{Name: "catalog"},
+		}},
+	}
+
+	err := NewValidator(cfg).Validate()
+	if err == nil {
+		t.Fatal("Validate() should reject duplicated generator names")
+	}
+	if !strings.Contains(err.Error(), `generators.items[1].name "catalog" is duplicated`) {
+		t.Errorf("Validate() error %q should mention the duplicated generator", err)
+	}
+}
@@ -0,0 +1,351 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ============================================
+// yaml.Node 编辑工具
+// 直接修改节点树（而非 struct 往返）可以保留用户的注释与字段顺序
+// ============================================
+
+// parseDocument 解析 YAML 文档，返回文档节点与顶层 mapping（空文档时创建空 mapping）
+func parseDocument(data []byte) (*yaml.Node, *yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{newMappingNode()}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("failed to parse config: top level must be a mapping")
+	}
+	return &doc, root, nil
+}
+
+// encodeDocument 以两空格缩进输出文档
+func encodeDocument(doc *yaml.Node) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func newMappingNode() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func newStringNode(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+// mappingIndex 返回 key 在 mapping.Content 中的下标，不存在时返回 -1
+func mappingIndex(m *yaml.Node, key string) int {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return -1
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return i
+		}
+	}
+	return -1
+}
+
+// mappingValue 返回 key 对应的值节点，不存在时返回 nil
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if i := mappingIndex(m, key); i >= 0 {
+		return m.Content[i+1]
+	}
+	return nil
+}
+
+// lookupNode 按路径逐层查找 mapping 中的值节点
+func lookupNode(m *yaml.Node, path ...string) *yaml.Node {
+	for _, key := range path {
+		m = mappingValue(m, key)
+		if m == nil {
+			return nil
+		}
+	}
+	return m
+}
+
+// mappingSet 设置 key 的值：已存在时原位替换，否则追加到末尾
+func mappingSet(m *yaml.Node, key string, value *yaml.Node) {
+	if i := mappingIndex(m, key); i >= 0 {
+		m.Content[i+1] = value
+		return
+	}
+	m.Content = append(m.Content, newStringNode(key), value)
+}
+
+// mappingEnsure 返回 key 对应的 mapping，不存在（或不是 mapping）时创建
+func mappingEnsure(m *yaml.Node, key string) *yaml.Node {
+	if v := mappingValue(m, key); v != nil && v.Kind == yaml.MappingNode {
+		return v
+	}
+	child := newMappingNode()
+	mappingSet(m, key, child)
+	return child
+}
+
+// mappingDelete 删除 key，返回被删除的值节点（不存在时返回 nil）
+func mappingDelete(m *yaml.Node, key string) *yaml.Node {
+	i := mappingIndex(m, key)
+	if i < 0 {
+		return nil
+	}
+	value := m.Content[i+1]
+	m.Content = append(m.Content[:i], m.Content[i+2:]...)
+	return value
+}
+
+// mappingRename 原位重命名 key（保留位置与注释），newKey 已存在时不做修改并返回 false
+func mappingRename(m *yaml.Node, key, newKey string) bool {
+	i := mappingIndex(m, key)
+	if i < 0 || mappingIndex(m, newKey) >= 0 {
+		return false
+	}
+	m.Content[i].Value = newKey
+	return true
+}
+
+var topLevelKeyLine = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_-]*):`)
+
+// restoreSectionSpacing 恢复顶层字段之间的空行
+// yaml.v3 重新编码时会丢弃空行，这里按原文中哪些顶层字段（连同其上方注释）前有空行来补回
+func restoreSectionSpacing(original, encoded []byte) []byte {
+	spaced := map[string]bool{}
+	lines := strings.Split(string(original), "\n")
+	for i, line := range lines {
+		m := topLevelKeyLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		j := i - 1
+		for j >= 0 && strings.HasPrefix(lines[j], "#") {
+			j--
+		}
+		if j >= 0 && strings.TrimSpace(lines[j]) == "" {
+			spaced[m[1]] = true
+		}
+	}
+
+	out := strings.Split(string(encoded), "\n")
+	var result []string
+	blockStart := -1 // 当前连续顶层注释块在 result 中的起点
+	for _, line := range out {
+		if strings.HasPrefix(line, "#") {
+			if blockStart < 0 {
+				blockStart = len(result)
+			}
+			result = append(result, line)
+			continue
+		}
+		if m := topLevelKeyLine.FindStringSubmatch(line); m != nil && spaced[m[1]] {
+			at := len(result)
+			if blockStart >= 0 {
+				at = blockStart
+			}
+			if at > 0 && result[at-1] != "" {
+				result = append(result[:at], append([]string{""}, result[at:]...)...)
+			}
+		}
+		blockStart = -1
+		result = append(result, line)
+	}
+	return []byte(strings.Join(result, "\n"))
+}
+
+// renderScalar 将标量节点渲染为单行 YAML 文本（多行时返回 false）
+func renderScalar(node *yaml.Node) (string, bool) {
+	out, err := yaml.Marshal(node)
+	if err != nil {
+		return "", false
+	}
+	text := strings.TrimSuffix(string(out), "\n")
+	if strings.Contains(text, "\n") {
+		return "", false
+	}
+	return text, true
+}
+
+// replaceScalarText 在原文中原位替换单行标量节点的文本，保留同一行的注释
+// 节点位置信息无效、跨行或为块标量时返回 false
+func replaceScalarText(data []byte, node *yaml.Node, text string) ([]byte, bool) {
+	if node.Kind != yaml.ScalarNode || node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return nil, false
+	}
+	lines := strings.Split(string(data), "\n")
+	if node.Line < 1 || node.Line > len(lines) || node.Column < 1 {
+		return nil, false
+	}
+	line := lines[node.Line-1]
+	start := node.Column - 1
+	if start > len(line) {
+		return nil, false
+	}
+
+	var end int
+	switch {
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		end = quotedEnd(line, start, '"')
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		end = quotedEnd(line, start, '\'')
+	default:
+		end = len(line)
+		if i := strings.Index(line[start:], " #"); i >= 0 {
+			end = start + i
+		}
+		for end > start && (line[end-1] == ' ' || line[end-1] == '\t') {
+			end--
+		}
+		if line[start:end] != node.Value {
+			// 多行 plain 标量或 tag / anchor 等情况
+			return nil, false
+		}
+	}
+	if end < 0 {
+		return nil, false
+	}
+
+	lines[node.Line-1] = line[:start] + text + line[end:]
+	return []byte(strings.Join(lines, "\n")), true
+}
+
+// quotedEnd 返回从 start 开始的引号字符串结束位置（不含），未在本行结束时返回 -1
+func quotedEnd(line string, start int, quote byte) int {
+	if start >= len(line) || line[start] != quote {
+		return -1
+	}
+	for i := start + 1; i < len(line); i++ {
+		switch {
+		case quote == '"' && line[i] == '\\':
+			i++
+		case line[i] == quote:
+			if quote == '\'' && i+1 < len(line) && line[i+1] == '\'' {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// insertion 一次对已有块 mapping / 列表的追加，用于在原文中就地插入
+type insertion struct {
+	// parent 已存在的 mapping 或列表（追加项已位于 Content 末尾）
+	parent *yaml.Node
+	// key mapping 新增字段的键节点；列表追加时为 nil
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// spliceInsertion 将追加的字段 / 列表项渲染后插入原文中 parent 最后一项之后
+// 只处理至少已有一项的块格式节点；其余情况返回 false，由调用方重新编码整个文档
+func spliceInsertion(data []byte, ins insertion) ([]byte, bool) {
+	parent := ins.parent
+	if parent.Style&yaml.FlowStyle != 0 {
+		return nil, false
+	}
+	lines := strings.Split(string(data), "\n")
+
+	var indent, end, lastLine int
+	var rendered *yaml.Node
+	switch parent.Kind {
+	case yaml.MappingNode:
+		if len(parent.Content) < 4 {
+			return nil, false
+		}
+		first, lastKey, lastValue := parent.Content[0], parent.Content[len(parent.Content)-4], parent.Content[len(parent.Content)-3]
+		if first.Line < 1 || lastKey.Line < 1 {
+			return nil, false
+		}
+		indent = first.Column - 1
+		end = max(nodeEndLine(lastKey), nodeEndLine(lastValue))
+		lastLine = lastKey.Line
+		rendered = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Content: []*yaml.Node{ins.key, ins.value}}
+	case yaml.SequenceNode:
+		if len(parent.Content) < 2 {
+			return nil, false
+		}
+		first, last := parent.Content[0], parent.Content[len(parent.Content)-2]
+		if first.Line < 1 || first.Line > len(lines) || last.Line < 1 {
+			return nil, false
+		}
+		line := lines[first.Line-1]
+		indent = strings.LastIndex(line[:min(first.Column-1, len(line))], "-")
+		if indent < 0 || strings.TrimSpace(line[:indent]) != "" {
+			return nil, false
+		}
+		end = nodeEndLine(last)
+		lastLine = last.Line
+		rendered = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq", Content: []*yaml.Node{ins.value}}
+	default:
+		return nil, false
+	}
+	if end > len(lines) {
+		return nil, false
+	}
+
+	// 继续包含缩进更深的行（注释、多行 plain 标量等）
+	for i := end; i < len(lines); i++ {
+		if strings.TrimSpace(lines[i]) == "" {
+			continue
+		}
+		if len(lines[i])-len(strings.TrimLeft(lines[i], " ")) <= indent {
+			break
+		}
+		end = i + 1
+	}
+
+	content, err := encodeDocument(rendered)
+	if err != nil {
+		return nil, false
+	}
+	// 顶层字段之间、或原有各项之间以空行分隔时，新增项前同样补一个空行
+	var block []string
+	if (indent == 0 && parent.Kind == yaml.MappingNode) || (lastLine >= 2 && strings.TrimSpace(lines[lastLine-2]) == "") {
+		block = append(block, "")
+	}
+	prefix := strings.Repeat(" ", indent)
+	for _, line := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+		if line == "" {
+			block = append(block, line)
+		} else {
+			block = append(block, prefix+line)
+		}
+	}
+
+	result := append(append(append([]string{}, lines[:end]...), block...), lines[end:]...)
+	return []byte(strings.Join(result, "\n")), true
+}
+
+// nodeEndLine 节点在原文中的最后一行（1-based），块标量按内容行数计算
+func nodeEndLine(node *yaml.Node) int {
+	end := node.Line
+	if node.Kind == yaml.ScalarNode && node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		end += strings.Count(strings.TrimRight(node.Value, "\n"), "\n") + 1
+	}
+	for _, child := range node.Content {
+		if e := nodeEndLine(child); e > end {
+			end = e
+		}
+	}
+	return end
+}
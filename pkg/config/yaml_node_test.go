@@ -0,0 +1,81 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestMappingHelpers(t *testing.T) {
+	_, root, err := parseDocument([]byte("a:\n  b: 1\nc: 2\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1", lookupNode(root, "a", "b").Value)
+	assert.Nil(t, lookupNode(root, "a", "missing"))
+
+	assert.True(t, mappingRename(root, "c", "d"))
+	assert.False(t, mappingRename(root, "a", "d"), "target key exists")
+	mappingSet(mappingEnsure(root, "e"), "f", newStringNode("3"))
+	assert.Equal(t, "2", mappingDelete(root, "d").Value)
+	assert.Nil(t, mappingDelete(root, "d"))
+
+	doc, _, err := parseDocument([]byte("a:\n  b: 1\ne:\n  f: \"3\"\n"))
+	require.NoError(t, err)
+	expected, err := encodeDocument(doc)
+	require.NoError(t, err)
+
+	doc.Content[0] = root
+	actual, err := encodeDocument(doc)
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func TestRestoreSectionSpacing(t *testing.T) {
+	original := "# header\nservice:\n  name: a\n\n# build section\nbuild:\n  x: 1\n\nmetadata: {}\n"
+	encoded := "# header\nservice:\n  name: a\n# build section\nbuild:\n  y: 2\nmetadata: {}\n"
+
+	assert.Equal(t,
+		"# header\nservice:\n  name: a\n\n# build section\nbuild:\n  y: 2\n\nmetadata: {}\n",
+		string(restoreSectionSpacing([]byte(original), []byte(encoded))))
+}
+
+func TestReplaceScalarText(t *testing.T) {
+	data := []byte("a: \"x \\\" y\" # note\nb: 'it''s'\nc: plain value # keep\nd: |\n  block\n")
+	_, root, err := parseDocument(data)
+	require.NoError(t, err)
+
+	out, ok := replaceScalarText(data, mappingValue(root, "a"), `"z"`)
+	require.True(t, ok)
+	out, ok = replaceScalarText(out, mappingValue(root, "b"), `'ok'`)
+	require.True(t, ok)
+	out, ok = replaceScalarText(out, mappingValue(root, "c"), "other")
+	require.True(t, ok)
+	assert.Equal(t, "a: \"z\" # note\nb: 'ok'\nc: other # keep\nd: |\n  block\n", string(out))
+
+	_, ok = replaceScalarText(data, mappingValue(root, "d"), "x")
+	assert.False(t, ok, "block scalars are not replaced in place")
+}
+
+func TestSpliceInsertion(t *testing.T) {
+	data := []byte("list:\n  - a\n  # trailing\nmap:\n  x: 1\n  y: |\n    text\nother: 2\n")
+	_, root, err := parseDocument(data)
+	require.NoError(t, err)
+
+	list := mappingValue(root, "list")
+	list.Content = append(list.Content, newStringNode("b"))
+	out, ok := spliceInsertion(data, insertion{parent: list, value: list.Content[1]})
+	require.True(t, ok)
+	assert.Equal(t, "list:\n  - a\n  - b\n  # trailing\nmap:\n  x: 1\n  y: |\n    text\nother: 2\n", string(out))
+
+	m := mappingValue(root, "map")
+	mappingSet(m, "z", newStringNode("3"))
+	out, ok = spliceInsertion(data, insertion{parent: m, key: m.Content[4], value: m.Content[5]})
+	require.True(t, ok)
+	assert.Equal(t, "list:\n  - a\n  # trailing\nmap:\n  x: 1\n  y: |\n    text\n  z: \"3\"\nother: 2\n", string(out))
+
+	flow := &yaml.Node{Kind: yaml.SequenceNode, Style: yaml.FlowStyle}
+	_, ok = spliceInsertion(data, insertion{parent: flow, value: newStringNode("c")})
+	assert.False(t, ok)
+}
@@ -9,6 +9,7 @@ const (
 	CategoryCIPaths  = "ci-paths" // CI path variables
 	CategoryService  = "service"  // Service-related variables
 	CategoryLanguage = "language" // Language-related variables
+	CategoryUser     = "user"     // User-defined variables (vars:)
 )
 
 // Path constants - 路径常量
@@ -33,6 +34,8 @@ const (
 	EntrypointScriptName   = "entrypoint.sh"
 	HealthcheckScriptName  = "healthchk.sh"
 	BuildPluginsScriptName = "build_plugins.sh"
+	TestScriptName         = "test.sh"
+	LintScriptName         = "lint.sh"
 )
 
 // Directory names - 目录名常量
@@ -64,6 +67,8 @@ const (
 	VarBuildCommand     = "BUILD_COMMAND"
 	VarPreBuildCommand  = "PRE_BUILD_COMMAND"
 	VarPostBuildCommand = "POST_BUILD_COMMAND"
+	VarTestCommand      = "TEST_COMMAND"
+	VarLintCommand      = "LINT_COMMAND"
 	VarBuildOutputDir   = "BUILD_OUTPUT_DIR"
 	VarProjectRoot      = "PROJECT_ROOT"
 
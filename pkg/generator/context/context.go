@@ -2,6 +2,7 @@ package context
 
 import (
 	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/vfs"
 )
 
 // GeneratorContext holds all context information needed for generation
@@ -15,8 +16,19 @@ type GeneratorContext struct {
 	// OutputDir is the output directory for generated files
 	OutputDir string
 
+	// FS is the output directory as a file system: project files are detected in it
+	// and generated files are written to it (defaults to the host directory OutputDir)
+	FS vfs.FS
+
 	// VariablePool manages shared variables (Flyweight Pattern)
 	VariablePool *VariablePool
+
+	// StrictTemplates makes templates fail on references to missing variables
+	// instead of rendering "<no value>"
+	StrictTemplates bool
+
+	// RecordVariables, when set, receives the variables each template is rendered with (svcgen vars)
+	RecordVariables func(templatePath string, vars map[string]interface{})
 }
 
 // NewGeneratorContext creates a new generator context
@@ -27,6 +39,7 @@ func NewGeneratorContext(cfg *config.ServiceConfig, outputDir string) *Generator
 		Config:    cfg,
 		Paths:     paths,
 		OutputDir: outputDir,
+		FS:        vfs.OS(outputDir),
 	}
 
 	// Initialize variable pool
@@ -71,6 +71,8 @@ type CIPaths struct {
 	EntrypointScript   string // entrypoint.sh
 	HealthcheckScript  string // healthchk.sh
 	BuildPluginsScript string // build_plugins.sh
+	TestScript         string // test.sh
+	LintScript         string // lint.sh
 }
 
 // NewCIPaths 创建 CI 路径管理器
@@ -119,6 +121,8 @@ func NewCIPaths(cfg *config.ServiceConfig) *CIPaths {
 		EntrypointScript:   EntrypointScriptName,
 		HealthcheckScript:  HealthcheckScriptName,
 		BuildPluginsScript: BuildPluginsScriptName,
+		TestScript:         TestScriptName,
+		LintScript:         LintScriptName,
 	}
 }
 
@@ -141,6 +145,8 @@ func (p *CIPaths) GetAllScriptPaths() map[string]string {
 		"entrypoint-script":    p.GetScriptPath(p.EntrypointScript),
 		"healthcheck-script":   p.GetScriptPath(p.HealthcheckScript),
 		"build-plugins-script": p.GetScriptPath(p.BuildPluginsScript),
+		"test-script":          p.GetScriptPath(p.TestScript),
+		"lint-script":          p.GetScriptPath(p.LintScript),
 	}
 }
 
@@ -159,6 +165,8 @@ func (p *CIPaths) ToTemplateVars() map[string]interface{} {
 		"ENTRYPOINT_SCRIPT":    p.EntrypointScript,
 		"HEALTHCHECK_SCRIPT":   p.HealthcheckScript,
 		"BUILD_PLUGINS_SCRIPT": p.BuildPluginsScript,
+		"TEST_SCRIPT":          p.TestScript,
+		"LINT_SCRIPT":          p.LintScript,
 
 		// 完整路径（主机）
 		"BUILD_SCRIPT_PATH":         p.GetScriptPath(p.BuildScript),
@@ -167,6 +175,8 @@ func (p *CIPaths) ToTemplateVars() map[string]interface{} {
 		"ENTRYPOINT_SCRIPT_PATH":    p.GetScriptPath(p.EntrypointScript),
 		"HEALTHCHECK_SCRIPT_PATH":   p.GetScriptPath(p.HealthcheckScript),
 		"BUILD_PLUGINS_SCRIPT_PATH": p.GetScriptPath(p.BuildPluginsScript),
+		"TEST_SCRIPT_PATH":          p.GetScriptPath(p.TestScript),
+		"LINT_SCRIPT_PATH":          p.GetScriptPath(p.LintScript),
 
 		// 完整路径（容器）
 		"BUILD_SCRIPT_CONTAINER_PATH":         p.GetContainerScriptPath(p.BuildScript),
@@ -175,5 +185,7 @@ func (p *CIPaths) ToTemplateVars() map[string]interface{} {
 		"ENTRYPOINT_SCRIPT_CONTAINER_PATH":    p.GetContainerScriptPath(p.EntrypointScript),
 		"HEALTHCHECK_SCRIPT_CONTAINER_PATH":   p.GetContainerScriptPath(p.HealthcheckScript),
 		"BUILD_PLUGINS_SCRIPT_CONTAINER_PATH": p.GetContainerScriptPath(p.BuildPluginsScript),
+		"TEST_SCRIPT_CONTAINER_PATH":          p.GetContainerScriptPath(p.TestScript),
+		"LINT_SCRIPT_CONTAINER_PATH":          p.GetContainerScriptPath(p.LintScript),
 	}
 }
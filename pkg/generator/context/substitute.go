@@ -0,0 +1,260 @@
+package context
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ============================================
+// 生成期变量替换
+// 支持 ${VAR}、${VAR:-default}（变量为空时使用默认值）和 $${VAR} 转义（输出字面量 ${VAR}，留给运行时 shell）。
+// 未知变量原样保留，由运行时 shell 展开；变量值中的引用递归展开，循环引用会被检测并报告。
+// ============================================
+
+// SubstitutionCycleError 变量之间存在循环引用
+type SubstitutionCycleError struct {
+	// Chain 循环链，首尾为同一个变量，如 [A B A]
+	Chain []string
+}
+
+func (e *SubstitutionCycleError) Error() string {
+	return fmt.Sprintf("variable reference cycle: %s", strings.Join(e.Chain, " -> "))
+}
+
+// Substitutor 基于词法扫描的变量替换器，结果与 map 遍历顺序无关
+type Substitutor struct {
+	vars map[string]string
+	// keepEscapes 为 true 时 $${VAR} 原样输出（文本之后还会经过 shell / make / compose 处理）
+	keepEscapes bool
+}
+
+// NewSubstitutor creates a substitutor from template variables (values are formatted with fmt.Sprint)
+func NewSubstitutor(vars map[string]interface{}) *Substitutor {
+	values := make(map[string]string, len(vars))
+	for key, value := range vars {
+		values[key] = fmt.Sprint(value)
+	}
+	return &Substitutor{vars: values}
+}
+
+// NewStringSubstitutor creates a substitutor from string variables
+func NewStringSubstitutor(vars map[string]string) *Substitutor {
+	values := make(map[string]string, len(vars))
+	for key, value := range vars {
+		values[key] = value
+	}
+	return &Substitutor{vars: values}
+}
+
+// Expand 展开 text 中的变量引用
+// 发生循环引用时，循环中的引用原样保留并返回 *SubstitutionCycleError
+func (s *Substitutor) Expand(text string) (string, error) {
+	var firstErr error
+	result := s.expand(text, nil, &firstErr)
+	return result, firstErr
+}
+
+// ExpandLenient 展开变量引用，忽略循环引用错误（循环中的引用原样保留）
+func (s *Substitutor) ExpandLenient(text string) string {
+	result, _ := s.Expand(text)
+	return result
+}
+
+// ExpandKnown 只展开已知变量的引用，$${VAR} 转义和未知变量原样保留，用于之后还会被
+// shell、make 或 compose 解释的文本（其中 $$ 有各自的含义）；循环引用原样保留
+func (s *Substitutor) ExpandKnown(text string) string {
+	keep := *s
+	keep.keepEscapes = true
+	return keep.ExpandLenient(text)
+}
+
+func (s *Substitutor) expand(text string, stack []string, firstErr *error) string {
+	var buf strings.Builder
+	for _, tok := range tokenizeVariables(text) {
+		switch tok.kind {
+		case tokenLiteral:
+			buf.WriteString(tok.text)
+		case tokenEscape:
+			if s.keepEscapes {
+				buf.WriteString(tok.text)
+				continue
+			}
+			// $${VAR} -> ${VAR}
+			buf.WriteString(tok.text[1:])
+		case tokenVariable:
+			buf.WriteString(s.expandVariable(tok, stack, firstErr))
+		}
+	}
+	return buf.String()
+}
+
+func (s *Substitutor) expandVariable(tok variableToken, stack []string, firstErr *error) string {
+	value, known := s.vars[tok.name]
+	if !tok.expandable || !known {
+		if tok.hasDefault {
+			// 未知变量留给运行时 shell，默认值中的已知变量仍然展开
+			return "${" + tok.name + ":-" + s.expand(tok.defaultValue, stack, firstErr) + "}"
+		}
+		return tok.text
+	}
+
+	for i, name := range stack {
+		if name == tok.name {
+			if *firstErr == nil {
+				chain := append(append([]string{}, stack[i:]...), tok.name)
+				*firstErr = &SubstitutionCycleError{Chain: chain}
+			}
+			return tok.text
+		}
+	}
+
+	stack = append(stack[:len(stack):len(stack)], tok.name)
+	expanded := s.expand(value, stack, firstErr)
+	if expanded == "" && tok.hasDefault {
+		return s.expand(tok.defaultValue, stack[:len(stack)-1], firstErr)
+	}
+	return expanded
+}
+
+// ReferencedVariables 返回 text 中引用的变量名（按出现顺序去重，包含默认值中的引用，不含转义的 $${VAR}）
+func ReferencedVariables(text string) []string {
+	var names []string
+	seen := map[string]bool{}
+	var walk func(text string)
+	walk = func(text string) {
+		for _, tok := range tokenizeVariables(text) {
+			if tok.kind != tokenVariable {
+				continue
+			}
+			if !seen[tok.name] {
+				seen[tok.name] = true
+				names = append(names, tok.name)
+			}
+			if tok.hasDefault {
+				walk(tok.defaultValue)
+			}
+		}
+	}
+	walk(text)
+	return names
+}
+
+type variableTokenKind int
+
+const (
+	tokenLiteral variableTokenKind = iota
+	tokenEscape
+	tokenVariable
+)
+
+// variableToken 词法单元；text 为原始文本
+type variableToken struct {
+	kind variableTokenKind
+	text string
+
+	name         string
+	hasDefault   bool
+	defaultValue string
+	// expandable 为 false 表示 ${#VAR}、${VAR#pattern} 等生成期不处理的 shell 展开，仅记录变量名
+	expandable bool
+}
+
+// tokenizeVariables 将文本切分为字面量、转义和变量引用
+// 不完整的引用（如缺少 "}"、变量名非法）按字面量处理
+func tokenizeVariables(text string) []variableToken {
+	var tokens []variableToken
+	literalStart := 0
+	flush := func(end int) {
+		if end > literalStart {
+			tokens = append(tokens, variableToken{kind: tokenLiteral, text: text[literalStart:end]})
+		}
+	}
+
+	for i := 0; i < len(text); {
+		if text[i] != '$' {
+			i++
+			continue
+		}
+		if strings.HasPrefix(text[i:], "$${") {
+			end := matchingBrace(text, i+2)
+			if end < 0 {
+				i += 3
+				continue
+			}
+			flush(i)
+			tokens = append(tokens, variableToken{kind: tokenEscape, text: text[i : end+1]})
+			i = end + 1
+			literalStart = i
+			continue
+		}
+		if !strings.HasPrefix(text[i:], "${") {
+			i++
+			continue
+		}
+		end := matchingBrace(text, i+1)
+		if end < 0 {
+			break
+		}
+		tok, ok := parseVariable(text[i : end+1])
+		if !ok {
+			i = end + 1
+			continue
+		}
+		flush(i)
+		tokens = append(tokens, tok)
+		i = end + 1
+		literalStart = i
+	}
+	flush(len(text))
+	return tokens
+}
+
+// matchingBrace 返回与 text[open]（"{"）匹配的 "}" 位置，支持嵌套的 ${...}
+func matchingBrace(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parseVariable 解析完整的 ${...} 引用
+func parseVariable(raw string) (variableToken, bool) {
+	body := raw[2 : len(raw)-1]
+	tok := variableToken{kind: tokenVariable, text: raw, expandable: true}
+	if strings.HasPrefix(body, "#") {
+		body = body[1:]
+		tok.expandable = false
+	}
+
+	n := 0
+	for n < len(body) && isVariableNameChar(body[n], n == 0) {
+		n++
+	}
+	if n == 0 {
+		return tok, false
+	}
+	tok.name = body[:n]
+
+	switch rest := body[n:]; {
+	case rest == "":
+	case strings.HasPrefix(rest, ":-") && tok.expandable:
+		tok.hasDefault = true
+		tok.defaultValue = rest[2:]
+	default:
+		tok.expandable = false
+	}
+	return tok, true
+}
+
+func isVariableNameChar(c byte, first bool) bool {
+	return c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (!first && c >= '0' && c <= '9')
+}
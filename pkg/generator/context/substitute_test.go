@@ -0,0 +1,113 @@
+package context
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSubstitutor_Expand(t *testing.T) {
+	sub := NewSubstitutor(map[string]interface{}{
+		"SERVICE_NAME": "demo",
+		"SERVICE_ROOT": "${DEPLOY_DIR}/${SERVICE_NAME}",
+		"DEPLOY_DIR":   "/usr/local/services",
+		"EMPTY":        "",
+		"PORT":         8080,
+		"NAME":         "${SERVICE_NAME}-worker",
+		"SERVICE":      "short",
+	})
+
+	tests := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{"plain", "run ${SERVICE_NAME}", "run demo"},
+		{"non-string value", "--port=${PORT}", "--port=8080"},
+		{"recursive", "cd ${SERVICE_ROOT}", "cd /usr/local/services/demo"},
+		{"recursive with prefix names", "${NAME} ${SERVICE}", "demo-worker short"},
+		{"default for empty value", "${EMPTY:-fallback}", "fallback"},
+		{"default not used", "${SERVICE_NAME:-fallback}", "demo"},
+		{"nested default", "${EMPTY:-${DEPLOY_DIR}/bin}", "/usr/local/services/bin"},
+		{"unknown kept for shell", "echo ${HOME} $HOME", "echo ${HOME} $HOME"},
+		{"unknown with default keeps shell default", "${APP_ENV:-${SERVICE_NAME}}", "${APP_ENV:-demo}"},
+		{"escape", "echo $${SERVICE_NAME} ${SERVICE_NAME}", "echo ${SERVICE_NAME} demo"},
+		{"escape in default", "${EMPTY:-$${SERVICE_NAME}}", "${SERVICE_NAME}"},
+		{"shell expansions untouched", "${#SERVICE_NAME} ${SERVICE_NAME%%-*} ${1} $$", "${#SERVICE_NAME} ${SERVICE_NAME%%-*} ${1} $$"},
+		{"unterminated", "echo ${SERVICE_NAME", "echo ${SERVICE_NAME"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := sub.Expand(tt.text)
+			if err != nil {
+				t.Fatalf("Expand() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("Expand(%q) = %q, want %q", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSubstitutor_Cycle(t *testing.T) {
+	sub := NewStringSubstitutor(map[string]string{
+		"A": "a-${B}",
+		"B": "b-${A}",
+		"C": "${C}",
+	})
+
+	result, err := sub.Expand("${A}")
+	var cycle *SubstitutionCycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("Expected SubstitutionCycleError, got %v", err)
+	}
+	if !reflect.DeepEqual(cycle.Chain, []string{"A", "B", "A"}) {
+		t.Errorf("Chain = %v, want [A B A]", cycle.Chain)
+	}
+	if err.Error() != "variable reference cycle: A -> B -> A" {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if result != "a-b-${A}" {
+		t.Errorf("Expand() = %q, want the cyclic reference kept", result)
+	}
+
+	if got := sub.ExpandLenient("x ${C}"); got != "x ${C}" {
+		t.Errorf("ExpandLenient() = %q", got)
+	}
+}
+
+func TestSubstitutor_Deterministic(t *testing.T) {
+	vars := map[string]interface{}{"A": "${B}", "B": "${C}", "C": "c", "D": "${A}${B}"}
+	for i := 0; i < 50; i++ {
+		if got := NewSubstitutor(vars).ExpandLenient("${D}"); got != "cc" {
+			t.Fatalf("ExpandLenient() = %q, want cc", got)
+		}
+	}
+}
+
+func TestReferencedVariables(t *testing.T) {
+	got := ReferencedVariables("${A} $${B} ${C:-${D}} ${#E} ${A} $F ${1}")
+	want := []string{"A", "C", "D", "E"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReferencedVariables() = %v, want %v", got, want)
+	}
+}
+
+func TestSubstitutor_ExpandKnown(t *testing.T) {
+	sub := NewStringSubstitutor(map[string]string{"CONF_DIR": "/srv/conf", "APP_CONF": "${CONF_DIR}/app.yaml"})
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"--config ${APP_CONF}", "--config /srv/conf/app.yaml"},
+		{"$${CONF_DIR} $${HOME}", "$${CONF_DIR} $${HOME}"},
+		{"${HOME}/x ${NAME:-${CONF_DIR}}", "${HOME}/x ${NAME:-/srv/conf}"},
+		{"$(CURDIR) $$PWD", "$(CURDIR) $$PWD"},
+	}
+	for _, tt := range tests {
+		if got := sub.ExpandKnown(tt.text); got != tt.expected {
+			t.Errorf("ExpandKnown(%q) = %q, want %q", tt.text, got, tt.expected)
+		}
+	}
+}
@@ -57,6 +57,12 @@ func (c *VariableComposer) WithLanguage() *VariableComposer {
 	return c
 }
 
+// WithUser adds user-defined variables (vars:); merged last, so built-in variables take precedence
+func (c *VariableComposer) WithUser() *VariableComposer {
+	c.merge(c.pool.GetSharedVariables(CategoryUser))
+	return c
+}
+
 // WithAll adds all standard variable categories
 func (c *VariableComposer) WithAll() *VariableComposer {
 	return c.
@@ -66,7 +72,8 @@ func (c *VariableComposer) WithAll() *VariableComposer {
 		WithPlugin().
 		WithCIPaths().
 		WithService().
-		WithLanguage()
+		WithLanguage().
+		WithUser()
 }
 
 // WithArchitecture adds architecture-specific variables (extrinsic state)
@@ -194,6 +201,7 @@ func (p *VariablePreset) ForDockerfile(arch string) *VariableComposer {
 		WithCIPaths().
 		WithService().
 		WithLanguage().
+		WithUser().
 		WithArchitecture(arch)
 }
 
@@ -203,7 +211,8 @@ func (p *VariablePreset) ForBuildScript() *VariableComposer {
 		WithCommon().
 		WithBuild().
 		WithPlugin().
-		WithCIPaths()
+		WithCIPaths().
+		WithUser()
 }
 
 // ForCompose returns a preset for docker-compose generation
@@ -212,7 +221,8 @@ func (p *VariablePreset) ForCompose() *VariableComposer {
 		WithCommon().
 		WithRuntime().
 		WithService().
-		WithCIPaths()
+		WithCIPaths().
+		WithUser()
 }
 
 // ForMakefile returns a preset for Makefile generation
@@ -220,7 +230,8 @@ func (p *VariablePreset) ForMakefile() *VariableComposer {
 	return p.composer.Clone().
 		WithCommon().
 		WithService().
-		WithCIPaths()
+		WithCIPaths().
+		WithUser()
 }
 
 // ForDevOps returns a preset for DevOps configuration generation
@@ -228,7 +239,8 @@ func (p *VariablePreset) ForDevOps() *VariableComposer {
 	return p.composer.Clone().
 		WithCommon().
 		WithBuild().
-		WithLanguage()
+		WithLanguage().
+		WithUser()
 }
 
 // ForScript returns a preset for script generation
@@ -238,5 +250,6 @@ func (p *VariablePreset) ForScript() *VariableComposer {
 		WithLanguage().
 		WithRuntime().
 		WithService().
-		WithCIPaths()
+		WithCIPaths().
+		WithUser()
 }
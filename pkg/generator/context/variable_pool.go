@@ -2,6 +2,7 @@ package context
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/junjiewwang/service-template/pkg/config"
@@ -40,7 +41,11 @@ func (p *VariablePool) GetSharedVariables(category string) *SharedVariables {
 	}
 	p.mu.RUnlock()
 
-	// Create new shared variable set
+	// Create new shared variable set outside the lock: some categories are built from others
+	// (user variables are resolved against the built-in ones, commands expand user variables)
+	shared := p.createSharedVariables(category)
+	shared.Freeze() // Freeze to prevent modification
+
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -48,9 +53,6 @@ func (p *VariablePool) GetSharedVariables(category string) *SharedVariables {
 	if cached, exists := p.cache[category]; exists {
 		return cached
 	}
-
-	shared := p.createSharedVariables(category)
-	shared.Freeze() // Freeze to prevent modification
 	p.cache[category] = shared
 	return shared
 }
@@ -77,6 +79,8 @@ func (p *VariablePool) createSharedVariables(category string) *SharedVariables {
 		p.fillServiceVariables(shared)
 	case CategoryLanguage:
 		p.fillLanguageVariables(shared)
+	case CategoryUser:
+		p.fillUserVariables(shared)
 	}
 
 	return shared
@@ -109,9 +113,11 @@ func (p *VariablePool) fillBuildVariables(shared *SharedVariables) {
 	}
 
 	// 填充变量（构建命令支持自动推导）
-	shared.vars[VarBuildCommand] = config.ResolveBuildCommand(cfg)
-	shared.vars[VarPreBuildCommand] = cfg.Build.Commands.PreBuild
-	shared.vars[VarPostBuildCommand] = cfg.Build.Commands.PostBuild
+	shared.vars[VarBuildCommand] = p.ExpandUserVariables(config.ResolveBuildCommand(cfg))
+	shared.vars[VarPreBuildCommand] = p.ExpandUserVariables(cfg.Build.Commands.PreBuild)
+	shared.vars[VarPostBuildCommand] = p.ExpandUserVariables(cfg.Build.Commands.PostBuild)
+	shared.vars[VarTestCommand] = p.ExpandUserVariables(config.ResolveTestCommand(cfg))
+	shared.vars[VarLintCommand] = p.ExpandUserVariables(config.ResolveLintCommand(cfg))
 	shared.vars["BUILD_DEPS_PACKAGES"] = cfg.Build.Dependencies.SystemPkgs
 
 	// 使用解析后的镜像
@@ -124,12 +130,11 @@ func (p *VariablePool) fillBuildVariables(shared *SharedVariables) {
 // fillRuntimeVariables fills runtime-related variables
 func (p *VariablePool) fillRuntimeVariables(shared *SharedVariables) {
 	cfg := p.ctx.Config
-	shared.vars["STARTUP_COMMAND"] = cfg.Runtime.Startup.Command
-	shared.vars["ENV_VARS"] = cfg.Runtime.Startup.Env
+	shared.vars["STARTUP_COMMAND"] = p.ExpandUserVariables(cfg.Runtime.Startup.Command)
+	shared.vars["ENV_VARS"] = p.ExpandUserVariablesInEnv(cfg.Runtime.Startup.Env)
 	shared.vars["RUNTIME_DEPS_PACKAGES"] = cfg.Runtime.SystemDependencies.Packages
 	shared.vars["HEALTHCHECK_ENABLED"] = cfg.Runtime.Healthcheck.Enabled
 	shared.vars["HEALTHCHECK_TYPE"] = cfg.Runtime.Healthcheck.Type
-	shared.vars["GENERATE_SCRIPTS"] = cfg.Runtime.GenerateScripts
 }
 
 // fillPluginVariables fills plugin-related variables
@@ -189,9 +194,122 @@ func (p *VariablePool) fillServiceVariables(shared *SharedVariables) {
 func (p *VariablePool) fillLanguageVariables(shared *SharedVariables) {
 	cfg := p.ctx.Config
 	shared.vars[VarLanguage] = cfg.Language.Type
+	shared.vars[VarLanguageVersion] = cfg.Language.GetVersion()
 	shared.vars["LANGUAGE_CONFIG"] = cfg.Language.Config
 }
 
+// userVariableScope lists the categories user variables can reference
+// (build and runtime variables expand user variables themselves, so they are not in scope)
+var userVariableScope = []string{CategoryCommon, CategoryPlugin, CategoryCIPaths, CategoryService, CategoryLanguage}
+
+// fillUserVariables fills user-defined variables (vars:), resolved against the built-in variables and each other
+func (p *VariablePool) fillUserVariables(shared *SharedVariables) {
+	if len(p.ctx.Config.Vars) == 0 {
+		return
+	}
+
+	substitutor := NewSubstitutor(p.userScope())
+	for name, value := range p.ctx.Config.Vars {
+		if p.inUserScope(name) {
+			// Built-in variables take precedence (reported by ValidateUserVariables)
+			continue
+		}
+		shared.vars[name] = substitutor.ExpandLenient(value)
+	}
+}
+
+// userScope returns the variables user variable values are expanded with: the built-in variables
+// in scope plus the unresolved user variables
+func (p *VariablePool) userScope() map[string]interface{} {
+	scope := make(map[string]interface{})
+	for _, category := range userVariableScope {
+		for k, v := range p.GetSharedVariables(category).ToMap() {
+			scope[k] = v
+		}
+	}
+	for name, value := range p.ctx.Config.Vars {
+		if _, exists := scope[name]; !exists {
+			scope[name] = value
+		}
+	}
+	return scope
+}
+
+// inUserScope reports whether name is a built-in variable user variables can reference
+func (p *VariablePool) inUserScope(name string) bool {
+	for _, category := range userVariableScope {
+		if _, exists := p.GetSharedVariables(category).Get(name); exists {
+			return true
+		}
+	}
+	return false
+}
+
+// isBuiltinVariable reports whether name is a variable the generator defines itself
+// (must not be called while filling the build, runtime or user categories, which depend on each other)
+func (p *VariablePool) isBuiltinVariable(name string) bool {
+	if builtinVariableNames[name] {
+		return true
+	}
+	for _, category := range []string{CategoryCommon, CategoryBuild, CategoryRuntime, CategoryPlugin, CategoryCIPaths, CategoryService, CategoryLanguage} {
+		if _, exists := p.GetSharedVariables(category).Get(name); exists {
+			return true
+		}
+	}
+	return false
+}
+
+// builtinVariableNames are built-in variables that are not in a shared category:
+// architecture variables (VariableComposer.WithArchitecture) and per-plugin variables
+var builtinVariableNames = map[string]bool{
+	VarGOARCH: true, VarGOOS: true, "ARCH": true, "BUILDER_IMAGE": true, "RUNTIME_IMAGE": true,
+	VarPluginName: true, VarPluginDescription: true, VarPluginDownloadURL: true,
+}
+
+// ValidateUserVariables checks user variables for name collisions with built-in variables
+// and for reference cycles (sorted by variable name)
+func (p *VariablePool) ValidateUserVariables() []error {
+	names := make([]string, 0, len(p.ctx.Config.Vars))
+	for name := range p.ctx.Config.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	substitutor := NewSubstitutor(p.userScope())
+	for _, name := range names {
+		if p.isBuiltinVariable(name) {
+			errs = append(errs, fmt.Errorf("vars.%s collides with the built-in variable ${%s}", name, name))
+			continue
+		}
+		if _, err := substitutor.Expand("${" + name + "}"); err != nil {
+			errs = append(errs, fmt.Errorf("vars.%s: %w", name, err))
+		}
+	}
+	return errs
+}
+
+// ExpandUserVariables expands references to user variables in text that is interpreted again later
+// (shell commands, Makefile recipes, compose files): other references and $${VAR} escapes are kept
+func (p *VariablePool) ExpandUserVariables(text string) string {
+	if len(p.ctx.Config.Vars) == 0 || text == "" {
+		return text
+	}
+	return NewSubstitutor(p.GetSharedVariables(CategoryUser).ToMap()).ExpandKnown(text)
+}
+
+// ExpandUserVariablesInEnv returns a copy of env with user variables expanded in the values
+func (p *VariablePool) ExpandUserVariablesInEnv(env []config.EnvConfig) []config.EnvConfig {
+	if len(p.ctx.Config.Vars) == 0 || env == nil {
+		return env
+	}
+	expanded := make([]config.EnvConfig, len(env))
+	for i, e := range env {
+		expanded[i] = config.EnvConfig{Name: e.Name, Value: p.ExpandUserVariables(e.Value)}
+	}
+	return expanded
+}
+
 // Freeze freezes the variable set to prevent modification
 func (s *SharedVariables) Freeze() {
 	s.frozen = true
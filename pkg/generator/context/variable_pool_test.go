@@ -47,7 +47,7 @@ func TestVariablePool_GetSharedVariables(t *testing.T) {
 		{
 			name:     "language variables",
 			category: CategoryLanguage,
-			wantKeys: []string{VarLanguage},
+			wantKeys: []string{VarLanguage, VarLanguageVersion},
 		},
 	}
 
@@ -177,3 +177,79 @@ func createTestConfig() *testutil.ServiceConfig {
 		}).
 		Build()
 }
+
+func TestVariablePool_UserVariables(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Build.Commands.Build = "go build -o ${BIN_DIR}/app $${GOFLAGS}"
+	cfg.Runtime.Startup.Command = "exec ${BIN_DIR}/app --config ${APP_CONF}"
+	cfg.Runtime.Startup.Env = []config.EnvConfig{{Name: "APP_CONFIG", Value: "${APP_CONF}"}}
+	cfg.Vars = map[string]string{
+		"CONF_DIR": "${SERVICE_ROOT}/conf",
+		"APP_CONF": "${CONF_DIR}/app.yaml",
+		"BIN_DIR":  "${SERVICE_BIN_DIR}",
+		"DATA_DIR": "${HOME}/data",
+	}
+	ctx := NewGeneratorContext(cfg, "/tmp/output")
+
+	user := ctx.VariablePool.GetSharedVariables(CategoryUser)
+	assert.Equal(t, map[string]interface{}{
+		"CONF_DIR": "/app/test-service/conf",
+		"APP_CONF": "/app/test-service/conf/app.yaml",
+		"BIN_DIR":  "/app/test-service/bin",
+		"DATA_DIR": "${HOME}/data",
+	}, user.ToMap())
+
+	vars := ctx.GetVariableComposer().WithAll().Build()
+	assert.Equal(t, "/app/test-service/conf/app.yaml", vars["APP_CONF"])
+	assert.Equal(t, "go build -o /app/test-service/bin/app $${GOFLAGS}", vars[VarBuildCommand])
+	assert.Equal(t, "exec /app/test-service/bin/app --config /app/test-service/conf/app.yaml", vars["STARTUP_COMMAND"])
+	assert.Equal(t, []config.EnvConfig{{Name: "APP_CONFIG", Value: "/app/test-service/conf/app.yaml"}}, vars["ENV_VARS"])
+
+	for _, composer := range []*VariableComposer{
+		ctx.GetVariablePreset().ForDockerfile("amd64"),
+		ctx.GetVariablePreset().ForBuildScript(),
+		ctx.GetVariablePreset().ForCompose(),
+		ctx.GetVariablePreset().ForMakefile(),
+		ctx.GetVariablePreset().ForDevOps(),
+		ctx.GetVariablePreset().ForScript(),
+	} {
+		assert.True(t, composer.Has("CONF_DIR"), "every preset includes user variables")
+	}
+
+	assert.Equal(t, "cat ${HOME}/x /app/test-service/conf", ctx.VariablePool.ExpandUserVariables("cat ${HOME}/x ${CONF_DIR}"))
+}
+
+func TestVariablePool_ValidateUserVariables(t *testing.T) {
+	cfg := createTestConfig()
+	cfg.Vars = map[string]string{
+		"CONF_DIR":      "${SERVICE_ROOT}/conf",
+		"SERVICE_ROOT":  "/override",
+		"GOARCH":        "amd64",
+		"BUILD_COMMAND": "make",
+		"LOOP_A":        "${LOOP_B}/a",
+		"LOOP_B":        "${LOOP_A}/b",
+		"SELF":          "${SELF}",
+	}
+	ctx := NewGeneratorContext(cfg, "/tmp/output")
+
+	var messages []string
+	for _, err := range ctx.VariablePool.ValidateUserVariables() {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{
+		"vars.BUILD_COMMAND collides with the built-in variable ${BUILD_COMMAND}",
+		"vars.GOARCH collides with the built-in variable ${GOARCH}",
+		"vars.LOOP_A: variable reference cycle: LOOP_A -> LOOP_B -> LOOP_A",
+		"vars.LOOP_B: variable reference cycle: LOOP_B -> LOOP_A -> LOOP_B",
+		"vars.SELF: variable reference cycle: SELF -> SELF",
+		"vars.SERVICE_ROOT collides with the built-in variable ${SERVICE_ROOT}",
+	}, messages)
+
+	// Built-in variables take precedence over colliding user variables
+	vars := ctx.GetVariableComposer().WithAll().Build()
+	assert.Equal(t, "/app/test-service", vars[VarServiceRoot])
+	assert.Equal(t, "/app/test-service/conf", vars["CONF_DIR"])
+
+	cfg.Vars = map[string]string{"CONF_DIR": "${SERVICE_ROOT}/conf"}
+	assert.Empty(t, NewGeneratorContext(cfg, "/tmp/output").VariablePool.ValidateUserVariables())
+}
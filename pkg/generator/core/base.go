@@ -1,6 +1,8 @@
 package core
 
 import (
+	"fmt"
+
 	"github.com/junjiewwang/service-template/pkg/generator/context"
 	"github.com/junjiewwang/service-template/pkg/generator/domain/events"
 )
@@ -79,12 +81,46 @@ func (g *BaseGenerator) Validate() error {
 
 // RenderTemplate renders a template with variables
 func (g *BaseGenerator) RenderTemplate(template string, vars map[string]interface{}) (string, error) {
-	return g.engine.Render(template, vars)
+	return g.renderEngine().Render(template, vars)
 }
 
 // RenderTemplateWithName renders a named template with variables
 func (g *BaseGenerator) RenderTemplateWithName(name, template string, vars map[string]interface{}) (string, error) {
-	return g.engine.RenderWithName(name, template, vars)
+	return g.renderEngine().RenderWithName(name, template, vars)
+}
+
+// RenderTemplateFile renders a registered embedded template, applying the user override
+// from templates.override_dir when present
+func (g *BaseGenerator) RenderTemplateFile(path string, vars map[string]interface{}) (string, error) {
+	content, ok := LookupTemplate(path)
+	if !ok {
+		return "", fmt.Errorf("template %s is not registered", path)
+	}
+	return g.RenderOverridable(path, content, vars)
+}
+
+// RenderOverridable renders template content that users can override at the given template path
+func (g *BaseGenerator) RenderOverridable(path, template string, vars map[string]interface{}) (string, error) {
+	var dir string
+	if g.ctx != nil && g.ctx.Config != nil {
+		dir = g.ctx.Config.Templates.OverrideDir
+	}
+	override, err := LoadTemplateOverride(dir, path)
+	if err != nil {
+		return "", err
+	}
+	if g.ctx != nil && g.ctx.RecordVariables != nil {
+		g.ctx.RecordVariables(path, vars)
+	}
+	return g.renderEngine().RenderWithOverride(path, template, override, vars)
+}
+
+// renderEngine returns the engine used for rendering, switched to strict mode when the context requests it
+func (g *BaseGenerator) renderEngine() *TemplateEngine {
+	if g.ctx != nil && g.ctx.StrictTemplates && !g.engine.IsStrict() {
+		return g.engine.Strict()
+	}
+	return g.engine
 }
 
 // VariablePreparator is an interface for generators that need custom variable preparation
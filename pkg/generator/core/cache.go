@@ -0,0 +1,59 @@
+package core
+
+import (
+	"crypto/sha256"
+	"sync"
+	"text/template"
+)
+
+// ============================================
+// 模板解析缓存
+// 解析后的模板可以并发执行，按模板名、内容摘要、覆盖内容摘要和严格模式缓存，
+// 同一进程内（如 CI 批量生成多个服务）每个模板只解析一次
+// ============================================
+
+// templateCacheKey identifies a parsed template
+type templateCacheKey struct {
+	name     string
+	content  [sha256.Size]byte
+	override [sha256.Size]byte
+	strict   bool
+}
+
+func newTemplateCacheKey(name, content, override string, strict bool) templateCacheKey {
+	return templateCacheKey{
+		name:     name,
+		content:  sha256.Sum256([]byte(content)),
+		override: sha256.Sum256([]byte(override)),
+		strict:   strict,
+	}
+}
+
+// templateCache stores parsed templates; safe for concurrent use
+type templateCache struct {
+	templates sync.Map // templateCacheKey -> *template.Template
+}
+
+var parsedTemplates = &templateCache{}
+
+func (c *templateCache) load(key templateCacheKey) (*template.Template, bool) {
+	tmpl, ok := c.templates.Load(key)
+	if !ok {
+		return nil, false
+	}
+	return tmpl.(*template.Template), true
+}
+
+// store caches the template and returns the cached one (another goroutine may have stored it first)
+func (c *templateCache) store(key templateCacheKey, tmpl *template.Template) *template.Template {
+	actual, _ := c.templates.LoadOrStore(key, tmpl)
+	return actual.(*template.Template)
+}
+
+// ResetTemplateCache drops all parsed templates (used by benchmarks to measure cold rendering)
+func ResetTemplateCache() {
+	parsedTemplates.templates.Range(func(key, _ interface{}) bool {
+		parsedTemplates.templates.Delete(key)
+		return true
+	})
+}
@@ -4,42 +4,62 @@ import (
 	"bytes"
 	"fmt"
 	"strings"
+	"sync"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/junjiewwang/service-template/pkg/generator/context"
 )
 
 // TemplateEngine handles template rendering
 type TemplateEngine struct {
 	funcMap template.FuncMap
+	// strict 为 true 时引用不存在的变量会报错（missingkey=error），而不是渲染为 "<no value>"
+	strict bool
 }
 
+// defaultFuncMap builds sprig and the custom functions once; the map is shared read-only by all engines
+var defaultFuncMap = sync.OnceValue(func() template.FuncMap {
+	funcMap := sprig.TxtFuncMap()
+	addCustomFunctions(funcMap)
+	return funcMap
+})
+
 // NewTemplateEngine creates a new template engine
 func NewTemplateEngine() *TemplateEngine {
-	engine := &TemplateEngine{
-		funcMap: sprig.TxtFuncMap(),
+	return &TemplateEngine{
+		funcMap: defaultFuncMap(),
 	}
+}
+
+// Strict returns a copy of the engine that fails on references to missing variables
+func (e *TemplateEngine) Strict() *TemplateEngine {
+	strict := *e
+	strict.strict = true
+	return &strict
+}
 
-	// Add custom functions
-	engine.addCustomFunctions()
+// IsStrict reports whether the engine fails on references to missing variables
+func (e *TemplateEngine) IsStrict() bool {
+	return e.strict
+}
 
-	return engine
+// newTemplate creates a template with the engine's functions and options
+func (e *TemplateEngine) newTemplate(name string) *template.Template {
+	tmpl := template.New(name).Funcs(e.funcMap)
+	if e.strict {
+		tmpl = tmpl.Option("missingkey=error")
+	}
+	return tmpl
 }
 
 // addCustomFunctions adds custom template functions
-func (e *TemplateEngine) addCustomFunctions() {
+func addCustomFunctions(funcMap template.FuncMap) {
 	// Add variable substitution function
-	e.funcMap["substitute"] = func(text string, vars map[string]interface{}) string {
-		result := text
-		for key, value := range vars {
-			placeholder := fmt.Sprintf("${%s}", key)
-			result = strings.ReplaceAll(result, placeholder, fmt.Sprint(value))
-		}
-		return result
-	}
+	funcMap["substitute"] = SubstituteVariables
 
 	// Add join function for ports
-	e.funcMap["joinPorts"] = func(ports []interface{}, sep string) string {
+	funcMap["joinPorts"] = func(ports []interface{}, sep string) string {
 		var result []string
 		for _, p := range ports {
 			result = append(result, fmt.Sprint(p))
@@ -47,8 +67,15 @@ func (e *TemplateEngine) addCustomFunctions() {
 		return strings.Join(result, sep)
 	}
 
+	// Add escaping functions for shell, YAML, Makefile and Dockerfile output
+	funcMap["shellQuote"] = ShellQuote
+	funcMap["shellDoubleQuote"] = ShellDoubleQuote
+	funcMap["yamlQuote"] = YAMLQuote
+	funcMap["makeEscape"] = MakeEscape
+	funcMap["dockerJSONArray"] = DockerJSONArray
+
 	// Add indent function
-	e.funcMap["indentLines"] = func(spaces int, text string) string {
+	funcMap["indentLines"] = func(spaces int, text string) string {
 		indent := strings.Repeat(" ", spaces)
 		lines := strings.Split(text, "\n")
 		for i, line := range lines {
@@ -62,50 +89,71 @@ func (e *TemplateEngine) addCustomFunctions() {
 
 // Render renders a template with the given variables
 func (e *TemplateEngine) Render(templateContent string, vars map[string]interface{}) (string, error) {
-	tmpl, err := template.New("template").Funcs(e.funcMap).Parse(templateContent)
+	return e.RenderWithName("template", templateContent, vars)
+}
+
+// RenderWithName renders a named template
+func (e *TemplateEngine) RenderWithName(name, templateContent string, vars map[string]interface{}) (string, error) {
+	return e.RenderWithOverride(name, templateContent, "", vars)
+}
+
+// RenderWithOverride renders a named template with a user override parsed on top of it:
+// an override with a body replaces the whole template, {{ define }} blocks replace blocks of the same name
+func (e *TemplateEngine) RenderWithOverride(name, templateContent, override string, vars map[string]interface{}) (string, error) {
+	tmpl, err := e.parse(name, templateContent, override)
 	if err != nil {
-		return "", fmt.Errorf("failed to parse template: %w", err)
+		return "", err
 	}
 
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, vars); err != nil {
-		return "", fmt.Errorf("failed to execute template: %w", err)
+		return "", fmt.Errorf("failed to execute template %s: %w", name, err)
 	}
 
 	return buf.String(), nil
 }
 
-// RenderWithName renders a named template
-func (e *TemplateEngine) RenderWithName(name, templateContent string, vars map[string]interface{}) (string, error) {
-	tmpl, err := template.New(name).Funcs(e.funcMap).Parse(templateContent)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse template %s: %w", name, err)
+// parse returns the parsed template from the cache, parsing it on first use
+func (e *TemplateEngine) parse(name, templateContent, override string) (*template.Template, error) {
+	key := newTemplateCacheKey(name, templateContent, override, e.strict)
+	if tmpl, ok := parsedTemplates.load(key); ok {
+		return tmpl, nil
 	}
 
-	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, vars); err != nil {
-		return "", fmt.Errorf("failed to execute template %s: %w", name, err)
+	tmpl, err := e.parseWithOverride(name, templateContent, override)
+	if err != nil {
+		return nil, err
 	}
+	return parsedTemplates.store(key, tmpl), nil
+}
 
-	return buf.String(), nil
+// parseWithOverride parses the shared partials, the embedded template, then the override into the same
+// template set (text/template keeps the existing body when a later parse only contains definitions)
+func (e *TemplateEngine) parseWithOverride(name, templateContent, override string) (*template.Template, error) {
+	tmpl := e.newTemplate(name)
+	if err := parsePartials(tmpl); err != nil {
+		return nil, err
+	}
+	tmpl, err := tmpl.Parse(templateContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+	}
+	if override == "" {
+		return tmpl, nil
+	}
+	if _, err := tmpl.Parse(override); err != nil {
+		return nil, fmt.Errorf("failed to parse template override %s: %w", name, err)
+	}
+	return tmpl, nil
 }
 
-// SubstituteVariables performs simple variable substitution in text
+// SubstituteVariables performs variable substitution in text (${VAR}, ${VAR:-default}, $${VAR} escaping)
+// Unknown variables are kept for the runtime shell; references in a cycle are kept unexpanded
 func SubstituteVariables(text string, vars map[string]interface{}) string {
-	result := text
-	for key, value := range vars {
-		placeholder := fmt.Sprintf("${%s}", key)
-		result = strings.ReplaceAll(result, placeholder, fmt.Sprint(value))
-	}
-	return result
+	return context.NewSubstitutor(vars).ExpandLenient(text)
 }
 
-// ReplaceVariables replaces variables in text with string values
+// ReplaceVariables replaces variables in text with string values (same syntax as SubstituteVariables)
 func (e *TemplateEngine) ReplaceVariables(text string, vars map[string]string) string {
-	result := text
-	for key, value := range vars {
-		placeholder := fmt.Sprintf("${%s}", key)
-		result = strings.ReplaceAll(result, placeholder, value)
-	}
-	return result
+	return context.NewStringSubstitutor(vars).ExpandLenient(text)
 }
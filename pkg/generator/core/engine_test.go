@@ -0,0 +1,96 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTemplateEngine_Strict(t *testing.T) {
+	vars := map[string]interface{}{"SERVICE_NAME": "demo"}
+	content := "FROM {{ .SERVICE_NAM }}\n"
+
+	engine := NewTemplateEngine()
+	result, err := engine.Render(content, vars)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if !strings.Contains(result, "<no value>") {
+		t.Errorf("Expected default mode to render <no value>, got %q", result)
+	}
+
+	strict := engine.Strict()
+	if engine.IsStrict() || !strict.IsStrict() {
+		t.Errorf("Strict() should return a strict copy and leave the engine unchanged")
+	}
+	if _, err := strict.Render(content, vars); err == nil || !strings.Contains(err.Error(), `map has no entry for key "SERVICE_NAM"`) {
+		t.Errorf("Expected missing key error, got %v", err)
+	}
+	if _, err := strict.RenderWithOverride("test", "{{ block \"b\" . }}{{ .SERVICE_NAME }}{{ end }}", "{{ define \"b\" }}{{ .TYPO }}{{ end }}", vars); err == nil || !strings.Contains(err.Error(), "TYPO") {
+		t.Errorf("Expected missing key error from override block, got %v", err)
+	}
+
+	result, err = strict.Render("{{ .SERVICE_NAME }}", vars)
+	if err != nil || result != "demo" {
+		t.Errorf("Strict Render() = %q, %v; want demo", result, err)
+	}
+}
+
+func TestTemplateEngine_ParseCache(t *testing.T) {
+	ResetTemplateCache()
+	engine := NewTemplateEngine()
+
+	first, err := engine.parse("cache-test", "{{ .A }}", "")
+	if err != nil {
+		t.Fatalf("parse() error = %v", err)
+	}
+	if again, _ := NewTemplateEngine().parse("cache-test", "{{ .A }}", ""); again != first {
+		t.Errorf("Expected the cached template to be reused across engines")
+	}
+	if changed, _ := engine.parse("cache-test", "{{ .B }}", ""); changed == first {
+		t.Errorf("Expected changed content to be parsed again")
+	}
+	if overridden, _ := engine.parse("cache-test", "{{ .A }}", "x"); overridden == first {
+		t.Errorf("Expected an override to be parsed separately")
+	}
+	if strict, _ := engine.Strict().parse("cache-test", "{{ .A }}", ""); strict == first {
+		t.Errorf("Expected strict templates to be cached separately")
+	}
+	if _, err := engine.parse("cache-test", "{{ if }}", ""); err == nil {
+		t.Errorf("Expected parse error")
+	}
+}
+
+// benchmarkTemplate is a template of realistic size using sprig functions
+var benchmarkTemplate = strings.Repeat(`{{- if .ENABLED }}
+RUN echo {{ .SERVICE_NAME | upper }} && mkdir -p {{ .DEPLOY_DIR }}/{{ .SERVICE_NAME }}
+{{- range .PORTS }}
+EXPOSE {{ . }}
+{{- end }}
+{{- end }}
+ENV NAME={{ default "demo" .NAME | quote }}
+`, 40)
+
+func BenchmarkTemplateEngine_Render(b *testing.B) {
+	vars := map[string]interface{}{
+		"ENABLED":      true,
+		"SERVICE_NAME": "demo",
+		"DEPLOY_DIR":   "/usr/local/services",
+		"PORTS":        []int{8080, 9090},
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			ResetTemplateCache()
+			if _, err := NewTemplateEngine().RenderWithName("bench", benchmarkTemplate, vars); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := NewTemplateEngine().RenderWithName("bench", benchmarkTemplate, vars); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
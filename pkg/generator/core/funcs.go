@@ -0,0 +1,94 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ============================================
+// 模板转义函数
+// 生成的文件会被 shell、YAML 解析器、make、Docker 再次解释，模板中的用户值应通过这些函数输出，
+// 而不是手写引号
+// ============================================
+
+// isShellSafe reports whether s needs no quoting in a POSIX shell word
+func isShellSafe(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case strings.ContainsRune("_@%+=:,./-", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// ShellQuote quotes s as a single POSIX shell word with no expansion (single quotes);
+// words that need no quoting are returned as is
+func ShellQuote(s string) string {
+	if isShellSafe(s) {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// ShellDoubleQuote quotes s as a single POSIX shell word that keeps $VAR, ${VAR} and $(cmd)
+// expansion (double quotes; ", \ and ` are escaped); words that need no quoting are returned as is
+func ShellDoubleQuote(s string) string {
+	if isShellSafe(s) {
+		return s
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, c := range s {
+		if c == '"' || c == '\\' || c == '`' {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// YAMLQuote quotes s as a YAML double-quoted scalar (a JSON string is a valid YAML scalar)
+func YAMLQuote(s string) string {
+	data, err := marshalJSON(s)
+	if err != nil {
+		// 字符串总能编码为 JSON
+		panic(err)
+	}
+	return string(data)
+}
+
+// MakeEscape escapes $ for Makefile variable values and recipes, so make passes it through literally
+func MakeEscape(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
+
+// DockerJSONArray encodes args as a Dockerfile exec-form JSON array (also valid as a YAML flow sequence)
+func DockerJSONArray(args []string) (string, error) {
+	if args == nil {
+		args = []string{}
+	}
+	data, err := marshalJSON(args)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// marshalJSON encodes v without HTML escaping (<, > and & are kept readable)
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+var escapeInputs = []string{
+	"plain",
+	"",
+	"two words",
+	"it's",
+	`say "hi" \ back`,
+	"${HOME}/$(id -u) `date`",
+	"line\nbreak",
+	"tab\tand *glob* ?",
+	"a&b<c>",
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain":       "plain",
+		"/opt/app-1":  "/opt/app-1",
+		"":            "''",
+		"two words":   "'two words'",
+		"it's":        `'it'\''s'`,
+		"${HOME}":     "'${HOME}'",
+		"KEY=a,b:c@d": "KEY=a,b:c@d",
+	}
+	for input, expected := range tests {
+		if got := ShellQuote(input); got != expected {
+			t.Errorf("ShellQuote(%q) = %s, want %s", input, got, expected)
+		}
+	}
+}
+
+func TestShellDoubleQuote(t *testing.T) {
+	tests := map[string]string{
+		"plain":                   "plain",
+		"":                        `""`,
+		"two words":               `"two words"`,
+		"${PLUGIN_INSTALL_DIR}/x": `"${PLUGIN_INSTALL_DIR}/x"`,
+		`say "hi" \ back`:         `"say \"hi\" \\ back"`,
+		"`date`":                  "\"\\`date\\`\"",
+	}
+	for input, expected := range tests {
+		if got := ShellDoubleQuote(input); got != expected {
+			t.Errorf("ShellDoubleQuote(%q) = %s, want %s", input, got, expected)
+		}
+	}
+}
+
+// TestShellQuote_RoundTrip checks the quoted words with a real shell
+func TestShellQuote_RoundTrip(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	for _, input := range escapeInputs {
+		out, err := exec.Command("sh", "-c", "printf '%s' "+ShellQuote(input)).Output()
+		if err != nil {
+			t.Fatalf("sh failed for %q: %v", input, err)
+		}
+		if string(out) != input {
+			t.Errorf("ShellQuote(%q) round trip = %q", input, out)
+		}
+
+		if strings.Contains(input, "$") || strings.Contains(input, "`") {
+			continue
+		}
+		out, err = exec.Command("sh", "-c", "printf '%s' "+ShellDoubleQuote(input)).Output()
+		if err != nil {
+			t.Fatalf("sh failed for %q: %v", input, err)
+		}
+		if string(out) != input {
+			t.Errorf("ShellDoubleQuote(%q) round trip = %q", input, out)
+		}
+	}
+}
+
+func TestYAMLQuote(t *testing.T) {
+	tests := map[string]string{
+		"GO_ENV=production": `"GO_ENV=production"`,
+		"":                  `""`,
+		"a: b # c":          `"a: b # c"`,
+		`say "hi"`:          `"say \"hi\""`,
+		"line\nbreak":       `"line\nbreak"`,
+		"a&b<c>":            `"a&b<c>"`,
+		"yes":               `"yes"`,
+	}
+	for input, expected := range tests {
+		if got := YAMLQuote(input); got != expected {
+			t.Errorf("YAMLQuote(%q) = %s, want %s", input, got, expected)
+		}
+	}
+}
+
+func TestMakeEscape(t *testing.T) {
+	if got := MakeEscape("echo ${HOME} $(id) $$"); got != "echo $${HOME} $$(id) $$$$" {
+		t.Errorf("MakeEscape() = %s", got)
+	}
+}
+
+func TestDockerJSONArray(t *testing.T) {
+	got, err := DockerJSONArray([]string{"/bin/app", "--name", `say "hi"`, "a&b"})
+	if err != nil {
+		t.Fatalf("DockerJSONArray() error = %v", err)
+	}
+	if expected := `["/bin/app","--name","say \"hi\"","a&b"]`; got != expected {
+		t.Errorf("DockerJSONArray() = %s, want %s", got, expected)
+	}
+	if got, _ := DockerJSONArray(nil); got != "[]" {
+		t.Errorf("DockerJSONArray(nil) = %s, want []", got)
+	}
+}
+
+func TestTemplateEngine_EscapeFunctions(t *testing.T) {
+	result, err := NewTemplateEngine().Render(
+		`export A={{ shellDoubleQuote .V }}; B={{ shellQuote .V }}`+"\n"+
+			`env: [{{ yamlQuote .V }}]`+"\n"+
+			`X ?= {{ makeEscape .V }}`+"\n"+
+			`CMD {{ dockerJSONArray .ARGS }}`,
+		map[string]interface{}{"V": "a $b", "ARGS": []string{"run", "a $b"}})
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	expected := `export A="a $b"; B='a $b'` + "\n" +
+		`env: ["a $b"]` + "\n" +
+		`X ?= a $$b` + "\n" +
+		`CMD ["run","a $b"]`
+	if result != expected {
+		t.Errorf("Render() = %s\nwant %s", result, expected)
+	}
+}
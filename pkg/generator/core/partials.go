@@ -0,0 +1,88 @@
+package core
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+	"text/template"
+)
+
+// ============================================
+// 共享模板片段（partials）
+// partials/ 下每个文件用 {{ define "name" }} 定义片段，解析到每个模板中，
+// 任何模板（包括用户覆盖）都可以用 {{ template "name" . }} 引用；
+// 覆盖文件中同名的 {{ define }} 只替换该模板中的片段
+// ============================================
+
+//go:embed partials/*.tmpl
+var partialFiles embed.FS
+
+var (
+	partials   = map[string]string{}
+	partialsMu sync.RWMutex
+)
+
+func init() {
+	entries, err := fs.ReadDir(partialFiles, "partials")
+	if err != nil {
+		panic(err)
+	}
+	for _, entry := range entries {
+		content, err := fs.ReadFile(partialFiles, path.Join("partials", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		RegisterPartial(path.Join("partials", entry.Name()), string(content))
+	}
+}
+
+// RegisterPartial 注册共享模板片段文件（包含一个或多个 {{ define }}），需在渲染前（init 中）调用
+func RegisterPartial(path, content string) {
+	partialsMu.Lock()
+	defer partialsMu.Unlock()
+
+	if _, exists := partials[path]; exists {
+		panic(fmt.Sprintf("partial %s is already registered", path))
+	}
+	partials[path] = content
+}
+
+// parsePartials parses all registered partials into the template set (sorted by path)
+func parsePartials(tmpl *template.Template) error {
+	partialsMu.RLock()
+	defer partialsMu.RUnlock()
+
+	paths := make([]string, 0, len(partials))
+	for path := range partials {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if _, err := tmpl.New(path).Parse(partials[path]); err != nil {
+			return fmt.Errorf("failed to parse partial %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// PartialNames 返回共享片段的名称（已排序），即可以 {{ template "name" . }} 引用的名称
+func PartialNames() []string {
+	tmpl := NewTemplateEngine().newTemplate("partials")
+	if err := parsePartials(tmpl); err != nil {
+		panic(err)
+	}
+	partialsMu.RLock()
+	defer partialsMu.RUnlock()
+
+	var names []string
+	for _, t := range tmpl.Templates() {
+		if _, isFile := partials[t.Name()]; !isFile && t.Name() != "partials" {
+			names = append(names, t.Name())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPartialNames(t *testing.T) {
+	names := PartialNames()
+	found := false
+	for _, name := range names {
+		if name == "pkg_install" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("PartialNames() = %v, want pkg_install", names)
+	}
+}
+
+func TestRenderWithPartials(t *testing.T) {
+	engine := NewTemplateEngine().Strict()
+
+	result, err := engine.RenderWithName("test/partials.tmpl", "{{ template \"pkg_install\" . }}\ninstall_packages curl\n", nil)
+	if err != nil {
+		t.Fatalf("RenderWithName() error = %v", err)
+	}
+	if !strings.HasPrefix(result, "# install_packages") || !strings.Contains(result, `apk add --no-cache "$@"`) {
+		t.Errorf("Expected pkg_install partial in output, got:\n%s", result)
+	}
+
+	// An override can replace a partial for one template
+	result, err = engine.RenderWithOverride("test/partials.tmpl", "{{ template \"pkg_install\" . }}", "{{ define \"pkg_install\" }}custom{{ end }}", nil)
+	if err != nil || result != "custom" {
+		t.Errorf("RenderWithOverride() = %q, %v; want custom", result, err)
+	}
+
+	// Partials are not listed as blocks of each template
+	blocks, err := TemplateBlocks(testTemplatePath)
+	if err != nil || len(blocks) != 1 {
+		t.Errorf("TemplateBlocks() = %v, %v; want [middle]", blocks, err)
+	}
+}
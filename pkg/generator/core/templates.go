@@ -0,0 +1,161 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ============================================
+// 内置模板注册与用户覆盖
+// 每个生成器以相对路径（如 docker/dockerfile/dockerfile_.tmpl）注册内置模板，
+// templates.override_dir 下同路径的文件在渲染时叠加到内置模板之上
+// ============================================
+
+var (
+	embeddedTemplates   = map[string]string{}
+	embeddedTemplatesMu sync.RWMutex
+)
+
+// RegisterTemplate 注册内置模板，返回模板路径（供生成器在包级变量中保存）
+func RegisterTemplate(path, content string) string {
+	embeddedTemplatesMu.Lock()
+	defer embeddedTemplatesMu.Unlock()
+
+	if _, exists := embeddedTemplates[path]; exists {
+		panic(fmt.Sprintf("template %s is already registered", path))
+	}
+	embeddedTemplates[path] = content
+	return path
+}
+
+// LookupTemplate 返回内置模板内容
+func LookupTemplate(path string) (string, bool) {
+	embeddedTemplatesMu.RLock()
+	defer embeddedTemplatesMu.RUnlock()
+
+	content, ok := embeddedTemplates[path]
+	return content, ok
+}
+
+// TemplatePaths 返回所有内置模板路径（已排序）
+func TemplatePaths() []string {
+	embeddedTemplatesMu.RLock()
+	defer embeddedTemplatesMu.RUnlock()
+
+	paths := make([]string, 0, len(embeddedTemplates))
+	for path := range embeddedTemplates {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// TemplateBlocks 返回内置模板中可单独覆盖的 {{ define }} / {{ block }} 块名（已排序）
+func TemplateBlocks(path string) ([]string, error) {
+	content, ok := LookupTemplate(path)
+	if !ok {
+		return nil, fmt.Errorf("unknown template %s", path)
+	}
+	// 只解析模板本身，共享片段见 PartialNames
+	tmpl, err := NewTemplateEngine().newTemplate(path).Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template %s: %w", path, err)
+	}
+	var blocks []string
+	for _, t := range tmpl.Templates() {
+		if t.Name() != path {
+			blocks = append(blocks, t.Name())
+		}
+	}
+	sort.Strings(blocks)
+	return blocks, nil
+}
+
+// TemplateVersion 内置模板版本（内容摘要），用于检查覆盖文件是否基于旧版本导出
+func TemplateVersion(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// templateHeader 导出文件首行，记录来源模板与版本（渲染时不产生输出）
+var templateHeader = regexp.MustCompile(`^\{\{/\* svcgen:template (\S+) version=([0-9a-f]+) \*/ -\}\}\n`)
+
+// ExportTemplate 返回带版本头的内置模板内容，作为覆盖文件的起点
+func ExportTemplate(path string) (string, error) {
+	content, ok := LookupTemplate(path)
+	if !ok {
+		return "", fmt.Errorf("unknown template %s", path)
+	}
+	return fmt.Sprintf("{{/* svcgen:template %s version=%s */ -}}\n%s", path, TemplateVersion(content), content), nil
+}
+
+// LoadTemplateOverride 读取 dir 下与 path 对应的覆盖文件，不存在时返回空字符串
+func LoadTemplateOverride(dir, path string) (string, error) {
+	if dir == "" {
+		return "", nil
+	}
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(path)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to read template override %s: %w", path, err)
+	}
+	return string(data), nil
+}
+
+// CheckTemplateOverrides 检查覆盖目录：
+// 不对应任何内置模板的文件、基于旧版本内置模板导出的文件、无法解析的文件都会产生警告
+func CheckTemplateOverrides(dir string) ([]string, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	var warnings []string
+	engine := NewTemplateEngine()
+	err := filepath.WalkDir(dir, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(file, ".tmpl") {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		path := filepath.ToSlash(rel)
+
+		content, ok := LookupTemplate(path)
+		if !ok {
+			warnings = append(warnings, fmt.Sprintf("template override %s does not match any embedded template (see 'svcgen templates list')", path))
+			return nil
+		}
+		override, err := LoadTemplateOverride(dir, path)
+		if err != nil {
+			return err
+		}
+		if _, err := engine.parseWithOverride(path, content, override); err != nil {
+			warnings = append(warnings, err.Error())
+			return nil
+		}
+		if m := templateHeader.FindStringSubmatch(override); m != nil && m[2] != TemplateVersion(content) {
+			warnings = append(warnings, fmt.Sprintf(
+				"template override %s is based on an older embedded template (version %s, current %s); export the current one with 'svcgen templates export --dir <tmp> %s' and merge your changes",
+				path, m[2], TemplateVersion(content), path))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check template overrides: %w", err)
+	}
+	return warnings, nil
+}
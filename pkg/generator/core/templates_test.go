@@ -0,0 +1,124 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testTemplatePath = RegisterTemplate("test/sample/sample.tmpl", "head\n{{- block \"middle\" . }}\nmiddle {{ .NAME }}\n{{- end }}\ntail\n")
+
+func writeOverride(t *testing.T, dir, path, content string) {
+	t.Helper()
+	file := filepath.Join(dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenderWithOverride(t *testing.T) {
+	engine := NewTemplateEngine()
+	content, _ := LookupTemplate(testTemplatePath)
+	vars := map[string]interface{}{"NAME": "demo"}
+
+	tests := []struct {
+		name     string
+		override string
+		expected string
+	}{
+		{"no override", "", "head\nmiddle demo\ntail\n"},
+		{"block override", "{{ define \"middle\" }}\nreplaced {{ .NAME }}{{ end }}\n", "head\nreplaced demo\ntail\n"},
+		{"full override", "only {{ .NAME }}\n", "only demo\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := engine.RenderWithOverride(testTemplatePath, content, tt.override, vars)
+			if err != nil {
+				t.Fatalf("RenderWithOverride() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("RenderWithOverride() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := engine.RenderWithOverride(testTemplatePath, content, "{{ if }}", vars); err == nil || !strings.Contains(err.Error(), "failed to parse template override") {
+		t.Errorf("Expected override parse error, got %v", err)
+	}
+}
+
+func TestExportTemplate(t *testing.T) {
+	exported, err := ExportTemplate(testTemplatePath)
+	if err != nil {
+		t.Fatalf("ExportTemplate() error = %v", err)
+	}
+	content, _ := LookupTemplate(testTemplatePath)
+	if !strings.HasPrefix(exported, "{{/* svcgen:template test/sample/sample.tmpl version="+TemplateVersion(content)+" */ -}}\n") {
+		t.Errorf("Missing version header:\n%s", exported)
+	}
+
+	// 导出的文件作为覆盖时输出与内置模板一致
+	engine := NewTemplateEngine()
+	vars := map[string]interface{}{"NAME": "demo"}
+	expected, _ := engine.RenderWithOverride(testTemplatePath, content, "", vars)
+	actual, err := engine.RenderWithOverride(testTemplatePath, content, exported, vars)
+	if err != nil || actual != expected {
+		t.Errorf("Exported template renders %q (err %v), want %q", actual, err, expected)
+	}
+
+	if _, err := ExportTemplate("missing.tmpl"); err == nil {
+		t.Error("Expected error for unknown template")
+	}
+}
+
+func TestTemplateBlocks(t *testing.T) {
+	blocks, err := TemplateBlocks(testTemplatePath)
+	if err != nil {
+		t.Fatalf("TemplateBlocks() error = %v", err)
+	}
+	if len(blocks) != 1 || blocks[0] != "middle" {
+		t.Errorf("TemplateBlocks() = %v, want [middle]", blocks)
+	}
+}
+
+func TestCheckTemplateOverrides(t *testing.T) {
+	dir := t.TempDir()
+	exported, _ := ExportTemplate(testTemplatePath)
+	writeOverride(t, dir, testTemplatePath, exported)
+
+	warnings, err := CheckTemplateOverrides(dir)
+	if err != nil {
+		t.Fatalf("CheckTemplateOverrides() error = %v", err)
+	}
+	if len(warnings) != 0 {
+		t.Errorf("Expected no warnings for a current export, got %v", warnings)
+	}
+
+	outdated := strings.Replace(exported, "version=", "version=0", 1)
+	writeOverride(t, dir, testTemplatePath, outdated)
+	writeOverride(t, dir, "test/unknown.tmpl", "x")
+	writeOverride(t, dir, "test/notes.md", "ignored")
+
+	warnings, err = CheckTemplateOverrides(dir)
+	if err != nil {
+		t.Fatalf("CheckTemplateOverrides() error = %v", err)
+	}
+	joined := strings.Join(warnings, "\n")
+	if len(warnings) != 2 {
+		t.Errorf("Expected 2 warnings, got %v", warnings)
+	}
+	if !strings.Contains(joined, "test/sample/sample.tmpl is based on an older embedded template") {
+		t.Errorf("Missing outdated warning: %s", joined)
+	}
+	if !strings.Contains(joined, "test/unknown.tmpl does not match any embedded template") {
+		t.Errorf("Missing unknown template warning: %s", joined)
+	}
+
+	if warnings, err := CheckTemplateOverrides(""); err != nil || warnings != nil {
+		t.Errorf("Expected no-op for empty dir, got %v, %v", warnings, err)
+	}
+}
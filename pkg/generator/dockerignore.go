@@ -0,0 +1,94 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/generator/domain/services/languageservice"
+	"github.com/junjiewwang/service-template/pkg/generator/filewriter/strategies"
+)
+
+const (
+	dockerignoreStartMarker = "# >>> svcgen generated - DO NOT EDIT >>>"
+	dockerignoreEndMarker   = "# <<< svcgen generated <<<"
+)
+
+// dockerignoreCommonPatterns 与语言无关的构建上下文排除项
+var dockerignoreCommonPatterns = []string{
+	".git/",
+	".idea/",
+	".vscode/",
+	"**/.DS_Store",
+}
+
+// dockerignoreSection 一组带注释标题的 .dockerignore 条目
+type dockerignoreSection struct {
+	title    string
+	patterns []string
+}
+
+// dockerignoreSections 收集 .dockerignore 的各组条目
+// 顺序很重要：.dockerignore 中后出现的规则优先，因此 "!" 重新包含的条目放在最后
+func (g *Generator) dockerignoreSections() []dockerignoreSection {
+	cfg := g.config
+	langService := languageservice.NewLanguageService(g.ctx)
+	scriptDir := strings.TrimRight(filepath.ToSlash(g.ctx.Paths.CI.ScriptDir), "/")
+
+	// 1. svcgen 自身的产物中 Dockerfile 永远用不到的部分（频繁变化会破坏缓存）
+	// Makefile / compose.yaml / .env.make 保留在上下文中：构建命令可能直接执行 make
+	outputs := []string{
+		".tad/",
+		"k8s-manifests/",
+		".dockerignore",
+	}
+
+	// 2. Dockerfile 需要 COPY 的文件：依赖清单 + CI 脚本目录
+	// 未配置自定义列表时按语言默认依赖文件处理（与 Loader 的默认值保持一致）
+	deps := cfg.Build.DependencyFiles
+	autoDetect := deps.AutoDetect || len(deps.Files) == 0
+	keep := make([]string, 0)
+	for _, file := range langService.GetDependencyFiles(cfg.Language.Type, autoDetect, deps.Files) {
+		keep = append(keep, "!"+file)
+	}
+	keep = append(keep, "!"+scriptDir+"/")
+
+	return []dockerignoreSection{
+		{title: "Common", patterns: dockerignoreCommonPatterns},
+		{title: "Language: " + cfg.Language.Type, patterns: langService.GetDockerignorePatterns(cfg.Language.Type)},
+		{title: "svcgen outputs (not needed in the build context)", patterns: outputs},
+		{title: "Required by the Dockerfile", patterns: keep},
+	}
+}
+
+// buildDockerignoreBlock 构建 marker block 内容
+func buildDockerignoreBlock(sections []dockerignoreSection) string {
+	var sb strings.Builder
+	sb.WriteString(dockerignoreStartMarker)
+	sb.WriteByte('\n')
+	for i, section := range sections {
+		if len(section.patterns) == 0 {
+			continue
+		}
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString("# " + section.title)
+		sb.WriteByte('\n')
+		for _, pattern := range section.patterns {
+			sb.WriteString(pattern)
+			sb.WriteByte('\n')
+		}
+	}
+	sb.WriteString(dockerignoreEndMarker)
+	return sb.String()
+}
+
+// dockerignoreFile 返回 .dockerignore 的 marker block
+// 与 .gitignore 相同，写入时增量更新 block，保留用户自定义条目
+func (g *Generator) dockerignoreFile() RenderedFile {
+	return RenderedFile{
+		Path:     ".dockerignore",
+		Content:  buildDockerignoreBlock(g.dockerignoreSections()),
+		Strategy: strategies.BlockStrategyID,
+	}
+}
@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	configtestutil "github.com/junjiewwang/service-template/pkg/config/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newDockerignoreTestGenerator(t *testing.T, outputDir, language string) *Generator {
+	t.Helper()
+
+	cfg := configtestutil.NewConfigBuilder().
+		WithService("test-service", "Test Service").
+		WithPort("http", 8080, "TCP", true).
+		WithLanguage(language).
+		WithBuilder("go_1.21", "golang:1.21", "golang:1.21").
+		WithRuntime("alpine_3.18", "alpine:3.18", "alpine:3.18").
+		WithBuilderImage("@builders.go_1.21").
+		WithRuntimeImage("@runtimes.alpine_3.18").
+		WithBuildCommand("go build -o bin/test-service").
+		WithDeployDir("/opt/services").
+		BuildWithDefaults()
+
+	return NewGenerator(cfg, outputDir)
+}
+
+func TestBuildDockerignoreBlock_Go(t *testing.T) {
+	gen := newDockerignoreTestGenerator(t, "/tmp/test-output", "go")
+
+	block := buildDockerignoreBlock(gen.dockerignoreSections())
+
+	assert.True(t, strings.HasPrefix(block, dockerignoreStartMarker))
+	assert.True(t, strings.HasSuffix(block, dockerignoreEndMarker))
+	// 通用 + 语言相关
+	assert.Contains(t, block, ".git/\n")
+	assert.Contains(t, block, "bin/\n")
+	// svcgen 产物
+	assert.Contains(t, block, "k8s-manifests/\n")
+	// 构建命令可能执行 make，Makefile 及其引用的文件不能排除
+	assert.NotContains(t, block, "\nMakefile\n")
+	assert.NotContains(t, block, "\ncompose.yaml\n")
+	assert.NotContains(t, block, "\n.env.make\n")
+	// Dockerfile 需要的文件必须重新包含，且位于排除规则之后
+	assert.Contains(t, block, "!go.mod\n")
+	assert.Contains(t, block, "!go.sum\n")
+	assert.Contains(t, block, "!.tad/build/test-service/\n")
+	assert.Greater(t, strings.Index(block, "!.tad/build/test-service/"), strings.Index(block, "\n.tad/\n"))
+}
+
+func TestBuildDockerignoreBlock_NodeJS(t *testing.T) {
+	gen := newDockerignoreTestGenerator(t, "/tmp/test-output", "nodejs")
+
+	block := buildDockerignoreBlock(gen.dockerignoreSections())
+
+	assert.Contains(t, block, "# Language: nodejs")
+	assert.Contains(t, block, "node_modules/\n")
+	assert.Contains(t, block, "!package.json\n")
+	assert.NotContains(t, block, "!go.mod")
+}
+
+func TestGenerator_WriteDockerignore_PreservesUserEntries(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, ".dockerignore")
+
+	existing := `# My entries
+*.log
+tmp/
+`
+	require.NoError(t, os.WriteFile(path, []byte(existing), 0644))
+
+	gen := newDockerignoreTestGenerator(t, tmpDir, "go")
+	require.NoError(t, writeRenderedFile(gen, ".dockerignore"))
+	// 再次执行应保持幂等
+	require.NoError(t, writeRenderedFile(gen, ".dockerignore"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	content := string(data)
+
+	assert.Contains(t, content, "*.log")
+	assert.Contains(t, content, "tmp/")
+	assert.Contains(t, content, "!go.mod")
+	assert.Equal(t, 1, countOccurrences(content, dockerignoreStartMarker))
+	assert.Equal(t, 1, countOccurrences(content, dockerignoreEndMarker))
+}
+
+func TestReplaceOrAppendMarkedBlock_CustomMarkers(t *testing.T) {
+	existing := "keep-me\n<<start\nold\n>>end\n"
+
+	result := replaceOrAppendMarkedBlock(existing, "<<start\nnew $1\n>>end", "<<start", ">>end")
+
+	assert.Equal(t, "keep-me\n<<start\nnew $1\n>>end\n", result)
+}
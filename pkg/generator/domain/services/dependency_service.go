@@ -38,7 +38,7 @@ func (s *DependencyService) GetBuildDependencies() BuildDependency {
 	deps := s.ctx.Config.Build.Dependencies
 
 	// Get variables using the new variable system
-	composer := s.ctx.GetVariableComposer().WithCommon().WithBuild()
+	composer := s.ctx.GetVariableComposer().WithCommon().WithBuild().WithUser()
 	vars := s.convertToStringMap(composer.Build())
 
 	// Process custom packages with variable substitution
@@ -33,3 +33,21 @@ func (s *ConfigurableStrategy) GetDepsInstallCommand() string {
 	// Use default command from wrapped strategy
 	return s.wrapped.GetDepsInstallCommand()
 }
+
+// GetDefaultBuildCommand returns the default build command derived from the language config
+// (e.g. language.config.main_package / build_tool); falls back to the wrapped strategy
+func (s *ConfigurableStrategy) GetDefaultBuildCommand() string {
+	if s.config != nil && config.HasDefaultBuildCommand(s.GetName()) {
+		return config.DefaultBuildCommand(s.GetName(), s.config)
+	}
+	return s.wrapped.GetDefaultBuildCommand()
+}
+
+// GetDefaultTestCommand returns the default test command derived from the language config
+// (Java projects built with language.config.build_tool=gradle run gradle instead of maven)
+func (s *ConfigurableStrategy) GetDefaultTestCommand() string {
+	if s.config != nil && s.GetName() == LangJava && s.config.GetString("build_tool", "maven") == "gradle" {
+		return "gradle test"
+	}
+	return s.wrapped.GetDefaultTestCommand()
+}
@@ -91,6 +91,12 @@ func TestVariableSubstitutor(t *testing.T) {
 			customCommand:  "pip install -r requirements.txt -t ${BUILD_OUTPUT_DIR}/bin --cache-dir ${BUILD_OUTPUT_DIR}/.cache",
 			expectedResult: "pip install -r requirements.txt -t /opt/dist/bin --cache-dir /opt/dist/.cache",
 		},
+		{
+			name:           "default and escaped runtime variable",
+			language:       "python",
+			customCommand:  "pip install -t ${BUILD_OUTPUT_DIR:-/tmp}/bin --cache-dir ${PIP_CACHE:-$${HOME}/.cache}",
+			expectedResult: "pip install -t /opt/dist/bin --cache-dir ${PIP_CACHE:-${HOME}/.cache}",
+		},
 	}
 
 	for _, tt := range tests {
@@ -240,3 +246,13 @@ func TestStrategyDecorator_Unwrap(t *testing.T) {
 	unwrapped := decorator.Unwrap()
 	assert.Equal(t, baseStrategy, unwrapped)
 }
+
+func TestConfigurableStrategy_DefaultBuildCommand(t *testing.T) {
+	goCfg := &config.LanguageConfig{Type: "go", Config: map[string]interface{}{"main_package": "./cmd/api"}}
+	assert.Contains(t, NewConfigurableStrategy(NewGoStrategy(), goCfg).GetDefaultBuildCommand(), "${SERVICE_NAME} ./cmd/api")
+
+	javaCfg := &config.LanguageConfig{Type: "java", Config: map[string]interface{}{"build_tool": "gradle"}}
+	assert.Contains(t, NewConfigurableStrategy(NewJavaStrategy(), javaCfg).GetDefaultBuildCommand(), "gradle build")
+
+	assert.Contains(t, NewConfigurableStrategy(NewGoStrategy(), nil).GetDefaultBuildCommand(), "./cmd/server")
+}
@@ -2,10 +2,10 @@ package languageservice
 
 import (
 	"fmt"
-	"os"
-	"path/filepath"
+	"io/fs"
 	"sort"
 
+	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
 )
 
@@ -33,12 +33,23 @@ type LanguageStrategy interface {
 	// Returns empty string if no reasonable default exists
 	GetDefaultBuildCommand() string
 
+	// GetDefaultTestCommand returns the default test command run in the Dockerfile test stage
+	GetDefaultTestCommand() string
+
+	// GetDefaultLintCommand returns the default lint command run in the Dockerfile lint stage
+	// Returns empty string if the builder image ships no lint tooling for this language
+	GetDefaultLintCommand() string
+
 	// GetPackageManager returns the package manager name
 	GetPackageManager() string
 
 	// GetDependencyFilesWithDetection returns dependency files that actually exist in the project
-	// projectDir: the root directory of the project to scan
-	GetDependencyFilesWithDetection(projectDir string) []string
+	// project: the file system rooted at the project directory
+	GetDependencyFilesWithDetection(project fs.FS) []string
+
+	// GetDockerignorePatterns returns language-specific .dockerignore patterns
+	// (local toolchain caches and build output that should not enter the build context)
+	GetDockerignorePatterns() []string
 }
 
 // LanguageService manages language-specific logic
@@ -79,7 +90,7 @@ func (s *LanguageService) GetDependencyFiles(language string, autoDetect bool, c
 }
 
 // GetDependencyFilesWithDetection returns dependency files that actually exist in the project
-func (s *LanguageService) GetDependencyFilesWithDetection(language string, projectDir string, autoDetect bool, customFiles []string) []string {
+func (s *LanguageService) GetDependencyFilesWithDetection(language string, project fs.FS, autoDetect bool, customFiles []string) []string {
 	if !autoDetect {
 		return customFiles
 	}
@@ -89,7 +100,7 @@ func (s *LanguageService) GetDependencyFilesWithDetection(language string, proje
 		return []string{}
 	}
 
-	return strategy.GetDependencyFilesWithDetection(projectDir)
+	return strategy.GetDependencyFilesWithDetection(project)
 }
 
 // GetDepsInstallCommand returns the dependency installation command
@@ -123,6 +134,36 @@ func (s *LanguageService) GetDefaultBuildCommand(language string) string {
 	return strategy.GetDefaultBuildCommand()
 }
 
+// GetDefaultTestCommand returns the default test command for the given language
+func (s *LanguageService) GetDefaultTestCommand(language string) string {
+	strategy, err := s.GetStrategy(language)
+	if err != nil {
+		return ""
+	}
+
+	return strategy.GetDefaultTestCommand()
+}
+
+// GetDefaultLintCommand returns the default lint command for the given language
+func (s *LanguageService) GetDefaultLintCommand(language string) string {
+	strategy, err := s.GetStrategy(language)
+	if err != nil {
+		return ""
+	}
+
+	return strategy.GetDefaultLintCommand()
+}
+
+// GetDockerignorePatterns returns the .dockerignore patterns for the given language
+func (s *LanguageService) GetDockerignorePatterns(language string) []string {
+	strategy, err := s.GetStrategy(language)
+	if err != nil {
+		return []string{}
+	}
+
+	return strategy.GetDockerignorePatterns()
+}
+
 // IsSupported checks if the language is supported
 func (s *LanguageService) IsSupported(language string) bool {
 	_, err := s.GetStrategy(language)
@@ -158,8 +199,8 @@ func (s *GoStrategy) GetDependencyFiles() []string {
 	return []string{"go.mod", "go.sum"}
 }
 
-func (s *GoStrategy) GetDependencyFilesWithDetection(projectDir string) []string {
-	return filterExistingFiles(projectDir, s.GetDependencyFiles())
+func (s *GoStrategy) GetDependencyFilesWithDetection(project fs.FS) []string {
+	return filterExistingFiles(project, s.GetDependencyFiles())
 }
 
 func (s *GoStrategy) GetDepsInstallCommand() string {
@@ -171,7 +212,19 @@ func (s *GoStrategy) GetPackageManager() string {
 }
 
 func (s *GoStrategy) GetDefaultBuildCommand() string {
-	return `CGO_ENABLED=0 go build -ldflags="-s -w" -o ${BUILD_OUTPUT_DIR}/bin/${SERVICE_NAME} ./cmd/server`
+	return config.DefaultBuildCommand(LangGo, &config.LanguageConfig{Type: LangGo})
+}
+
+func (s *GoStrategy) GetDefaultTestCommand() string {
+	return "go test ./..."
+}
+
+func (s *GoStrategy) GetDefaultLintCommand() string {
+	return "go vet ./..."
+}
+
+func (s *GoStrategy) GetDockerignorePatterns() []string {
+	return []string{"bin/", "*.test", "*.out", "coverage.*"}
 }
 
 // --- Python Language Strategy ---
@@ -192,8 +245,8 @@ func (s *PythonStrategy) GetDependencyFiles() []string {
 	return []string{"requirements.txt"}
 }
 
-func (s *PythonStrategy) GetDependencyFilesWithDetection(projectDir string) []string {
-	return filterExistingFiles(projectDir, s.GetDependencyFiles())
+func (s *PythonStrategy) GetDependencyFilesWithDetection(project fs.FS) []string {
+	return filterExistingFiles(project, s.GetDependencyFiles())
 }
 
 func (s *PythonStrategy) GetDepsInstallCommand() string {
@@ -208,6 +261,18 @@ func (s *PythonStrategy) GetDefaultBuildCommand() string {
 	return `cp -r . ${BUILD_OUTPUT_DIR}/`
 }
 
+func (s *PythonStrategy) GetDefaultTestCommand() string {
+	return "pytest"
+}
+
+func (s *PythonStrategy) GetDefaultLintCommand() string {
+	return ""
+}
+
+func (s *PythonStrategy) GetDockerignorePatterns() []string {
+	return []string{"__pycache__/", "*.py[cod]", ".venv/", "venv/", ".pytest_cache/", ".mypy_cache/", "*.egg-info/"}
+}
+
 // --- NodeJS Language Strategy ---
 
 // NodeJSStrategy implements LanguageStrategy for NodeJS
@@ -226,8 +291,8 @@ func (s *NodeJSStrategy) GetDependencyFiles() []string {
 	return []string{"package.json", "package-lock.json"}
 }
 
-func (s *NodeJSStrategy) GetDependencyFilesWithDetection(projectDir string) []string {
-	return filterExistingFiles(projectDir, s.GetDependencyFiles())
+func (s *NodeJSStrategy) GetDependencyFilesWithDetection(project fs.FS) []string {
+	return filterExistingFiles(project, s.GetDependencyFiles())
 }
 
 func (s *NodeJSStrategy) GetDepsInstallCommand() string {
@@ -242,6 +307,18 @@ func (s *NodeJSStrategy) GetDefaultBuildCommand() string {
 	return `npm run build 2>/dev/null || true && cp -r . ${BUILD_OUTPUT_DIR}/`
 }
 
+func (s *NodeJSStrategy) GetDefaultTestCommand() string {
+	return "npm test"
+}
+
+func (s *NodeJSStrategy) GetDefaultLintCommand() string {
+	return "npm run lint --if-present"
+}
+
+func (s *NodeJSStrategy) GetDockerignorePatterns() []string {
+	return []string{"node_modules/", "npm-debug.log*", "yarn-error.log*", "coverage/", ".npm/"}
+}
+
 // --- Java Language Strategy ---
 
 // JavaStrategy implements LanguageStrategy for Java
@@ -264,23 +341,19 @@ func (s *JavaStrategy) GetDependencyFiles() []string {
 	return []string{"pom.xml", "build.gradle", "settings.gradle"}
 }
 
-func (s *JavaStrategy) GetDependencyFilesWithDetection(projectDir string) []string {
+func (s *JavaStrategy) GetDependencyFilesWithDetection(project fs.FS) []string {
 	var detectedFiles []string
 
 	// Check for Maven (pom.xml)
-	pomPath := filepath.Join(projectDir, "pom.xml")
-	if fileExists(pomPath) {
+	if fileExists(project, "pom.xml") {
 		detectedFiles = append(detectedFiles, "pom.xml")
 	}
 
 	// Check for Gradle (build.gradle and settings.gradle)
-	buildGradlePath := filepath.Join(projectDir, "build.gradle")
-	settingsGradlePath := filepath.Join(projectDir, "settings.gradle")
-
-	if fileExists(buildGradlePath) {
+	if fileExists(project, "build.gradle") {
 		detectedFiles = append(detectedFiles, "build.gradle")
 	}
-	if fileExists(settingsGradlePath) {
+	if fileExists(project, "settings.gradle") {
 		detectedFiles = append(detectedFiles, "settings.gradle")
 	}
 
@@ -302,6 +375,18 @@ func (s *JavaStrategy) GetDefaultBuildCommand() string {
 	return `mvn package -DskipTests && cp target/*.jar ${BUILD_OUTPUT_DIR}/app.jar`
 }
 
+func (s *JavaStrategy) GetDefaultTestCommand() string {
+	return "mvn test"
+}
+
+func (s *JavaStrategy) GetDefaultLintCommand() string {
+	return ""
+}
+
+func (s *JavaStrategy) GetDockerignorePatterns() []string {
+	return []string{"target/", "build/", ".gradle/", "*.class"}
+}
+
 // --- Rust Language Strategy ---
 
 // RustStrategy implements LanguageStrategy for Rust
@@ -320,8 +405,8 @@ func (s *RustStrategy) GetDependencyFiles() []string {
 	return []string{"Cargo.toml", "Cargo.lock"}
 }
 
-func (s *RustStrategy) GetDependencyFilesWithDetection(projectDir string) []string {
-	return filterExistingFiles(projectDir, s.GetDependencyFiles())
+func (s *RustStrategy) GetDependencyFilesWithDetection(project fs.FS) []string {
+	return filterExistingFiles(project, s.GetDependencyFiles())
 }
 
 func (s *RustStrategy) GetDepsInstallCommand() string {
@@ -336,11 +421,23 @@ func (s *RustStrategy) GetDefaultBuildCommand() string {
 	return `cargo build --release && cp target/release/${SERVICE_NAME} ${BUILD_OUTPUT_DIR}/bin/${SERVICE_NAME}`
 }
 
+func (s *RustStrategy) GetDefaultTestCommand() string {
+	return "cargo test"
+}
+
+func (s *RustStrategy) GetDefaultLintCommand() string {
+	return ""
+}
+
+func (s *RustStrategy) GetDockerignorePatterns() []string {
+	return []string{"target/"}
+}
+
 // --- Helper Functions ---
 
 // fileExists checks if a file exists
-func fileExists(path string) bool {
-	info, err := os.Stat(path)
+func fileExists(project fs.FS, name string) bool {
+	info, err := fs.Stat(project, name)
 	if err != nil {
 		return false
 	}
@@ -348,11 +445,10 @@ func fileExists(path string) bool {
 }
 
 // filterExistingFiles filters the list of files to only include those that exist
-func filterExistingFiles(projectDir string, files []string) []string {
+func filterExistingFiles(project fs.FS, files []string) []string {
 	var existingFiles []string
 	for _, file := range files {
-		filePath := filepath.Join(projectDir, file)
-		if fileExists(filePath) {
+		if fileExists(project, file) {
 			existingFiles = append(existingFiles, file)
 		}
 	}
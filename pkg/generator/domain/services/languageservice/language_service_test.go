@@ -1,9 +1,12 @@
 package languageservice
 
 import (
+	"io/fs"
 	"os"
 	"testing"
+	"testing/fstest"
 
+	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
 	"github.com/junjiewwang/service-template/pkg/generator/internal/testutil"
 )
@@ -341,7 +344,7 @@ func TestLanguageService_GetDependencyFilesWithDetection(t *testing.T) {
 				f.Close()
 			}
 
-			got := service.GetDependencyFilesWithDetection(tt.language, tt.projectDir, tt.autoDetect, tt.customFiles)
+			got := service.GetDependencyFilesWithDetection(tt.language, os.DirFS(tt.projectDir), tt.autoDetect, tt.customFiles)
 			if len(got) != len(tt.expected) {
 				t.Errorf("GetDependencyFilesWithDetection() length = %d, want %d", len(got), len(tt.expected))
 				t.Errorf("Got: %v, Want: %v", got, tt.expected)
@@ -406,7 +409,7 @@ func TestJavaStrategy_GetDependencyFilesWithDetection(t *testing.T) {
 				f.Close()
 			}
 
-			got := strategy.GetDependencyFilesWithDetection(tmpDir)
+			got := strategy.GetDependencyFilesWithDetection(os.DirFS(tmpDir))
 			if len(got) != len(tt.expected) {
 				t.Errorf("GetDependencyFilesWithDetection() length = %d, want %d", len(got), len(tt.expected))
 				t.Errorf("Got: %v, Want: %v", got, tt.expected)
@@ -420,3 +423,107 @@ func TestJavaStrategy_GetDependencyFilesWithDetection(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDependencyFilesWithDetection_ProjectSnapshot(t *testing.T) {
+	// Detection only reads through fs.FS, so it works on a snapshot that is not on disk
+	project := fstest.MapFS{
+		"go.mod":                {Data: []byte("module example.com/app\n")},
+		"frontend/package.json": {Data: []byte("{}")},
+		"go.sum":                {Mode: fs.ModeDir},
+	}
+
+	got := NewGoStrategy().GetDependencyFilesWithDetection(project)
+	if len(got) != 1 || got[0] != "go.mod" {
+		t.Errorf("GetDependencyFilesWithDetection() = %v, want [go.mod] (directories are not dependency files)", got)
+	}
+
+	got = NewNodeJSStrategy().GetDependencyFilesWithDetection(project)
+	if len(got) == 0 || got[0] != "package.json" {
+		t.Errorf("GetDependencyFilesWithDetection() = %v, want the fallback list (package.json is only in a subdirectory)", got)
+	}
+}
+
+func TestLanguageService_GetDockerignorePatterns(t *testing.T) {
+	service := createTestService()
+
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{"go", "bin/"},
+		{"python", "__pycache__/"},
+		{"nodejs", "node_modules/"},
+		{"java", "target/"},
+		{"rust", "target/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			patterns := service.GetDockerignorePatterns(tt.language)
+			found := false
+			for _, p := range patterns {
+				if p == tt.want {
+					found = true
+					break
+				}
+			}
+			if !found {
+				t.Errorf("GetDockerignorePatterns(%s) = %v, want to contain %s", tt.language, patterns, tt.want)
+			}
+		})
+	}
+
+	if patterns := service.GetDockerignorePatterns("unknown"); len(patterns) != 0 {
+		t.Errorf("GetDockerignorePatterns(unknown) = %v, want empty", patterns)
+	}
+}
+
+func TestLanguageService_GetDefaultTestAndLintCommand(t *testing.T) {
+	service := createTestService()
+
+	tests := []struct {
+		language string
+		test     string
+		lint     string
+	}{
+		{"go", "go test ./...", "go vet ./..."},
+		{"python", "pytest", ""},
+		{"nodejs", "npm test", "npm run lint --if-present"},
+		{"java", "mvn test", ""},
+		{"rust", "cargo test", ""},
+		{"unknown", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			if got := service.GetDefaultTestCommand(tt.language); got != tt.test {
+				t.Errorf("GetDefaultTestCommand(%s) = %q, want %q", tt.language, got, tt.test)
+			}
+			if got := service.GetDefaultLintCommand(tt.language); got != tt.lint {
+				t.Errorf("GetDefaultLintCommand(%s) = %q, want %q", tt.language, got, tt.lint)
+			}
+		})
+	}
+}
+
+func TestResolveTestCommand_FromStrategies(t *testing.T) {
+	tests := []struct {
+		name string
+		lang config.LanguageConfig
+		want string
+	}{
+		{"go", config.LanguageConfig{Type: LangGo}, "go test ./..."},
+		{"maven", config.LanguageConfig{Type: LangJava}, "mvn test"},
+		{"gradle", config.LanguageConfig{Type: LangJava, Config: map[string]interface{}{"build_tool": "gradle"}}, "gradle test"},
+		{"unknown", config.LanguageConfig{Type: "cobol"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.ServiceConfig{Language: tt.lang}
+			if got := config.ResolveTestCommand(cfg); got != tt.want {
+				t.Errorf("ResolveTestCommand(%s) = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package languageservice
 
+import "io/fs"
+
 // StrategyDecorator is the base decorator for LanguageStrategy
 // It implements the Decorator Pattern to add functionality to strategies
 type StrategyDecorator struct {
@@ -38,9 +40,24 @@ func (d *StrategyDecorator) GetDefaultBuildCommand() string {
 	return d.wrapped.GetDefaultBuildCommand()
 }
 
+// GetDefaultTestCommand delegates to the wrapped strategy
+func (d *StrategyDecorator) GetDefaultTestCommand() string {
+	return d.wrapped.GetDefaultTestCommand()
+}
+
+// GetDefaultLintCommand delegates to the wrapped strategy
+func (d *StrategyDecorator) GetDefaultLintCommand() string {
+	return d.wrapped.GetDefaultLintCommand()
+}
+
 // GetDependencyFilesWithDetection delegates to the wrapped strategy
-func (d *StrategyDecorator) GetDependencyFilesWithDetection(projectDir string) []string {
-	return d.wrapped.GetDependencyFilesWithDetection(projectDir)
+func (d *StrategyDecorator) GetDependencyFilesWithDetection(project fs.FS) []string {
+	return d.wrapped.GetDependencyFilesWithDetection(project)
+}
+
+// GetDockerignorePatterns delegates to the wrapped strategy
+func (d *StrategyDecorator) GetDockerignorePatterns() []string {
+	return d.wrapped.GetDockerignorePatterns()
 }
 
 // Unwrap returns the wrapped strategy
@@ -52,6 +52,18 @@ var strategyRegistry = map[string]LanguageRegistryEntry{
 	},
 }
 
+// The strategies own the default test / lint commands; config resolves
+// build.commands.test / lint through this provider.
+func init() {
+	config.RegisterCheckCommandProvider(func(lang *config.LanguageConfig) (string, string) {
+		strategy, err := NewStrategyFactory(nil).CreateStrategy(lang.Type, lang)
+		if err != nil {
+			return "", ""
+		}
+		return strategy.GetDefaultTestCommand(), strategy.GetDefaultLintCommand()
+	})
+}
+
 // StrategyFactory creates decorated language strategies
 type StrategyFactory struct {
 	ctx *context.GeneratorContext
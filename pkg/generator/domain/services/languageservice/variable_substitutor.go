@@ -1,10 +1,8 @@
 package languageservice
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/junjiewwang/service-template/pkg/generator/context"
+	"github.com/junjiewwang/service-template/pkg/generator/core"
 )
 
 // VariableSubstitutor decorates a strategy with variable substitution capability
@@ -32,23 +30,13 @@ func (s *VariableSubstitutor) GetDepsInstallCommand() string {
 }
 
 // substituteVariables replaces variables in the command string
-// Supports ${VAR} format
+// Supports ${VAR}, ${VAR:-default} and $${VAR} escaping (see context.Substitutor)
 func (s *VariableSubstitutor) substituteVariables(command string) string {
 	if s.ctx == nil {
 		return command
 	}
 
-	// Get all common variables from context
-	composer := s.ctx.GetVariableComposer().WithCommon()
-	variables := composer.Build()
-
-	result := command
-
-	// Replace ${VAR} format
-	for key, value := range variables {
-		placeholder := fmt.Sprintf("${%s}", key)
-		result = strings.ReplaceAll(result, placeholder, fmt.Sprint(value))
-	}
-
-	return result
+	// Get common and user-defined variables from context
+	composer := s.ctx.GetVariableComposer().WithCommon().WithUser()
+	return core.SubstituteVariables(command, composer.Build())
 }
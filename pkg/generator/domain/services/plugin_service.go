@@ -37,7 +37,7 @@ func (s *PluginService) PrepareForDockerfile() []map[string]interface{} {
 	sharedInstallDir := s.ctx.Config.Plugins.InstallDir
 
 	// Get base variables using the new variable system
-	composer := s.ctx.GetVariableComposer().WithCommon().WithPlugin()
+	composer := s.ctx.GetVariableComposer().WithCommon().WithPlugin().WithUser()
 	baseVars := composer.Build()
 
 	for _, plugin := range s.ctx.Config.Plugins.Items {
@@ -55,7 +55,7 @@ func (s *PluginService) PrepareForDockerfile() []map[string]interface{} {
 			"InstallCommand": core.SubstituteVariables(plugin.InstallCommand, pluginVars),
 			"Name":           plugin.Name,
 			"InstallDir":     sharedInstallDir,
-			"RuntimeEnv":     plugin.RuntimeEnv,
+			"RuntimeEnv":     s.expandUserVariables(plugin.RuntimeEnv),
 		})
 	}
 
@@ -83,7 +83,7 @@ func (s *PluginService) PrepareForBuildScript() []PluginInfo {
 			DownloadURL:       downloadURL,
 			URLResolverScript: urlResolverScript,
 			InstallDir:        sharedInstallDir,
-			InstallCommand:    plugin.InstallCommand,
+			InstallCommand:    s.ctx.VariablePool.ExpandUserVariables(plugin.InstallCommand),
 			RuntimeEnv:        processedEnv,
 		})
 	}
@@ -101,7 +101,7 @@ func (s *PluginService) PrepareForEntrypoint() []map[string]interface{} {
 			pluginEnvs = append(pluginEnvs, map[string]interface{}{
 				"Name":       plugin.Name,
 				"InstallDir": sharedInstallDir,
-				"RuntimeEnv": plugin.RuntimeEnv,
+				"RuntimeEnv": s.expandUserVariables(plugin.RuntimeEnv),
 			})
 		}
 	}
@@ -113,7 +113,7 @@ func (s *PluginService) PrepareForEntrypoint() []map[string]interface{} {
 func (s *PluginService) processRuntimeEnv(envVars []config.EnvironmentVariable, installDir string) []config.EnvironmentVariable {
 	processed := make([]config.EnvironmentVariable, len(envVars))
 
-	for i, env := range envVars {
+	for i, env := range s.expandUserVariables(envVars) {
 		processed[i] = config.EnvironmentVariable{
 			Name: env.Name,
 			Value: s.engine.ReplaceVariables(env.Value, map[string]string{
@@ -125,6 +125,18 @@ func (s *PluginService) processRuntimeEnv(envVars []config.EnvironmentVariable,
 	return processed
 }
 
+// expandUserVariables returns a copy of envVars with user-defined variables (vars:) expanded in the values
+func (s *PluginService) expandUserVariables(envVars []config.EnvironmentVariable) []config.EnvironmentVariable {
+	if envVars == nil {
+		return nil
+	}
+	expanded := make([]config.EnvironmentVariable, len(envVars))
+	for i, env := range envVars {
+		expanded[i] = config.EnvironmentVariable{Name: env.Name, Value: s.ctx.VariablePool.ExpandUserVariables(env.Value)}
+	}
+	return expanded
+}
+
 // HasPlugins checks if there are any plugins configured
 func (s *PluginService) HasPlugins() bool {
 	return len(s.ctx.Config.Plugins.Items) > 0
@@ -141,12 +153,12 @@ func (s *PluginService) GetPluginCount() int {
 }
 
 // resolveDownloadURL resolves the download URL based on configuration type
-// For static URL: returns the URL directly
+// For static URL: returns the URL directly (after registry_mirrors rewriting)
 // For arch mapping: returns a placeholder that will be resolved at runtime
 func (s *PluginService) resolveDownloadURL(urlConfig config.DownloadURLConfig) string {
 	if urlConfig.IsStatic() {
 		url, _ := urlConfig.GetStaticURL()
-		return url
+		return s.ctx.Config.RegistryMirrors.RewriteDownloadURL(url)
 	}
 	// For arch mapping, return a placeholder
 	// The actual URL will be resolved in the shell script at runtime
@@ -159,11 +171,11 @@ func (s *PluginService) GenerateURLResolverScript(urlConfig config.DownloadURLCo
 	if urlConfig.IsStatic() {
 		// For static URL, just echo the URL
 		url, _ := urlConfig.GetStaticURL()
-		return "PLUGIN_DOWNLOAD_URL=\"" + url + "\""
+		return "PLUGIN_DOWNLOAD_URL=\"" + s.ctx.Config.RegistryMirrors.RewriteDownloadURL(url) + "\""
 	}
 
 	// For arch mapping, generate case statement
-	urls, _ := urlConfig.GetArchURLs()
+	urls := s.mirrorArchURLs(urlConfig)
 	script := `# Detect architecture and set download URL
 ARCH=$(uname -m)
 case "${ARCH}" in
@@ -197,6 +209,16 @@ case "${ARCH}" in
 	return script
 }
 
+// mirrorArchURLs returns the architecture URL mapping with registry_mirrors rewriting applied
+func (s *PluginService) mirrorArchURLs(urlConfig config.DownloadURLConfig) map[string]string {
+	urls, _ := urlConfig.GetArchURLs()
+	mirrored := make(map[string]string, len(urls))
+	for arch, url := range urls {
+		mirrored[arch] = s.ctx.Config.RegistryMirrors.RewriteDownloadURL(url)
+	}
+	return mirrored
+}
+
 // normalizeArchMapping normalizes architecture names to standard forms
 // Maps common aliases to standard architecture names
 func (s *PluginService) normalizeArchMapping(urls map[string]string) map[string]string {
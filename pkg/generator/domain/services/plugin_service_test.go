@@ -128,6 +128,30 @@ func TestPluginService_GenerateURLResolverScript_ArchMapping(t *testing.T) {
 	assert.Contains(t, script, "exit 1")
 }
 
+func TestPluginService_RegistryMirrors(t *testing.T) {
+	// Arrange
+	cfg := testutil.NewMinimal("test-service")
+	cfg.RegistryMirrors = config.RegistryMirrorsConfig{MirrorRules: config.MirrorRules{
+		DownloadURLs: map[string]string{"github.com": "ghproxy.example.com/github.com"},
+	}}
+	ctx := context.NewGeneratorContext(cfg, ".")
+	service := NewPluginService(ctx, core.NewTemplateEngine())
+
+	// Act
+	static := service.GenerateURLResolverScript(config.NewStaticDownloadURL("https://github.com/org/tool/tool.sh"))
+	archScript := service.GenerateURLResolverScript(config.NewArchMappingDownloadURL(map[string]string{
+		"x86_64":  "https://github.com/org/tool/tool-x86_64.tar.gz",
+		"default": "https://example.com/tool.tar.gz",
+	}))
+
+	// Assert
+	assert.Equal(t, `PLUGIN_DOWNLOAD_URL="https://ghproxy.example.com/github.com/org/tool/tool.sh"`, static)
+	assert.Contains(t, archScript, "https://ghproxy.example.com/github.com/org/tool/tool-x86_64.tar.gz")
+	assert.Contains(t, archScript, "https://example.com/tool.tar.gz")
+	assert.Equal(t, "https://ghproxy.example.com/github.com/org/tool/tool.sh",
+		service.resolveDownloadURL(config.NewStaticDownloadURL("https://github.com/org/tool/tool.sh")))
+}
+
 func TestPluginService_GenerateURLResolverScript_WithDefault(t *testing.T) {
 	// Arrange
 	cfg := testutil.NewMinimal("test-service")
@@ -0,0 +1,97 @@
+// Package external runs generators that live outside the svcgen binary.
+//
+// An external generator is an executable (svcgen-gen-<name> on PATH, or a command listed
+// under generators.items in service.yaml), similar to a protoc plugin: svcgen writes a
+// Request as JSON to its stdin and reads a Response as JSON from its stdout. Files in the
+// response are written by svcgen like the built-in outputs. Anything the generator prints
+// to stderr is reported when it fails.
+package external
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ProtocolVersion is sent in every request; it changes only on incompatible changes
+const ProtocolVersion = 1
+
+// Request is written to the generator's stdin
+type Request struct {
+	ProtocolVersion int `json:"protocol_version"`
+	// Generator is the configured name of the generator being run
+	Generator string `json:"generator"`
+	// Options are generators.items[].options from service.yaml
+	Options map[string]interface{} `json:"options,omitempty"`
+	// Config is the resolved service.yaml (defaults applied), with the same keys as the file
+	Config map[string]interface{} `json:"config"`
+	// Variables are the shared template variables (see svcgen vars)
+	Variables map[string]interface{} `json:"variables"`
+	Paths     Paths                  `json:"paths"`
+}
+
+// Paths are the output and service paths; host paths are relative to OutputDir
+type Paths struct {
+	// OutputDir is the absolute output directory
+	OutputDir          string `json:"output_dir"`
+	ScriptDir          string `json:"script_dir"`
+	BuildConfigDir     string `json:"build_config_dir"`
+	ConfigTemplateDir  string `json:"config_template_dir"`
+	ContainerScriptDir string `json:"container_script_dir"`
+	// Paths inside the runtime image
+	ServiceRoot string `json:"service_root"`
+	DeployDir   string `json:"deploy_dir"`
+	ConfigDir   string `json:"config_dir"`
+	BinDir      string `json:"bin_dir"`
+}
+
+// Response is read from the generator's stdout
+type Response struct {
+	Files []File `json:"files"`
+	// Warnings are printed by svcgen; they do not fail the generation
+	Warnings []string `json:"warnings,omitempty"`
+	// Error fails the generation with this message
+	Error string `json:"error,omitempty"`
+}
+
+// File is a file to generate
+type File struct {
+	// Path is relative to the output directory and may not leave it
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	// Mode is an octal permission string such as "0755" (default "0644")
+	Mode string `json:"mode,omitempty"`
+	// Strategy is a filewriter strategy: overwrite (default), incremental or skip
+	Strategy string `json:"strategy,omitempty"`
+}
+
+// CleanPath validates the file path and returns it in the OS path format
+func (f File) CleanPath() (string, error) {
+	if f.Path == "" {
+		return "", fmt.Errorf("file path is empty")
+	}
+	slashed := filepath.ToSlash(f.Path)
+	if path.IsAbs(slashed) || filepath.IsAbs(f.Path) {
+		return "", fmt.Errorf("file path %s must be relative to the output directory", f.Path)
+	}
+	cleaned := path.Clean(slashed)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("file path %s leaves the output directory", f.Path)
+	}
+	return filepath.FromSlash(cleaned), nil
+}
+
+// FileMode parses Mode; an empty mode returns 0 (the writer's default)
+func (f File) FileMode() (os.FileMode, error) {
+	if f.Mode == "" {
+		return 0, nil
+	}
+	mode, err := strconv.ParseUint(f.Mode, 8, 32)
+	if err != nil || mode > 0777 {
+		return 0, fmt.Errorf("file %s: invalid mode %q (use an octal permission such as \"0755\")", f.Path, f.Mode)
+	}
+	return os.FileMode(mode), nil
+}
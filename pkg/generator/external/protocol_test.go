@@ -0,0 +1,53 @@
+package external
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFile_CleanPath(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr string
+	}{
+		{path: "catalog/service.yaml", want: filepath.Join("catalog", "service.yaml")},
+		{path: "./deploy/../deploy.yaml", want: "deploy.yaml"},
+		{path: "", wantErr: "empty"},
+		{path: "/etc/passwd", wantErr: "must be relative"},
+		{path: "../outside.yaml", wantErr: "leaves the output directory"},
+		{path: "a/../../outside.yaml", wantErr: "leaves the output directory"},
+		{path: ".", wantErr: "leaves the output directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := File{Path: tt.path}.CleanPath()
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFile_FileMode(t *testing.T) {
+	mode, err := File{Path: "run.sh", Mode: "0755"}.FileMode()
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), mode)
+
+	mode, err = File{Path: "a.yaml"}.FileMode()
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0), mode)
+
+	for _, invalid := range []string{"rwx", "0999", "01777"} {
+		_, err := File{Path: "a.yaml", Mode: invalid}.FileMode()
+		assert.ErrorContains(t, err, "invalid mode", invalid)
+	}
+}
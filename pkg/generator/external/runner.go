@@ -0,0 +1,150 @@
+package external
+
+import (
+	"bytes"
+	gocontext "context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+)
+
+// Generator is an external generator executable
+type Generator struct {
+	Name string
+	// Command is the executable path (resolved on PATH when it has no path separator)
+	Command string
+	Args    []string
+	Options map[string]interface{}
+	// Discovered is true for svcgen-gen-* executables found on PATH by generators.discover
+	Discovered bool
+}
+
+// Resolve lists the generators configured in service.yaml followed, when discover is enabled,
+// by the svcgen-gen-* executables on PATH that are not configured explicitly (sorted by name)
+func Resolve(cfg config.GeneratorsConfig) []Generator {
+	var generators []Generator
+	configured := map[string]bool{}
+	for _, item := range cfg.Items {
+		configured[item.Name] = true
+		generators = append(generators, Generator{
+			Name:    item.Name,
+			Command: item.Executable(),
+			Args:    item.Args,
+			Options: item.Options,
+		})
+	}
+	if cfg.Discover {
+		for _, discovered := range Discover(os.Getenv("PATH")) {
+			if !configured[discovered.Name] {
+				generators = append(generators, discovered)
+			}
+		}
+	}
+	return generators
+}
+
+// Discover finds svcgen-gen-* executables in the directories of pathList;
+// the first executable of a name wins, like a PATH lookup
+func Discover(pathList string) []Generator {
+	found := map[string]Generator{}
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := generatorName(entry.Name())
+			if !ok || found[name].Command != "" {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			found[name] = Generator{Name: name, Command: path, Discovered: true}
+		}
+	}
+
+	generators := make([]Generator, 0, len(found))
+	for _, generator := range found {
+		generators = append(generators, generator)
+	}
+	sort.Slice(generators, func(i, j int) bool { return generators[i].Name < generators[j].Name })
+	return generators
+}
+
+// generatorName returns the generator name of a svcgen-gen-<name> executable file name
+func generatorName(fileName string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		fileName = strings.TrimSuffix(strings.ToLower(fileName), ".exe")
+	}
+	name, ok := strings.CutPrefix(fileName, config.ExternalGeneratorPrefix)
+	return name, ok && name != ""
+}
+
+// isExecutable reports whether path is a regular file that can be executed
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	return runtime.GOOS == "windows" || info.Mode()&0111 != 0
+}
+
+// Run executes the generator with the request on stdin and decodes the response from stdout
+func (g Generator) Run(ctx gocontext.Context, req Request) (*Response, error) {
+	command, err := exec.LookPath(g.Command)
+	if err != nil {
+		return nil, fmt.Errorf("generator %s: %w", g.Name, err)
+	}
+
+	req.ProtocolVersion = ProtocolVersion
+	req.Generator = g.Name
+	req.Options = g.Options
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("generator %s: failed to encode request: %w", g.Name, err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command, g.Args...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("generator %s: %w%s", g.Name, err, formatStderr(stderr.String()))
+	}
+
+	var resp Response
+	decoder := json.NewDecoder(&stdout)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&resp); err != nil {
+		return nil, fmt.Errorf("generator %s: invalid response: %w%s", g.Name, err, formatStderr(stderr.String()))
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("generator %s: %s", g.Name, resp.Error)
+	}
+	return &resp, nil
+}
+
+// formatStderr appends the generator's stderr to an error message
+func formatStderr(stderr string) string {
+	stderr = strings.TrimSpace(stderr)
+	if stderr == "" {
+		return ""
+	}
+	return "\n" + stderr
+}
@@ -0,0 +1,121 @@
+package external
+
+import (
+	gocontext "context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeScript writes an executable shell script
+func writeScript(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("external generator tests use shell scripts")
+	}
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body), 0755))
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	writeScript(t, first, "svcgen-gen-catalog", "exit 0\n")
+	writeScript(t, second, "svcgen-gen-catalog", "exit 0\n")
+	writeScript(t, second, "svcgen-gen-deploy", "exit 0\n")
+	writeScript(t, second, "other-tool", "exit 0\n")
+	require.NoError(t, os.WriteFile(filepath.Join(second, "svcgen-gen-readme"), []byte("not executable"), 0644))
+	require.NoError(t, os.Mkdir(filepath.Join(second, "svcgen-gen-dir"), 0755))
+
+	generators := Discover(first + string(os.PathListSeparator) + second)
+	require.Len(t, generators, 2)
+	assert.Equal(t, "catalog", generators[0].Name)
+	assert.Equal(t, filepath.Join(first, "svcgen-gen-catalog"), generators[0].Command)
+	assert.Equal(t, "deploy", generators[1].Name)
+	assert.True(t, generators[1].Discovered)
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "svcgen-gen-catalog", "exit 0\n")
+	writeScript(t, dir, "svcgen-gen-deploy", "exit 0\n")
+	t.Setenv("PATH", dir)
+
+	cfg := config.GeneratorsConfig{Items: []config.ExternalGeneratorConfig{
+		{Name: "deploy", Args: []string{"--env", "prod"}},
+	}}
+	generators := Resolve(cfg)
+	require.Len(t, generators, 1)
+	assert.Equal(t, "svcgen-gen-deploy", generators[0].Command)
+	assert.Equal(t, []string{"--env", "prod"}, generators[0].Args)
+
+	cfg.Discover = true
+	generators = Resolve(cfg)
+	require.Len(t, generators, 2)
+	assert.Equal(t, "deploy", generators[0].Name)
+	assert.False(t, generators[0].Discovered)
+	assert.Equal(t, "catalog", generators[1].Name)
+	assert.True(t, generators[1].Discovered)
+}
+
+func TestGenerator_Run(t *testing.T) {
+	dir := t.TempDir()
+	requestFile := filepath.Join(dir, "request.json")
+	script := writeScript(t, dir, "gen", `cat > "$1"
+printf '%s\n' '{"files": [{"path": "catalog.yaml", "content": "name: demo\n", "mode": "0600"}], "warnings": ["beta"]}'
+`)
+
+	gen := Generator{Name: "catalog", Command: script, Args: []string{requestFile}, Options: map[string]interface{}{"team": "infra"}}
+	resp, err := gen.Run(gocontext.Background(), Request{
+		Config:    map[string]interface{}{"service": map[string]interface{}{"name": "demo"}},
+		Variables: map[string]interface{}{"SERVICE_NAME": "demo"},
+		Paths:     Paths{OutputDir: "/project"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Files, 1)
+	assert.Equal(t, "catalog.yaml", resp.Files[0].Path)
+	assert.Equal(t, "name: demo\n", resp.Files[0].Content)
+	assert.Equal(t, "0600", resp.Files[0].Mode)
+	assert.Equal(t, []string{"beta"}, resp.Warnings)
+
+	data, err := os.ReadFile(requestFile)
+	require.NoError(t, err)
+	var req Request
+	require.NoError(t, json.Unmarshal(data, &req))
+	assert.Equal(t, ProtocolVersion, req.ProtocolVersion)
+	assert.Equal(t, "catalog", req.Generator)
+	assert.Equal(t, "infra", req.Options["team"])
+	assert.Equal(t, "demo", req.Variables["SERVICE_NAME"])
+	assert.Equal(t, "/project", req.Paths.OutputDir)
+}
+
+func TestGenerator_Run_Errors(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{name: "exit", body: "cat >/dev/null\necho 'missing template' >&2\nexit 3\n", wantErr: "exit status 3\nmissing template"},
+		{name: "invalid", body: "cat >/dev/null\necho 'not json'\n", wantErr: "invalid response"},
+		{name: "unknown-field", body: "cat >/dev/null\necho '{\"file\": []}'\n", wantErr: "invalid response"},
+		{name: "reported", body: "cat >/dev/null\necho '{\"error\": \"catalog id is required\"}'\n", wantErr: "generator reported: catalog id is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen := Generator{Name: tt.name, Command: writeScript(t, dir, tt.name, tt.body)}
+			_, err := gen.Run(gocontext.Background(), Request{})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := Generator{Name: "missing", Command: filepath.Join(dir, "does-not-exist")}.Run(gocontext.Background(), Request{})
+	assert.ErrorContains(t, err, "generator missing")
+}
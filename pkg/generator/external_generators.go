@@ -0,0 +1,119 @@
+package generator
+
+import (
+	gocontext "context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/junjiewwang/service-template/pkg/generator/external"
+	"github.com/junjiewwang/service-template/pkg/generator/filewriter"
+	"gopkg.in/yaml.v3"
+)
+
+// ExternalGenerators lists the external generators configured in service.yaml (generators:)
+func (g *Generator) ExternalGenerators() []external.Generator {
+	return external.Resolve(g.config.Generators)
+}
+
+// renderExternal runs the external generators in order and returns their files;
+// files may not overwrite built-in outputs or files of another generator
+func (g *Generator) renderExternal(ctx gocontext.Context, builtin []RenderedFile) ([]RenderedFile, error) {
+	generators := g.ExternalGenerators()
+	if len(generators) == 0 {
+		return nil, nil
+	}
+
+	req, err := g.externalRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	owners := make(map[string]string, len(builtin))
+	for _, file := range builtin {
+		owners[filepath.Clean(file.Path)] = "svcgen"
+	}
+
+	var files []RenderedFile
+	for _, gen := range generators {
+		resp, err := gen.Run(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, warning := range resp.Warnings {
+			g.warnings = append(g.warnings, fmt.Sprintf("generator %s: %s", gen.Name, warning))
+		}
+
+		for _, out := range resp.Files {
+			file, err := externalFile(out)
+			if err != nil {
+				return nil, fmt.Errorf("generator %s: %w", gen.Name, err)
+			}
+			if owner, exists := owners[file.Path]; exists {
+				return nil, fmt.Errorf("generator %s: %s is already generated by %s", gen.Name, file.Path, owner)
+			}
+			owners[file.Path] = "generator " + gen.Name
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// externalFile validates a file returned by an external generator
+func externalFile(out external.File) (RenderedFile, error) {
+	path, err := out.CleanPath()
+	if err != nil {
+		return RenderedFile{}, err
+	}
+	mode, err := out.FileMode()
+	if err != nil {
+		return RenderedFile{}, err
+	}
+	if out.Strategy != "" {
+		if _, exists := filewriter.DefaultStrategyRegistry.Get(out.Strategy); !exists {
+			return RenderedFile{}, fmt.Errorf("file %s: unknown write strategy %q", out.Path, out.Strategy)
+		}
+	}
+	return RenderedFile{Path: path, Content: out.Content, Mode: mode, Strategy: out.Strategy}, nil
+}
+
+// externalRequest builds the request sent to every external generator
+func (g *Generator) externalRequest() (external.Request, error) {
+	cfg, err := configMap(g.config)
+	if err != nil {
+		return external.Request{}, err
+	}
+	outputDir, err := filepath.Abs(g.outputDir)
+	if err != nil {
+		return external.Request{}, fmt.Errorf("failed to resolve output directory: %w", err)
+	}
+
+	paths := g.ctx.Paths
+	return external.Request{
+		Config:    cfg,
+		Variables: g.ctx.GetVariableComposer().WithAll().Build(),
+		Paths: external.Paths{
+			OutputDir:          outputDir,
+			ScriptDir:          paths.CI.ScriptDir,
+			BuildConfigDir:     paths.CI.BuildConfigDir,
+			ConfigTemplateDir:  paths.CI.ConfigTemplateDir,
+			ContainerScriptDir: paths.CI.ContainerScriptDir,
+			ServiceRoot:        paths.ServiceRoot,
+			DeployDir:          paths.DeployDir,
+			ConfigDir:          paths.ConfigDir,
+			BinDir:             paths.BinDir,
+		},
+	}, nil
+}
+
+// configMap converts the resolved config to a map with the same keys as service.yaml
+func configMap(cfg interface{}) (map[string]interface{}, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	result := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	configtestutil "github.com/junjiewwang/service-template/pkg/config/testutil"
+	"github.com/junjiewwang/service-template/pkg/generator/external"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeGeneratorScript writes an external generator that saves its request to request.json
+// in dir and prints response
+func writeGeneratorScript(t *testing.T, dir, name, response string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("external generator tests use shell scripts")
+	}
+	path := filepath.Join(dir, name)
+	script := "#!/bin/sh\ncat > \"" + filepath.Join(dir, name+".request.json") + "\"\nprintf '%s\\n' '" + response + "'\n"
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+func newExternalGeneratorConfig(items ...config.ExternalGeneratorConfig) *config.ServiceConfig {
+	cfg := configtestutil.NewConfigBuilder().
+		WithService("test-service", "Test Service").
+		WithPort("http", 8080, "TCP", true).
+		WithLanguage("go").
+		WithBuildCommand("go build -o bin/test-service").
+		WithStartupCommand("./bin/test-service").
+		BuildWithDefaults()
+	cfg.Generators.Items = items
+	return cfg
+}
+
+func TestGenerator_ExternalGenerators(t *testing.T) {
+	dir := t.TempDir()
+	script := writeGeneratorScript(t, dir, "catalog", `{"files": [
+		{"path": "catalog/entry.yaml", "content": "name: test-service\n"},
+		{"path": "catalog/register.sh", "content": "#!/bin/sh\n", "mode": "0700"}
+	], "warnings": ["catalog schema is in beta"]}`)
+
+	cfg := newExternalGeneratorConfig(config.ExternalGeneratorConfig{
+		Name: "catalog", Command: script, Options: map[string]interface{}{"team": "infra"},
+	})
+	outputDir := filepath.Join(t.TempDir(), "output")
+	gen := NewGenerator(cfg, outputDir)
+	require.NoError(t, gen.Generate())
+
+	content, err := os.ReadFile(filepath.Join(outputDir, "catalog", "entry.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "name: test-service\n", string(content))
+
+	info, err := os.Stat(filepath.Join(outputDir, "catalog", "register.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+	assert.Equal(t, []string{"generator catalog: catalog schema is in beta"}, gen.Warnings())
+
+	// The request carries the resolved config with service.yaml keys, variables and paths
+	data, err := os.ReadFile(filepath.Join(dir, "catalog.request.json"))
+	require.NoError(t, err)
+	var req external.Request
+	require.NoError(t, json.Unmarshal(data, &req))
+	assert.Equal(t, "catalog", req.Generator)
+	assert.Equal(t, "infra", req.Options["team"])
+	assert.Equal(t, "test-service", req.Config["service"].(map[string]interface{})["name"])
+	assert.Equal(t, "test-service", req.Variables["SERVICE_NAME"])
+	assert.Equal(t, outputDir, req.Paths.OutputDir)
+	assert.Equal(t, ".tad/build/test-service", req.Paths.ScriptDir)
+}
+
+func TestGenerator_ExternalGenerators_SkipStrategy(t *testing.T) {
+	dir := t.TempDir()
+	script := writeGeneratorScript(t, dir, "seed", `{"files": [{"path": "deploy/values.yaml", "content": "replicas: 1\n", "strategy": "skip"}]}`)
+
+	outputDir := t.TempDir()
+	existing := filepath.Join(outputDir, "deploy", "values.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(existing), 0755))
+	require.NoError(t, os.WriteFile(existing, []byte("replicas: 3\n"), 0644))
+
+	cfg := newExternalGeneratorConfig(config.ExternalGeneratorConfig{Name: "seed", Command: script})
+	require.NoError(t, NewGenerator(cfg, outputDir).Generate())
+
+	content, err := os.ReadFile(existing)
+	require.NoError(t, err)
+	assert.Equal(t, "replicas: 3\n", string(content), "skip strategy keeps the existing file")
+}
+
+func TestGenerator_ExternalGenerators_InvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name     string
+		response string
+		wantErr  string
+	}{
+		{name: "escape", response: `{"files": [{"path": "../outside.yaml", "content": "x"}]}`, wantErr: "leaves the output directory"},
+		{name: "builtin", response: `{"files": [{"path": "compose.yaml", "content": "x"}]}`, wantErr: "compose.yaml is already generated by svcgen"},
+		{name: "strategy", response: `{"files": [{"path": "a.yaml", "content": "x", "strategy": "append"}]}`, wantErr: `unknown write strategy "append"`},
+		{name: "mode", response: `{"files": [{"path": "a.yaml", "content": "x", "mode": "rw"}]}`, wantErr: "invalid mode"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			script := writeGeneratorScript(t, dir, tt.name, tt.response)
+			cfg := newExternalGeneratorConfig(config.ExternalGeneratorConfig{Name: tt.name, Command: script})
+			_, err := NewGenerator(cfg, t.TempDir()).Render()
+			assert.ErrorContains(t, err, "generator "+tt.name)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGenerator_ExternalGenerators_Conflict(t *testing.T) {
+	dir := t.TempDir()
+	response := `{"files": [{"path": "catalog.yaml", "content": "x"}]}`
+	cfg := newExternalGeneratorConfig(
+		config.ExternalGeneratorConfig{Name: "first", Command: writeGeneratorScript(t, dir, "first", response)},
+		config.ExternalGeneratorConfig{Name: "second", Command: writeGeneratorScript(t, dir, "second", response)},
+	)
+
+	_, err := NewGenerator(cfg, t.TempDir()).Render()
+	assert.ErrorContains(t, err, "generator second: catalog.yaml is already generated by generator first")
+}
@@ -2,11 +2,16 @@ package filewriter
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/junjiewwang/service-template/pkg/vfs"
 )
 
 // FileWriter provides a unified interface for file writing operations
 type FileWriter struct {
 	strategy WriteStrategy
+	fsys     vfs.FS
 }
 
 // New creates a new FileWriter with the default strategy (overwrite)
@@ -27,12 +32,31 @@ func New() *FileWriter {
 func (w *FileWriter) WithStrategy(strategy WriteStrategy) *FileWriter {
 	return &FileWriter{
 		strategy: strategy,
+		fsys:     w.fsys,
+	}
+}
+
+// WithFS writes into fsys; paths passed to Write are then slash-separated names in fsys.
+// Without a file system, paths are host paths.
+func (w *FileWriter) WithFS(fsys vfs.FS) *FileWriter {
+	return &FileWriter{
+		strategy: w.strategy,
+		fsys:     fsys,
 	}
 }
 
 // Write writes content to the specified file path
 func (w *FileWriter) Write(ctx context.Context, path string, content []byte) error {
-	return w.strategy.Write(ctx, path, content)
+	if w.fsys != nil {
+		return w.strategy.Write(ctx, w.fsys, path, content)
+	}
+
+	// 未指定文件系统时 path 为宿主机路径，在其所在目录上写入
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	return w.strategy.Write(ctx, vfs.OS(dir), filepath.Base(path), content)
 }
 
 // WriteString writes string content to the specified file path
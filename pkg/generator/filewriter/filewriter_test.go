@@ -8,6 +8,7 @@ import (
 
 	"github.com/junjiewwang/service-template/pkg/generator/filewriter"
 	"github.com/junjiewwang/service-template/pkg/generator/filewriter/strategies"
+	"github.com/junjiewwang/service-template/pkg/vfs"
 )
 
 func TestFileWriter_Overwrite(t *testing.T) {
@@ -240,3 +241,87 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestFileWriter_Block(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, ".gitignore")
+
+	ctx := context.Background()
+	writer := filewriter.New().
+		WithStrategy(filewriter.DefaultStrategyRegistry.MustGet(strategies.BlockStrategyID))
+
+	// First write creates the file with only the block
+	if err := writer.WriteString(ctx, testFile, "# >>> start\nbuild/\n# <<< end"); err != nil {
+		t.Fatalf("Failed to write block: %v", err)
+	}
+	content, err := os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(content) != "# >>> start\nbuild/\n# <<< end\n" {
+		t.Errorf("Unexpected content after create:\n%s", content)
+	}
+
+	// User content outside the block is kept, the block is replaced
+	if err := os.WriteFile(testFile, []byte("*.log\n\n# >>> start\nbuild/\n# <<< end\n\nvendor/\n"), 0644); err != nil {
+		t.Fatalf("Failed to write user content: %v", err)
+	}
+	if err := writer.WriteString(ctx, testFile, "# >>> start\nbuild/\ndist/\n# <<< end"); err != nil {
+		t.Fatalf("Failed to update block: %v", err)
+	}
+	content, err = os.ReadFile(testFile)
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	expected := "*.log\n\n# >>> start\nbuild/\ndist/\n# <<< end\n\nvendor/\n"
+	if string(content) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, content)
+	}
+
+	if err := writer.WriteString(ctx, testFile, "no markers"); err == nil {
+		t.Error("Expected an error for a block without markers")
+	}
+}
+
+func TestFileWriter_WithFS(t *testing.T) {
+	ctx := context.Background()
+	fsys := vfs.NewMem()
+	registry := filewriter.DefaultStrategyRegistry
+
+	// Names are relative to the file system, parent directories are created in it
+	writer := filewriter.New().WithFS(fsys)
+	if err := writer.WriteString(ctx, "build/Dockerfile", "FROM scratch\n"); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	// WithStrategy keeps the file system
+	skipWriter := writer.WithStrategy(registry.MustGet(strategies.SkipStrategyID))
+	if err := skipWriter.WriteString(ctx, "build/Dockerfile", "FROM alpine\n"); err != nil {
+		t.Fatalf("Failed to skip file: %v", err)
+	}
+
+	incrementalWriter := writer.WithStrategy(registry.MustGet(strategies.IncrementalStrategyID))
+	if err := incrementalWriter.WriteString(ctx, "Makefile", "build:\n\tgo build\n"); err != nil {
+		t.Fatalf("Failed to write Makefile: %v", err)
+	}
+
+	content, err := fsys.ReadFile("build/Dockerfile")
+	if err != nil {
+		t.Fatalf("Failed to read file: %v", err)
+	}
+	if string(content) != "FROM scratch\n" {
+		t.Errorf("Expected 'FROM scratch', got '%s'", content)
+	}
+
+	content, err = fsys.ReadFile("Makefile")
+	if err != nil {
+		t.Fatalf("Failed to read Makefile: %v", err)
+	}
+	if !contains(string(content), "# ===== GENERATED_START =====") {
+		t.Errorf("Makefile should have markers, got:\n%s", content)
+	}
+
+	if files := fsys.Files(); len(files) != 2 {
+		t.Errorf("Expected 2 files in memory, got %v", files)
+	}
+}
@@ -0,0 +1,83 @@
+package strategies
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/generator/filewriter"
+	"github.com/junjiewwang/service-template/pkg/vfs"
+)
+
+// BlockStrategyID is the unique identifier for the block strategy
+const BlockStrategyID = "block"
+
+// BlockStrategy owns a single marker block inside a file that is otherwise edited by users
+// (.gitignore, .dockerignore). The content is the whole block: its first line is the start
+// marker and its last line the end marker.
+type BlockStrategy struct{}
+
+func init() {
+	filewriter.DefaultStrategyRegistry.MustRegister(&BlockStrategy{})
+}
+
+// ID returns the strategy identifier
+func (s *BlockStrategy) ID() string {
+	return BlockStrategyID
+}
+
+// Description returns the strategy description
+func (s *BlockStrategy) Description() string {
+	return "Replace or append a marker block, keeping the rest of the file"
+}
+
+// Write replaces the block in the existing file, appends it, or creates the file with only the block
+func (s *BlockStrategy) Write(ctx context.Context, fsys vfs.FS, name string, content []byte) error {
+	block := strings.TrimRight(string(content), "\n")
+	lines := strings.Split(block, "\n")
+	if len(lines) < 2 {
+		return fmt.Errorf("block for %s needs a start and an end marker line", name)
+	}
+	startMarker, endMarker := lines[0], lines[len(lines)-1]
+
+	existing, err := fsys.ReadFile(name)
+	if err != nil {
+		if !vfs.IsNotExist(err) {
+			return err
+		}
+		if err := vfs.MkdirParent(fsys, name); err != nil {
+			return err
+		}
+		return fsys.WriteFile(name, []byte(block+"\n"), 0644)
+	}
+
+	updated := ReplaceOrAppendBlock(string(existing), block, startMarker, endMarker)
+	if updated == string(existing) {
+		return nil
+	}
+	return fsys.WriteFile(name, []byte(updated), 0644)
+}
+
+// ReplaceOrAppendBlock replaces the block between startMarker and endMarker, or appends block
+// after a blank line when the markers are not found
+func ReplaceOrAppendBlock(existing, block, startMarker, endMarker string) string {
+	pattern := fmt.Sprintf(`(?s)%s\n.*?%s`,
+		regexp.QuoteMeta(startMarker),
+		regexp.QuoteMeta(endMarker))
+
+	re := regexp.MustCompile(pattern)
+
+	if re.MatchString(existing) {
+		// 替换已有 block（使用 Func 版本避免 block 中的 $ 被当作分组引用）
+		return re.ReplaceAllStringFunc(existing, func(string) string { return block })
+	}
+
+	// 追加新 block
+	result := strings.TrimRight(existing, "\n")
+	if result != "" {
+		result += "\n\n"
+	}
+	result += block + "\n"
+	return result
+}
@@ -2,11 +2,10 @@ package strategies
 
 import (
 	"context"
-	"os"
-	"path/filepath"
 
 	"github.com/junjiewwang/service-template/pkg/generator/filewriter"
 	"github.com/junjiewwang/service-template/pkg/generator/filewriter/mergers"
+	"github.com/junjiewwang/service-template/pkg/vfs"
 )
 
 // IncrementalStrategyID is the unique identifier for the incremental strategy
@@ -46,10 +45,9 @@ func (s *IncrementalStrategy) Description() string {
 }
 
 // Write writes content to the file using incremental merge
-func (s *IncrementalStrategy) Write(ctx context.Context, path string, content []byte) error {
+func (s *IncrementalStrategy) Write(ctx context.Context, fsys vfs.FS, name string, content []byte) error {
 	// Ensure directory exists
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := vfs.MkdirParent(fsys, name); err != nil {
 		return err
 	}
 
@@ -57,20 +55,20 @@ func (s *IncrementalStrategy) Write(ctx context.Context, path string, content []
 	merger := mergers.DefaultMergerRegistry.MustGet(s.mergerID)
 
 	// Check if file exists
-	existingContent, err := os.ReadFile(path)
+	existingContent, err := fsys.ReadFile(name)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if vfs.IsNotExist(err) {
 			// File doesn't exist, use merger to wrap content with markers
 			// This ensures first-time generation also has markers
 			mergedContent, err := merger.Merge(ctx, &mergers.MergeInput{
 				ExistingContent: []byte{}, // Empty existing content
 				NewContent:      content,
-				FilePath:        path,
+				FilePath:        name,
 			})
 			if err != nil {
 				return err
 			}
-			return os.WriteFile(path, mergedContent, 0644)
+			return fsys.WriteFile(name, mergedContent, 0644)
 		}
 		return err
 	}
@@ -79,12 +77,12 @@ func (s *IncrementalStrategy) Write(ctx context.Context, path string, content []
 	mergedContent, err := merger.Merge(ctx, &mergers.MergeInput{
 		ExistingContent: existingContent,
 		NewContent:      content,
-		FilePath:        path,
+		FilePath:        name,
 	})
 	if err != nil {
 		return err
 	}
 
 	// Write merged content
-	return os.WriteFile(path, mergedContent, 0644)
+	return fsys.WriteFile(name, mergedContent, 0644)
 }
@@ -2,10 +2,9 @@ package strategies
 
 import (
 	"context"
-	"os"
-	"path/filepath"
 
 	"github.com/junjiewwang/service-template/pkg/generator/filewriter"
+	"github.com/junjiewwang/service-template/pkg/vfs"
 )
 
 // OverwriteStrategyID is the unique identifier for the overwrite strategy
@@ -29,11 +28,10 @@ func (s *OverwriteStrategy) Description() string {
 }
 
 // Write writes content to the file, overwriting if it exists
-func (s *OverwriteStrategy) Write(ctx context.Context, path string, content []byte) error {
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+func (s *OverwriteStrategy) Write(ctx context.Context, fsys vfs.FS, name string, content []byte) error {
+	if err := vfs.MkdirParent(fsys, name); err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, content, 0644)
+	return fsys.WriteFile(name, content, 0644)
 }
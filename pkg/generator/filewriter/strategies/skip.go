@@ -2,10 +2,9 @@ package strategies
 
 import (
 	"context"
-	"os"
-	"path/filepath"
 
 	"github.com/junjiewwang/service-template/pkg/generator/filewriter"
+	"github.com/junjiewwang/service-template/pkg/vfs"
 )
 
 // SkipStrategyID is the unique identifier for the skip strategy
@@ -29,18 +28,17 @@ func (s *SkipStrategy) Description() string {
 }
 
 // Write writes content to the file only if it doesn't exist
-func (s *SkipStrategy) Write(ctx context.Context, path string, content []byte) error {
+func (s *SkipStrategy) Write(ctx context.Context, fsys vfs.FS, name string, content []byte) error {
 	// Check if file exists
-	if _, err := os.Stat(path); err == nil {
+	if vfs.Exists(fsys, name) {
 		// File exists, skip writing
 		return nil
 	}
 
 	// File doesn't exist, create it
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
+	if err := vfs.MkdirParent(fsys, name); err != nil {
 		return err
 	}
 
-	return os.WriteFile(path, content, 0644)
+	return fsys.WriteFile(name, content, 0644)
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sync"
+
+	"github.com/junjiewwang/service-template/pkg/vfs"
 )
 
 // WriteStrategy defines the interface for file writing strategies
@@ -14,8 +16,9 @@ type WriteStrategy interface {
 	// Description returns a human-readable description of the strategy
 	Description() string
 
-	// Write executes the file writing operation
-	Write(ctx context.Context, path string, content []byte) error
+	// Write executes the file writing operation on the named file of fsys
+	// (a slash-separated name, see io/fs.ValidPath)
+	Write(ctx context.Context, fsys vfs.FS, name string, content []byte) error
 }
 
 // StrategyRegistry manages the registration and retrieval of write strategies
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
 
 	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
 	"github.com/junjiewwang/service-template/pkg/generator/core"
 	"github.com/junjiewwang/service-template/pkg/generator/filewriter"
 	"github.com/junjiewwang/service-template/pkg/generator/filewriter/strategies"
-	"github.com/junjiewwang/service-template/pkg/utils"
+	"github.com/junjiewwang/service-template/pkg/vfs"
+	"golang.org/x/sync/errgroup"
 
 	// Import all generators to register them
 	_ "github.com/junjiewwang/service-template/pkg/generator/generators/build_tools/makefile"
@@ -21,6 +24,7 @@ import (
 	_ "github.com/junjiewwang/service-template/pkg/generator/generators/k8s/service"
 	_ "github.com/junjiewwang/service-template/pkg/generator/generators/scripts/build"
 	_ "github.com/junjiewwang/service-template/pkg/generator/generators/scripts/build_plugins"
+	_ "github.com/junjiewwang/service-template/pkg/generator/generators/scripts/check"
 	_ "github.com/junjiewwang/service-template/pkg/generator/generators/scripts/deps_install"
 	_ "github.com/junjiewwang/service-template/pkg/generator/generators/scripts/entrypoint"
 	_ "github.com/junjiewwang/service-template/pkg/generator/generators/scripts/healthcheck"
@@ -32,6 +36,10 @@ type Generator struct {
 	config    *config.ServiceConfig
 	ctx       *context.GeneratorContext
 	outputDir string
+	// concurrency limits the number of files rendered in parallel
+	concurrency int
+	// warnings collected while rendering (e.g. from external generators)
+	warnings []string
 }
 
 // NewGenerator creates a new generator instance
@@ -39,247 +47,317 @@ func NewGenerator(cfg *config.ServiceConfig, outputDir string) *Generator {
 	ctx := context.NewGeneratorContext(cfg, outputDir)
 
 	return &Generator{
-		config:    cfg,
-		ctx:       ctx,
-		outputDir: outputDir,
+		config:      cfg,
+		ctx:         ctx,
+		outputDir:   outputDir,
+		concurrency: runtime.GOMAXPROCS(0),
 	}
 }
 
-// Generate generates all project files
-func (g *Generator) Generate() error {
-	// Create output directory
-	if err := os.MkdirAll(g.outputDir, 0755); err != nil {
-		return fmt.Errorf("failed to create output directory: %w", err)
+// WithConcurrency limits the number of files rendered in parallel (1 renders sequentially)
+func (g *Generator) WithConcurrency(n int) *Generator {
+	if n < 1 {
+		n = 1
 	}
+	g.concurrency = n
+	return g
+}
 
-	// Generate Dockerfiles
-	if err := g.generateDockerfiles(); err != nil {
-		return fmt.Errorf("failed to generate Dockerfiles: %w", err)
-	}
+// WithFS renders against and writes into fsys instead of the host output directory,
+// e.g. vfs.NewMem() for hermetic runs or vfs.Overlay(vfs.NewMem(), vfs.OS(dir)) for a dry run.
+// The output directory is still passed to external generators.
+func (g *Generator) WithFS(fsys vfs.FS) *Generator {
+	g.ctx.FS = fsys
+	return g
+}
+
+// RenderedFile is a generated file rendered in memory
+type RenderedFile struct {
+	// Path is relative to the output directory
+	Path    string
+	Content string
+	// Incremental files keep user content outside the generated block (Makefile)
+	Incremental bool
+	// Mode is the file permission; 0 uses 0755 for shell scripts and 0644 otherwise
+	Mode os.FileMode
+	// Strategy is a filewriter strategy ID; empty overwrites the file
+	Strategy string
+}
 
-	// Generate Docker Compose
-	if err := g.generateCompose(); err != nil {
-		return fmt.Errorf("failed to generate compose.yaml: %w", err)
+// FileMode returns the permission the file is written with
+func (f RenderedFile) FileMode() os.FileMode {
+	switch {
+	case f.Mode != 0:
+		return f.Mode
+	case filepath.Ext(f.Path) == ".sh":
+		return 0755
+	default:
+		return 0644
 	}
+}
 
-	// Generate Makefile
-	if err := g.generateMakefile(); err != nil {
-		return fmt.Errorf("failed to generate Makefile: %w", err)
+// WriteStrategy returns the filewriter strategy ID the file is written with
+func (f RenderedFile) WriteStrategy() string {
+	switch {
+	case f.Incremental:
+		return strategies.IncrementalStrategyID
+	case f.Strategy != "":
+		return f.Strategy
+	default:
+		return strategies.OverwriteStrategyID
 	}
+}
+
+// WithStrictTemplates makes templates fail on references to missing variables
+// instead of rendering "<no value>"
+func (g *Generator) WithStrictTemplates(strict bool) *Generator {
+	g.ctx.StrictTemplates = strict
+	return g
+}
 
-	// Generate build scripts
-	if err := g.generateScripts(); err != nil {
-		return fmt.Errorf("failed to generate scripts: %w", err)
+// Generate generates all project files
+func (g *Generator) Generate() error {
+	return g.GenerateContext(gocontext.Background())
+}
+
+// GenerateContext generates all project files; rendering stops early when ctx is cancelled.
+// Nothing is printed: Warnings returns the warnings collected while rendering
+func (g *Generator) GenerateContext(ctx gocontext.Context) error {
+	files, err := g.RenderContext(ctx)
+	if err != nil {
+		return err
 	}
+	return WriteFiles(ctx, g.ctx.FS, files)
+}
 
-	// Generate DevOps configuration
-	if err := g.generateDevOps(); err != nil {
-		return fmt.Errorf("failed to generate DevOps configuration: %w", err)
+// Render renders all generated files in memory without touching the output directory
+func (g *Generator) Render() ([]RenderedFile, error) {
+	return g.RenderContext(gocontext.Background())
+}
+
+// RenderContext renders all generated files concurrently; files are returned in a fixed order
+// and the error of the first failing file (in that order) is reported.
+// Files of external generators (generators:) follow the built-in files
+func (g *Generator) RenderContext(ctx gocontext.Context) ([]RenderedFile, error) {
+	if err := g.ValidateUserVariables(); err != nil {
+		return nil, err
+	}
+	g.warnings = nil
+
+	tasks := g.renderTasks()
+	contents := make([]string, len(tasks))
+	errs := make([]error, len(tasks))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(g.concurrency)
+	for i, task := range tasks {
+		group.Go(func() error {
+			if err := groupCtx.Err(); err != nil {
+				return err
+			}
+			contents[i], errs[i] = task.render()
+			return errs[i]
+		})
+	}
+	if err := group.Wait(); err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
 	}
 
-	// Generate Kubernetes Service manifest
-	if err := g.generateK8sService(); err != nil {
-		return fmt.Errorf("failed to generate K8s Service manifest: %w", err)
+	var files []RenderedFile
+	for i, task := range tasks {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", task.group, errs[i])
+		}
+		// Skip if generator returned empty content (e.g., healthcheck disabled or no plugins)
+		if contents[i] == "" {
+			continue
+		}
+		file := task.file
+		file.Content = contents[i]
+		files = append(files, file)
 	}
 
-	// Update .gitignore to ignore generated files (only when manage_gitignore is enabled)
+	// .dockerignore keeps the build context small and cache-friendly;
+	// .gitignore ignores generated files (only when manage_gitignore is enabled)
+	files = append(files, g.dockerignoreFile())
 	if g.config.Metadata.ManageGitignore {
-		if err := g.updateGitignore(); err != nil {
-			return fmt.Errorf("failed to update .gitignore: %w", err)
-		}
+		files = append(files, g.gitignoreFile())
 	}
 
-	fmt.Println("✓ Project generated successfully!")
-	return nil
+	externalFiles, err := g.renderExternal(ctx, files)
+	if err != nil {
+		return nil, err
+	}
+	return append(files, externalFiles...), nil
 }
 
-// generateDockerfiles generates Dockerfiles for different architectures
-func (g *Generator) generateDockerfiles() error {
-	architectures := []string{"amd64", "arm64"}
+// Warnings returns the warnings of the last Render or Generate
+func (g *Generator) Warnings() []string {
+	return g.warnings
+}
 
-	// Create CI script directory using CIPaths (supports custom script_dir)
-	dockerfileDir := filepath.Join(g.outputDir, g.ctx.Paths.CI.ScriptDir)
-	if err := os.MkdirAll(dockerfileDir, 0755); err != nil {
-		return fmt.Errorf("failed to create dockerfile directory: %w", err)
+// WriteFiles writes rendered files into fsys (the output directory, see vfs.OS) with their
+// write strategies and modes
+func WriteFiles(ctx gocontext.Context, fsys vfs.FS, files []RenderedFile) error {
+	if err := fsys.MkdirAll(".", 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
 	}
-
-	for _, arch := range architectures {
-		// Create generator using new registry
-		creator, exists := core.DefaultRegistry.Get("dockerfile")
-		if !exists {
-			return fmt.Errorf("generator type dockerfile not found")
-		}
-
-		generator, err := creator(g.ctx, arch)
-		if err != nil {
-			return fmt.Errorf("failed to create dockerfile generator for %s: %w", arch, err)
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return err
 		}
-
-		content, err := generator.Generate()
-		if err != nil {
-			return fmt.Errorf("failed to generate Dockerfile for %s: %w", arch, err)
-		}
-
-		// Generate Dockerfile with format: Dockerfile.{service-name}.{arch}
-		filename := fmt.Sprintf("Dockerfile.%s.%s", g.config.Service.Name, arch)
-		outputPath := filepath.Join(dockerfileDir, filename)
-		if err := utils.WriteFile(outputPath, content); err != nil {
-			return fmt.Errorf("failed to write %s: %w", filename, err)
+		if err := writeFile(ctx, fsys, file); err != nil {
+			return fmt.Errorf("failed to write %s: %w", file.Path, err)
 		}
-
-		fmt.Printf("✓ Generated %s/%s\n", g.ctx.Paths.CI.ScriptDir, filename)
 	}
-
 	return nil
 }
 
-// generateCompose generates docker-compose.yaml
-func (g *Generator) generateCompose() error {
-	generator, err := g.createGenerator("compose")
-	if err != nil {
-		return fmt.Errorf("failed to create compose generator: %w", err)
+// writeFile writes a rendered file into the output directory
+func writeFile(ctx gocontext.Context, fsys vfs.FS, file RenderedFile) error {
+	name := filepath.ToSlash(file.Path)
+	writer := filewriter.New().WithFS(fsys)
+	strategyID := file.WriteStrategy()
+	strategy, exists := filewriter.DefaultStrategyRegistry.Get(strategyID)
+	if !exists {
+		return fmt.Errorf("unknown write strategy %s", strategyID)
 	}
 
-	content, err := generator.Generate()
-	if err != nil {
-		return err
+	switch strategyID {
+	case strategies.IncrementalStrategyID, strategies.BlockStrategyID:
+		// Files shared with the user (Makefile, .gitignore) keep their mode
+		return writer.WithStrategy(strategy).WriteString(ctx, name, file.Content)
+	case strategies.SkipStrategyID:
+		if vfs.Exists(fsys, name) {
+			return nil
+		}
 	}
 
-	outputPath := filepath.Join(g.outputDir, "compose.yaml")
-	if err := utils.WriteFile(outputPath, content); err != nil {
+	if err := writer.WithStrategy(strategy).WriteString(ctx, name, file.Content); err != nil {
 		return err
 	}
-
-	fmt.Println("✓ Generated compose.yaml")
+	if err := fsys.Chmod(name, file.FileMode()); err != nil {
+		return fmt.Errorf("failed to set file mode: %w", err)
+	}
 	return nil
 }
 
-// generateMakefile generates Makefile using incremental update strategy
-func (g *Generator) generateMakefile() error {
-	generator, err := g.createGenerator("makefile")
-	if err != nil {
-		return fmt.Errorf("failed to create makefile generator: %w", err)
-	}
-
-	content, err := generator.Generate()
-	if err != nil {
-		return err
-	}
+// renderTask renders a single file; tasks are independent of each other
+type renderTask struct {
+	// group names the kind of file in error messages
+	group  string
+	file   RenderedFile
+	render func() (string, error)
+}
 
-	outputPath := filepath.Join(g.outputDir, "Makefile")
+// renderTasks lists all generated files in output order
+func (g *Generator) renderTasks() []renderTask {
+	var tasks []renderTask
+	tasks = append(tasks, g.dockerfileTasks()...)
+	tasks = append(tasks,
+		g.singleTask("compose.yaml", "compose", RenderedFile{Path: "compose.yaml"}),
+		g.makefileTask(),
+	)
+	tasks = append(tasks, g.scriptTasks()...)
+	tasks = append(tasks,
+		g.singleTask("DevOps configuration", "devops", RenderedFile{Path: filepath.Join(".tad", "devops.yaml")}),
+		g.singleTask("K8s Service manifest", "k8s-service", RenderedFile{Path: filepath.Join(".tad", "k8s-service.yaml")}),
+	)
+	return tasks
+}
 
-	// Use FileWriter with incremental strategy for Makefile
-	// This allows users to add custom targets outside the generated block
-	ctx := gocontext.Background()
-	writer := filewriter.New().
-		WithStrategy(filewriter.DefaultStrategyRegistry.MustGet(strategies.IncrementalStrategyID))
+// dockerfileTasks renders Dockerfiles for different architectures
+func (g *Generator) dockerfileTasks() []renderTask {
+	architectures := []string{"amd64", "arm64"}
 
-	if err := writer.WriteString(ctx, outputPath, content); err != nil {
-		return err
+	var tasks []renderTask
+	for _, arch := range architectures {
+		// Dockerfile with format: Dockerfile.{service-name}.{arch} in the CI script directory (supports custom script_dir)
+		filename := fmt.Sprintf("Dockerfile.%s.%s", g.config.Service.Name, arch)
+		tasks = append(tasks, renderTask{
+			group: "Dockerfiles",
+			file:  RenderedFile{Path: filepath.Join(g.ctx.Paths.CI.ScriptDir, filename)},
+			render: func() (string, error) {
+				// Create generator using new registry
+				creator, exists := core.DefaultRegistry.Get("dockerfile")
+				if !exists {
+					return "", fmt.Errorf("generator type dockerfile not found")
+				}
+
+				generator, err := creator(g.ctx, arch)
+				if err != nil {
+					return "", fmt.Errorf("failed to create dockerfile generator for %s: %w", arch, err)
+				}
+
+				content, err := generator.Generate()
+				if err != nil {
+					return "", fmt.Errorf("failed to generate Dockerfile for %s: %w", arch, err)
+				}
+				return content, nil
+			},
+		})
 	}
+	return tasks
+}
 
-	fmt.Println("✓ Generated Makefile (incremental update)")
-	return nil
+// makefileTask renders the Makefile, which is written with the incremental update strategy
+func (g *Generator) makefileTask() renderTask {
+	return g.singleTask("Makefile", "makefile", RenderedFile{Path: "Makefile", Incremental: true})
 }
 
-// generateScripts generates build and deployment scripts
-func (g *Generator) generateScripts() error {
+// scriptTasks renders build and deployment scripts
+func (g *Generator) scriptTasks() []renderTask {
 	// Use CIPaths to get all script paths
 	scripts := g.ctx.Paths.CI.GetAllScriptPaths()
 
-	for generatorType, scriptPath := range scripts {
-		// Skip build_plugins.sh if no plugins configured
-		if generatorType == "build-plugins-script" && len(g.config.Plugins.Items) == 0 {
-			continue
-		}
-
-		generator, err := g.createGenerator(generatorType)
-		if err != nil {
-			return fmt.Errorf("failed to create %s generator: %w", generatorType, err)
-		}
+	generatorTypes := make([]string, 0, len(scripts))
+	for generatorType := range scripts {
+		generatorTypes = append(generatorTypes, generatorType)
+	}
+	sort.Strings(generatorTypes)
 
-		content, err := generator.Generate()
-		if err != nil {
-			return fmt.Errorf("failed to generate %s: %w", scriptPath, err)
-		}
+	var tasks []renderTask
+	for _, generatorType := range generatorTypes {
+		scriptPath := scripts[generatorType]
 
-		// Skip if generator returned empty content (e.g., healthcheck disabled or no plugins)
-		if content == "" {
+		// Skip build_plugins.sh if no plugins configured
+		if generatorType == "build-plugins-script" && len(g.config.Plugins.Items) == 0 {
 			continue
 		}
 
-		outputPath := filepath.Join(g.outputDir, scriptPath)
-		if err := os.MkdirAll(filepath.Dir(outputPath), 0755); err != nil {
-			return fmt.Errorf("failed to create directory for %s: %w", scriptPath, err)
-		}
-
-		if err := utils.WriteFile(outputPath, content); err != nil {
-			return fmt.Errorf("failed to write %s: %w", scriptPath, err)
-		}
-
-		// Make shell scripts executable
-		if filepath.Ext(scriptPath) == ".sh" {
-			if err := os.Chmod(outputPath, 0755); err != nil {
-				return fmt.Errorf("failed to make %s executable: %w", scriptPath, err)
-			}
-		}
-
-		fmt.Printf("✓ Generated %s\n", scriptPath)
+		tasks = append(tasks, renderTask{
+			group: "scripts",
+			file:  RenderedFile{Path: scriptPath},
+			render: func() (string, error) {
+				generator, err := g.createGenerator(generatorType)
+				if err != nil {
+					return "", fmt.Errorf("failed to create %s generator: %w", generatorType, err)
+				}
+
+				content, err := generator.Generate()
+				if err != nil {
+					return "", fmt.Errorf("failed to generate %s: %w", scriptPath, err)
+				}
+				return content, nil
+			},
+		})
 	}
-
-	return nil
+	return tasks
 }
 
-// generateDevOps generates DevOps configuration
-func (g *Generator) generateDevOps() error {
-	generator, err := g.createGenerator("devops")
-	if err != nil {
-		return fmt.Errorf("failed to create devops generator: %w", err)
-	}
-
-	content, err := generator.Generate()
-	if err != nil {
-		return err
-	}
-
-	tadDir := filepath.Join(g.outputDir, ".tad")
-	if err := os.MkdirAll(tadDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .tad directory: %w", err)
-	}
-
-	outputPath := filepath.Join(tadDir, "devops.yaml")
-	if err := utils.WriteFile(outputPath, content); err != nil {
-		return err
-	}
-
-	fmt.Println("✓ Generated .tad/devops.yaml")
-	return nil
-}
-
-// generateK8sService generates Kubernetes Service manifest
-func (g *Generator) generateK8sService() error {
-	generator, err := g.createGenerator("k8s-service")
-	if err != nil {
-		return fmt.Errorf("failed to create k8s-service generator: %w", err)
-	}
-
-	content, err := generator.Generate()
-	if err != nil {
-		return err
-	}
-
-	tadDir := filepath.Join(g.outputDir, ".tad")
-	if err := os.MkdirAll(tadDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .tad directory: %w", err)
-	}
-
-	outputPath := filepath.Join(tadDir, "k8s-service.yaml")
-	if err := utils.WriteFile(outputPath, content); err != nil {
-		return err
+// singleTask renders a generator that produces exactly one file
+func (g *Generator) singleTask(group, generatorType string, file RenderedFile) renderTask {
+	return renderTask{
+		group: group,
+		file:  file,
+		render: func() (string, error) {
+			generator, err := g.createGenerator(generatorType)
+			if err != nil {
+				return "", fmt.Errorf("failed to create %s generator: %w", generatorType, err)
+			}
+			return generator.Generate()
+		},
 	}
-
-	fmt.Println("✓ Generated .tad/k8s-service.yaml")
-	return nil
 }
 
 // createGenerator creates a generator using the new registry
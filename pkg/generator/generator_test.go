@@ -1,17 +1,37 @@
 package generator
 
 import (
+	gocontext "context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/junjiewwang/service-template/pkg/config"
 	configtestutil "github.com/junjiewwang/service-template/pkg/config/testutil"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
+	"github.com/junjiewwang/service-template/pkg/generator/core"
+	"github.com/junjiewwang/service-template/pkg/vfs"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// writeRenderedFile renders all files and writes the one at path into the output directory,
+// the same way Generate writes it
+func writeRenderedFile(gen *Generator, path string) error {
+	files, err := gen.Render()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if filepath.ToSlash(file.Path) == path {
+			return WriteFiles(gocontext.Background(), vfs.OS(gen.outputDir), []RenderedFile{file})
+		}
+	}
+	return fmt.Errorf("%s was not rendered", path)
+}
+
 func TestGenerator_Generate(t *testing.T) {
 	// Arrange: Create temp directory for test
 	tmpDir, err := os.MkdirTemp("", "generator-test-*")
@@ -63,6 +83,7 @@ func TestGenerator_Generate(t *testing.T) {
 		".tad/devops.yaml",
 		".tad/k8s-service.yaml",
 		".gitignore",
+		".dockerignore",
 	}
 
 	// List all generated files for debugging
@@ -278,3 +299,193 @@ func TestGenerator_GenerateWithKubernetesConfig(t *testing.T) {
 
 	t.Logf("✓ Verified Makefile created with Kubernetes targets")
 }
+
+func TestGenerator_RenderStrict(t *testing.T) {
+	cfg := configtestutil.NewConfigBuilder().
+		WithService("test-service", "Test Service").
+		WithPort("http", 8080, "TCP", true).
+		WithLanguage("go").
+		WithBuildCommand("go build -o bin/test-service").
+		WithStartupCommand("./bin/test-service").
+		WithCustomHealthcheck("curl -f localhost:8080").
+		BuildWithDefaults()
+
+	outputDir := filepath.Join(t.TempDir(), "output")
+	files, err := NewGenerator(cfg, outputDir).WithStrictTemplates(true).Render()
+	require.NoError(t, err, "all templates should render in strict mode")
+
+	paths := make(map[string]RenderedFile, len(files))
+	for _, file := range files {
+		paths[file.Path] = file
+	}
+	ciPaths := context.NewCIPaths(cfg)
+	assert.Contains(t, paths, filepath.Join(ciPaths.ScriptDir, "Dockerfile.test-service.amd64"))
+	assert.Contains(t, paths, "compose.yaml")
+	assert.Contains(t, paths, ciPaths.GetScriptPath(ciPaths.HealthcheckScript))
+	assert.True(t, paths["Makefile"].Incremental)
+	assert.NotContains(t, paths, ciPaths.GetScriptPath(ciPaths.BuildPluginsScript), "build_plugins.sh is skipped without plugins")
+
+	_, err = os.Stat(outputDir)
+	assert.True(t, os.IsNotExist(err), "Render() should not write files")
+}
+
+func newBenchmarkConfig() *config.ServiceConfig {
+	return configtestutil.NewConfigBuilder().
+		WithService("test-service", "Test Service").
+		WithPort("http", 8080, "TCP", true).
+		WithLanguage("go").
+		WithBuildCommand("go build -o bin/test-service").
+		WithStartupCommand("./bin/test-service").
+		WithPluginInstallDir("/opt/plugins").
+		WithPlugin(config.PluginConfig{
+			Name:        "test-plugin",
+			DownloadURL: config.NewStaticDownloadURL("https://example.com/plugin.tar.gz"),
+		}).
+		BuildWithDefaults()
+}
+
+func TestGenerator_RenderConcurrent(t *testing.T) {
+	cfg := newBenchmarkConfig()
+
+	sequential, err := NewGenerator(cfg, t.TempDir()).WithConcurrency(1).Render()
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		parallel, err := NewGenerator(cfg, t.TempDir()).WithConcurrency(8).Render()
+		require.NoError(t, err)
+		assert.Equal(t, sequential, parallel, "parallel rendering should produce the same files in the same order")
+	}
+
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	cancel()
+	_, err = NewGenerator(cfg, t.TempDir()).RenderContext(ctx)
+	assert.ErrorIs(t, err, gocontext.Canceled)
+}
+
+func BenchmarkGenerator_Render(b *testing.B) {
+	cfg := newBenchmarkConfig()
+
+	b.Run("sequential-uncached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			core.ResetTemplateCache()
+			if _, err := NewGenerator(cfg, b.TempDir()).WithConcurrency(1).Render(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("sequential-cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := NewGenerator(cfg, b.TempDir()).WithConcurrency(1).Render(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("parallel-cached", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := NewGenerator(cfg, b.TempDir()).Render(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestRenderedFile_ModeAndStrategy(t *testing.T) {
+	assert.Equal(t, os.FileMode(0644), RenderedFile{Path: "compose.yaml"}.FileMode())
+	assert.Equal(t, os.FileMode(0755), RenderedFile{Path: "build.sh"}.FileMode())
+	assert.Equal(t, os.FileMode(0700), RenderedFile{Path: "build.sh", Mode: 0700}.FileMode())
+
+	assert.Equal(t, "overwrite", RenderedFile{Path: "compose.yaml"}.WriteStrategy())
+	assert.Equal(t, "incremental", RenderedFile{Path: "Makefile", Incremental: true}.WriteStrategy())
+	assert.Equal(t, "skip", RenderedFile{Path: "values.yaml", Strategy: "skip"}.WriteStrategy())
+}
+
+func TestGenerator_RenderManagedFiles(t *testing.T) {
+	cfg := newBenchmarkConfig()
+	files, err := NewGenerator(cfg, t.TempDir()).Render()
+	require.NoError(t, err)
+
+	paths := make(map[string]RenderedFile, len(files))
+	for _, file := range files {
+		paths[file.Path] = file
+	}
+	require.Contains(t, paths, ".dockerignore")
+	assert.Equal(t, "block", paths[".dockerignore"].WriteStrategy())
+	assert.NotContains(t, paths, ".gitignore", ".gitignore is only managed with manage_gitignore")
+
+	cfg.Metadata.ManageGitignore = true
+	files, err = NewGenerator(cfg, t.TempDir()).Render()
+	require.NoError(t, err)
+	assert.Equal(t, ".gitignore", files[len(files)-1].Path)
+}
+
+func TestWriteFiles(t *testing.T) {
+	dir := t.TempDir()
+	keep := filepath.Join(dir, "keep.yaml")
+	require.NoError(t, os.WriteFile(keep, []byte("user\n"), 0600))
+
+	files := []RenderedFile{
+		{Path: filepath.Join("scripts", "run.sh"), Content: "#!/bin/sh\n"},
+		{Path: "secret.yaml", Content: "token: x\n", Mode: 0600},
+		{Path: "keep.yaml", Content: "generated\n", Strategy: "skip"},
+	}
+	require.NoError(t, WriteFiles(gocontext.Background(), vfs.OS(dir), files))
+
+	info, err := os.Stat(filepath.Join(dir, "scripts", "run.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	info, err = os.Stat(filepath.Join(dir, "secret.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	content, err := os.ReadFile(keep)
+	require.NoError(t, err)
+	assert.Equal(t, "user\n", string(content), "skip strategy keeps existing files")
+
+	err = WriteFiles(gocontext.Background(), vfs.OS(dir), []RenderedFile{{Path: "a.yaml", Strategy: "append"}})
+	assert.ErrorContains(t, err, "unknown write strategy append")
+}
+
+func TestGenerator_GenerateWithFS(t *testing.T) {
+	cfg := newBenchmarkConfig()
+	cfg.Build.DependencyFiles.AutoDetect = true
+	cfg.Metadata.ManageGitignore = true
+
+	// 项目快照：依赖文件探测与写入都只经过 vfs，不访问输出目录
+	dir := t.TempDir()
+	mem := vfs.NewMem()
+	require.NoError(t, mem.WriteFile("go.mod", []byte("module example.com/app\n"), 0644))
+	require.NoError(t, NewGenerator(cfg, dir).WithFS(mem).Generate())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "nothing is written to the output directory")
+
+	dockerfile, err := mem.ReadFile(".tad/build/test-service/Dockerfile.test-service.amd64")
+	require.NoError(t, err)
+	assert.Contains(t, string(dockerfile), "COPY go.mod ./")
+	assert.NotContains(t, string(dockerfile), "COPY go.sum ./", "go.sum is not in the snapshot")
+
+	scripts := 0
+	for _, name := range mem.Files() {
+		if strings.HasSuffix(name, ".sh") {
+			info, err := mem.Stat(name)
+			require.NoError(t, err)
+			assert.Equal(t, os.FileMode(0755), info.Mode().Perm(), name)
+			scripts++
+		}
+	}
+	assert.NotZero(t, scripts)
+
+	// Overlay：读取输出目录中的用户文件，写入只落在内存中
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0644))
+	upper := vfs.NewMem()
+	require.NoError(t, NewGenerator(cfg, dir).WithFS(vfs.Overlay(upper, vfs.OS(dir))).Generate())
+
+	gitignore, err := upper.ReadFile(".gitignore")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(gitignore), "*.log\n\n"), "user entries are kept: %s", gitignore)
+
+	onDisk, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "*.log\n", string(onDisk))
+}
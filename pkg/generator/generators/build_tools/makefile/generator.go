@@ -3,6 +3,7 @@ package makefile
 import (
 	_ "embed"
 
+	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
 	"github.com/junjiewwang/service-template/pkg/generator/core"
 )
@@ -34,7 +35,7 @@ func (g *Generator) Generate() (string, error) {
 	}
 
 	vars := g.prepareTemplateVars()
-	return g.RenderTemplate(template, vars)
+	return g.RenderTemplateFile(templatePath, vars)
 }
 
 // prepareTemplateVars prepares variables for Makefile template
@@ -52,10 +53,29 @@ func (g *Generator) prepareTemplateVars() map[string]interface{} {
 		WithCustom("K8S_WAIT_ENABLED", ctx.Config.LocalDev.Kubernetes.Wait.Enabled).
 		WithCustom("K8S_WAIT_TIMEOUT", ctx.Config.LocalDev.Kubernetes.Wait.Timeout).
 		WithCustom("K8S_VOLUME_TYPE", ctx.Config.LocalDev.Kubernetes.VolumeType).
-		WithCustom("CUSTOM_TARGETS", ctx.Config.Makefile.CustomTargets)
+		WithCustom("CUSTOM_TARGETS", expandCustomTargets(ctx)).
+		WithCustom("HAS_TEST_STAGE", config.ResolveTestCommand(ctx.Config) != "").
+		WithCustom("HAS_LINT_STAGE", config.ResolveLintCommand(ctx.Config) != "")
 
 	return composer.Build()
 }
 
+// expandCustomTargets expands user-defined variables (vars:) in custom target commands;
+// other ${VAR} references and $$ escapes are left to make
+func expandCustomTargets(ctx *context.GeneratorContext) []config.CustomTarget {
+	targets := make([]config.CustomTarget, len(ctx.Config.Makefile.CustomTargets))
+	for i, target := range ctx.Config.Makefile.CustomTargets {
+		commands := make([]string, len(target.Commands))
+		for j, command := range target.Commands {
+			commands[j] = ctx.VariablePool.ExpandUserVariables(command)
+		}
+		targets[i] = config.CustomTarget{Name: target.Name, Description: target.Description, Commands: commands}
+	}
+	return targets
+}
+
 //go:embed templates/makefile.tmpl
 var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("build_tools/makefile/makefile.tmpl", template)
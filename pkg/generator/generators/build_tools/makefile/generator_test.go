@@ -6,6 +6,8 @@ import (
 
 	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
+	// registers the language strategies' default test / lint commands
+	_ "github.com/junjiewwang/service-template/pkg/generator/domain/services/languageservice"
 	"github.com/junjiewwang/service-template/pkg/generator/internal/testutil"
 )
 
@@ -45,6 +47,12 @@ func TestGenerator_Generate(t *testing.T) {
 	if !strings.Contains(content, "custom-test:") {
 		t.Error("Expected custom target not found")
 	}
+	if !strings.Contains(content, "VCS_REF ?= $(shell git rev-parse HEAD") {
+		t.Error("Expected VCS_REF from git rev-parse not found")
+	}
+	if !strings.Contains(content, "VERSION ?= $(shell git describe") {
+		t.Error("Expected VERSION from git describe not found")
+	}
 }
 
 func TestGenerator_GetName(t *testing.T) {
@@ -66,3 +74,54 @@ func TestGenerator_Validate(t *testing.T) {
 		t.Errorf("Validation failed: %v", err)
 	}
 }
+
+func TestGenerator_Generate_DockerCheckTargets(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if !strings.Contains(content, "docker-test: .env.make") {
+		t.Error("Expected docker-test target not found")
+	}
+	if !strings.Contains(content, "$(DOCKER_TARGET_BUILD) --target test .") {
+		t.Error("Expected docker-test to build with --target test")
+	}
+	if !strings.Contains(content, "docker-lint: .env.make") {
+		t.Error("Expected docker-lint target not found (go has a default lint command)")
+	}
+	if !strings.Contains(content, "-f .tad/build/test-service/$(DOCKERFILE)") {
+		t.Error("Expected docker build to use the generated Dockerfile")
+	}
+}
+
+func TestGenerator_Generate_NoLintTargetWithoutCommand(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Language.Type = "python"
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if !strings.Contains(content, "docker-test: .env.make") {
+		t.Error("Expected docker-test target for python (pytest default)")
+	}
+	if strings.Contains(content, "docker-lint: .env.make") {
+		t.Error("docker-lint should not be rendered when no lint command is available")
+	}
+}
@@ -2,8 +2,10 @@ package compose
 
 import (
 	_ "embed"
+	"strconv"
 	"strings"
 
+	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
 	"github.com/junjiewwang/service-template/pkg/generator/core"
 )
@@ -35,7 +37,7 @@ func (g *Generator) Generate() (string, error) {
 	}
 
 	vars := g.prepareTemplateVars()
-	return g.RenderTemplate(template, vars)
+	return g.RenderTemplateFile(templatePath, vars)
 }
 
 // prepareTemplateVars prepares variables for compose template
@@ -71,8 +73,9 @@ func (g *Generator) prepareTemplateVars() map[string]interface{} {
 		variableMap := composer.Build()
 		variableMap["PLUGIN_INSTALL_DIR"] = ctx.Config.Plugins.InstallDir
 
+		// The source is a host path interpolated by compose: only user variables are expanded
 		volumes = append(volumes, VolumeMapping{
-			Source: vol.Source,
+			Source: ctx.VariablePool.ExpandUserVariables(vol.Source),
 			Target: core.SubstituteVariables(vol.Target, variableMap),
 		})
 	}
@@ -95,7 +98,8 @@ func (g *Generator) prepareTemplateVars() map[string]interface{} {
 		WithCustom("HEALTHCHECK_TIMEOUT", ctx.Config.LocalDev.Compose.Healthcheck.Timeout).
 		WithCustom("HEALTHCHECK_RETRIES", ctx.Config.LocalDev.Compose.Healthcheck.Retries).
 		WithCustom("HEALTHCHECK_START_PERIOD", ctx.Config.LocalDev.Compose.Healthcheck.StartPeriod).
-		WithCustom("LABELS", ctx.Config.LocalDev.Compose.Labels)
+		WithCustom("HEALTHCHECK_TEST", buildHealthcheckTest(ctx, composer.Build())).
+		WithCustom("LABELS", mergeProbeLabels(ctx))
 
 	return composer.Build()
 }
@@ -103,30 +107,94 @@ func (g *Generator) prepareTemplateVars() map[string]interface{} {
 // mergeEnvironmentVariables merges environment variables from runtime.startup.env and local_dev.compose.environment
 // Compose environment variables have higher priority and can override runtime env vars
 func (g *Generator) mergeEnvironmentVariables(ctx *context.GeneratorContext) []interface{} {
-	// Create a map to store merged environment variables (for deduplication)
+	// Keep the order of first appearance so the generated file is stable across runs
+	var names []string
 	envMap := make(map[string]string)
+	add := func(name, value string) {
+		if _, exists := envMap[name]; !exists {
+			names = append(names, name)
+		}
+		envMap[name] = value
+	}
 
 	// First, add runtime environment variables
-	for _, env := range ctx.Config.Runtime.Startup.Env {
-		envMap[env.Name] = env.Value
+	for _, env := range ctx.VariablePool.ExpandUserVariablesInEnv(ctx.Config.Runtime.Startup.Env) {
+		add(env.Name, env.Value)
 	}
 
 	// Then, add/override with compose environment variables
-	for _, env := range ctx.Config.LocalDev.Compose.Environment {
-		envMap[env.Name] = env.Value
+	for _, env := range ctx.VariablePool.ExpandUserVariablesInEnv(ctx.Config.LocalDev.Compose.Environment) {
+		add(env.Name, env.Value)
 	}
 
 	// Convert map back to slice for template rendering
 	var result []interface{}
-	for name, value := range envMap {
+	for _, name := range names {
 		result = append(result, map[string]interface{}{
 			"Name":  name,
-			"Value": value,
+			"Value": envMap[name],
 		})
 	}
 
 	return result
 }
 
+// buildHealthcheckTest builds the compose healthcheck test command
+// Native probes (http / tcp) have no in-container command and return an empty string
+func buildHealthcheckTest(ctx *context.GeneratorContext, vars map[string]interface{}) string {
+	hc := &ctx.Config.Runtime.Healthcheck
+
+	var command []string
+	switch {
+	case hc.RequiresShell():
+		command = []string{"CMD", "/bin/sh", ctx.Paths.ServiceRoot + "/healthcheck.sh"}
+	case hc.EffectiveType() == config.HealthcheckTypeBinary:
+		command = []string{"CMD"}
+		for _, arg := range hc.ExecCommand() {
+			command = append(command, core.SubstituteVariables(arg, vars))
+		}
+	default:
+		return ""
+	}
+
+	quoted := make([]string, 0, len(command))
+	for _, part := range command {
+		quoted = append(quoted, strconv.Quote(part))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// mergeProbeLabels merges user labels with kompose probe labels for native healthchecks
+// kompose converts these labels into Kubernetes liveness/readiness probes
+func mergeProbeLabels(ctx *context.GeneratorContext) map[string]string {
+	hc := &ctx.Config.Runtime.Healthcheck
+	userLabels := ctx.Config.LocalDev.Compose.Labels
+
+	if !hc.Enabled || !hc.IsNativeProbe() {
+		return userLabels
+	}
+
+	labels := make(map[string]string, len(userLabels)+4)
+	port := strconv.Itoa(hc.ProbePort(ctx.Config.Service.Ports))
+	for _, probe := range []string{"liveness", "readiness"} {
+		prefix := "kompose.service.healthcheck." + probe + "."
+		if hc.EffectiveType() == config.HealthcheckTypeHTTP {
+			labels[prefix+"http_get_path"] = hc.ProbePath()
+			labels[prefix+"http_get_port"] = port
+		} else {
+			labels[prefix+"tcp_port"] = port
+		}
+	}
+
+	// User labels take precedence
+	for k, v := range userLabels {
+		labels[k] = v
+	}
+	return labels
+}
+
 //go:embed templates/compose.yaml.tmpl
 var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("docker/compose/compose.yaml.tmpl", template)
@@ -46,6 +46,9 @@ func TestGenerator_Generate(t *testing.T) {
 	if !strings.Contains(content, "ENV=production") {
 		t.Error("Expected environment variable not found")
 	}
+	if !strings.Contains(content, "- VCS_REF=${VCS_REF:-unknown}") {
+		t.Error("Expected VCS_REF build arg not found")
+	}
 }
 
 func TestGenerator_GetName(t *testing.T) {
@@ -163,6 +166,34 @@ func TestGenerator_MergeEnvironmentVariables(t *testing.T) {
 	}
 }
 
+func TestGenerator_MergeEnvironmentVariables_Order(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Runtime.Startup.Env = []config.EnvConfig{
+		{Name: "ENV", Value: "production"},
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "APP_MODE", Value: "server"},
+	}
+	cfg.LocalDev.Compose.Environment = []config.EnvConfig{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "DEBUG", Value: "true"},
+	}
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, _ := New(ctx)
+
+	// Overrides keep their original position, new variables are appended
+	want := []string{"ENV=production", "LOG_LEVEL=debug", "APP_MODE=server", "DEBUG=true"}
+	for i := 0; i < 20; i++ {
+		var got []string
+		for _, item := range gen.(*Generator).mergeEnvironmentVariables(ctx) {
+			env := item.(map[string]interface{})
+			got = append(got, env["Name"].(string)+"="+env["Value"].(string))
+		}
+		if strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Fatalf("Expected env order %v, got %v", want, got)
+		}
+	}
+}
+
 func TestGenerator_Generate_WithComposeEnvironment(t *testing.T) {
 	cfg := testutil.NewTestConfig()
 	cfg.Service.Ports = []config.PortConfig{
@@ -353,3 +384,85 @@ func TestGenerator_Generate_WithEntrypointAndEnvironment(t *testing.T) {
 		t.Error("Expected entrypoint command not found")
 	}
 }
+
+func TestGenerator_Generate_NativeProbeLabels(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Runtime.ShellLess = true
+	cfg.Runtime.Healthcheck = config.HealthcheckConfig{
+		Enabled: true,
+		Type:    config.HealthcheckTypeHTTP,
+		Path:    "/healthz",
+	}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if strings.Contains(content, "healthcheck.sh") {
+		t.Error("Native probes should not reference healthcheck.sh")
+	}
+	if !strings.Contains(content, `kompose.service.healthcheck.liveness.http_get_path: "/healthz"`) {
+		t.Errorf("Expected kompose liveness http path label, got:\n%s", content)
+	}
+	if !strings.Contains(content, `kompose.service.healthcheck.readiness.http_get_port: "8080"`) {
+		t.Error("Expected kompose readiness http port label")
+	}
+}
+
+func TestGenerator_Generate_BinaryHealthcheck(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Runtime.Healthcheck = config.HealthcheckConfig{
+		Enabled: true,
+		Type:    config.HealthcheckTypeBinary,
+		Binary:  "${SERVICE_BIN_DIR}/healthcheck",
+		Args:    []string{"-timeout", "2s"},
+	}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	want := `test: ["CMD", "/usr/local/services/test-service/bin/healthcheck", "-timeout", "2s"]`
+	if !strings.Contains(content, want) {
+		t.Errorf("Expected %q in compose, got:\n%s", want, content)
+	}
+}
+
+func TestGenerator_Generate_QuotesEnvironment(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Service.Ports = []config.PortConfig{
+		{Port: 8080, Protocol: "tcp"},
+	}
+	cfg.LocalDev.Compose.Environment = []config.EnvConfig{
+		{Name: "MESSAGE", Value: "key: value # not a comment"},
+	}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if !strings.Contains(content, `- "MESSAGE=key: value # not a comment"`) {
+		t.Errorf("Expected YAML-quoted environment entry, got:\n%s", content)
+	}
+}
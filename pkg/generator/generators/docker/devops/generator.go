@@ -2,11 +2,11 @@ package devops
 
 import (
 	_ "embed"
-	"strings"
 
 	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
 	"github.com/junjiewwang/service-template/pkg/generator/core"
+	"github.com/junjiewwang/service-template/pkg/images"
 )
 
 const GeneratorType = "devops"
@@ -36,7 +36,7 @@ func (g *Generator) Generate() (string, error) {
 	}
 
 	vars := g.prepareTemplateVars()
-	return g.RenderTemplate(template, vars)
+	return g.RenderTemplateFile(templatePath, vars)
 }
 
 // prepareTemplateVars prepares variables for devops template
@@ -75,12 +75,9 @@ func (g *Generator) prepareTemplateVars() map[string]interface{} {
 }
 
 // parseImageAndTag parses image name and tag from full image string
+// Registry ports and digests (from images.lock.yaml) are handled, e.g. "host:5000/app:1.0@sha256:..."
 func parseImageAndTag(fullImage string) (string, string) {
-	parts := strings.Split(fullImage, ":")
-	if len(parts) == 2 {
-		return parts[0], parts[1]
-	}
-	return fullImage, "latest"
+	return images.SplitTag(fullImage)
 }
 
 // getLanguageDisplayName returns display name for the language
@@ -103,3 +100,6 @@ func getLanguageDisplayName(langType string) string {
 
 //go:embed templates/devops.yaml.tmpl
 var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("docker/devops/devops.yaml.tmpl", template)
@@ -79,6 +79,10 @@ func TestParseImageAndTag(t *testing.T) {
 		{"with tag", "alpine:3.18", "alpine", "3.18"},
 		{"without tag", "alpine", "alpine", "latest"},
 		{"complex tag", "golang:1.21-alpine", "golang", "1.21-alpine"},
+		{"registry with port", "localhost:5000/team/app:1.0", "localhost:5000/team/app", "1.0"},
+		{"registry with port without tag", "localhost:5000/team/app", "localhost:5000/team/app", "latest"},
+		{"pinned digest", "alpine:3.19@sha256:abc", "alpine", "3.19@sha256:abc"},
+		{"digest without tag", "alpine@sha256:abc", "alpine", "latest@sha256:abc"},
 	}
 
 	for _, tt := range tests {
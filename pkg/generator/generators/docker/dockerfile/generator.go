@@ -8,6 +8,7 @@ import (
 	"github.com/junjiewwang/service-template/pkg/generator/context"
 	"github.com/junjiewwang/service-template/pkg/generator/core"
 	"github.com/junjiewwang/service-template/pkg/generator/domain/services"
+	"github.com/junjiewwang/service-template/pkg/images"
 )
 
 const GeneratorType = "dockerfile"
@@ -51,12 +52,15 @@ func (g *Generator) Generate() (string, error) {
 		return "", err
 	}
 
-	vars := g.prepareTemplateVars()
-	return g.RenderTemplate(template, vars)
+	vars, err := g.prepareTemplateVars()
+	if err != nil {
+		return "", err
+	}
+	return g.RenderTemplateFile(templatePath, vars)
 }
 
 // prepareTemplateVars prepares variables for Dockerfile template
-func (g *Generator) prepareTemplateVars() map[string]interface{} {
+func (g *Generator) prepareTemplateVars() (map[string]interface{}, error) {
 	ctx := g.GetContext()
 
 	// Use preset for Dockerfile with architecture
@@ -74,14 +78,24 @@ func (g *Generator) prepareTemplateVars() map[string]interface{} {
 	// Add Dockerfile-specific custom variables
 	composer.
 		WithCustom("PKG_MANAGER", detectPackageManager(builderImage)).
-		WithCustom("DEPENDENCY_FILES", getDependencyFilesList(ctx, ctx.OutputDir)).
+		WithCustom("DEPENDENCY_FILES", getDependencyFilesList(ctx)).
 		WithCustom("DEPS_INSTALL_COMMAND", langService.GetDepsInstallCommand(ctx.Config.Language.Type))
 
 	// Use plugin service to process plugins
 	pluginService := services.NewPluginService(ctx, g.GetEngine())
 	hasPlugins := pluginService.HasPlugins()
 
-	composer.WithCustom("HAS_PLUGINS", hasPlugins)
+	// images.lock.yaml 存在时，将锁定的镜像引用写入 ARG 默认值
+	runtimeImage, _ := composer.Get("RUNTIME_IMAGE")
+	runtimeName, runtimeTag := images.SplitTag(fmt.Sprint(runtimeImage))
+	composer.
+		WithCustom("IMAGE_LOCKED", ctx.Config.ImageLock != nil).
+		WithCustom("RUNTIME_IMAGE_NAME", runtimeName).
+		WithCustom("RUNTIME_IMAGE_TAG", runtimeTag)
+
+	composer.
+		WithCustom("HAS_PLUGINS", hasPlugins).
+		WithCustom("OCI_LABELS", buildOCILabels(ctx.Config))
 
 	if hasPlugins {
 		plugins := pluginService.PrepareForDockerfile()
@@ -93,8 +107,16 @@ func (g *Generator) prepareTemplateVars() map[string]interface{} {
 			WithCustom("PLUGIN_BUILD_SCRIPT_CONTAINER_PATH", fmt.Sprintf("%s/build_plugins.sh", ctx.Paths.CI.ContainerScriptDir))
 	}
 
-	return composer.Build()
+	// shell-less 运行时（distroless / scratch）：ENTRYPOINT 直接指向二进制，环境变量写入 ENV
+	if err := prepareRuntimeModeVars(ctx, composer); err != nil {
+		return nil, err
+	}
+
+	return composer.Build(), nil
 }
 
 //go:embed templates/dockerfile_.tmpl
 var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("docker/dockerfile/dockerfile_.tmpl", template)
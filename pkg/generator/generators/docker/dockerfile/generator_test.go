@@ -1,9 +1,12 @@
 package dockerfile
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
 	"github.com/junjiewwang/service-template/pkg/generator/internal/testutil"
 )
@@ -90,3 +93,240 @@ func TestDetectPackageManager(t *testing.T) {
 
 // TestGetDefaultDependencyFiles removed - functionality moved to LanguageService
 // See pkg/generator/domain/services/language_service_test.go for dependency file detection tests
+
+func TestGenerator_Generate_ShellLess(t *testing.T) {
+	cfg := testutil.NewGeneratorTestConfig()
+	cfg.Runtime.ShellLess = true
+	cfg.Runtime.Startup.Command = "${SERVICE_BIN_DIR}/${SERVICE_NAME} --port ${SERVICE_PORT}"
+	cfg.Runtime.Startup.Env = []config.EnvConfig{{Name: "GO_ENV", Value: "production"}}
+	cfg.Runtime.Healthcheck = config.HealthcheckConfig{
+		Enabled: true,
+		Type:    config.HealthcheckTypeBinary,
+		Binary:  "${SERVICE_BIN_DIR}/healthcheck",
+	}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx, "amd64")
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if strings.Contains(content, "rt_prepare.sh") {
+		t.Error("rt_prepare.sh should be skipped in shell-less mode")
+	}
+	if strings.Contains(content, "entrypoint.sh") {
+		t.Error("entrypoint.sh should not be used in shell-less mode")
+	}
+	if !strings.Contains(content, `ENTRYPOINT ["/usr/local/services/generator-test/bin/generator-test","--port","8080"]`) {
+		t.Errorf("Expected exec-form ENTRYPOINT with substituted variables, got:\n%s", content)
+	}
+	if !strings.Contains(content, `ENV GO_ENV="production"`) {
+		t.Error("Expected startup env baked in as ENV")
+	}
+	if !strings.Contains(content, `HEALTHCHECK CMD ["/usr/local/services/generator-test/bin/healthcheck"]`) {
+		t.Error("Expected exec-form HEALTHCHECK with compiled binary")
+	}
+}
+
+func TestGenerator_Generate_ScratchRuntime(t *testing.T) {
+	cfg := testutil.NewGeneratorTestConfig()
+	cfg.Runtime.ShellLess = true
+	cfg.Build.RuntimeImage = config.NewImageSpec("scratch")
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx, "arm64")
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if !strings.Contains(content, "\nFROM scratch\n") {
+		t.Error("Expected FROM scratch for scratch runtime image")
+	}
+	if strings.Contains(content, "FROM ${TLINUX_BASE_IMAGE_ARM}") {
+		t.Error("scratch runtime should not use the base image build args")
+	}
+}
+
+func TestGenerator_Generate_OCILabels(t *testing.T) {
+	cfg := testutil.NewGeneratorTestConfig()
+	cfg.Service.Description = "Generator test service"
+	cfg.Build.ImageLabels = config.ImageLabelsConfig{
+		Source: "https://github.com/example/generator-test",
+		Custom: map[string]string{"com.example.team": "platform"},
+	}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx, "amd64")
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	expected := []string{
+		"ARG VERSION=dev",
+		"ARG VCS_REF=unknown",
+		"ARG BUILD_DATE=unknown",
+		`org.opencontainers.image.title="generator-test"`,
+		`org.opencontainers.image.description="Generator test service"`,
+		`org.opencontainers.image.source="https://github.com/example/generator-test"`,
+		`org.opencontainers.image.revision="${VCS_REF}"`,
+		`org.opencontainers.image.version="${VERSION}"`,
+		`org.opencontainers.image.created="${BUILD_DATE}"`,
+		`com.example.team="platform"`,
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q in Dockerfile", want)
+		}
+	}
+	if strings.Contains(content, "org.opencontainers.image.vendor") {
+		t.Error("Unset vendor label should be omitted")
+	}
+}
+
+func TestGenerator_Generate_TestAndLintStages(t *testing.T) {
+	cfg := testutil.NewGeneratorTestConfig()
+	cfg.Build.Commands.Lint = "golangci-lint run"
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx, "amd64")
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if !strings.Contains(content, "FROM deps AS test") {
+		t.Error("Expected test stage not found")
+	}
+	if !strings.Contains(content, "RUN sh -xe /opt/.tad/build/generator-test/test.sh") {
+		t.Error("Expected test stage to run test.sh")
+	}
+	if !strings.Contains(content, "FROM deps AS lint") {
+		t.Error("Expected lint stage not found")
+	}
+
+	// runtime 必须仍是最后一个 stage（默认构建目标）
+	lastFrom := strings.LastIndex(content, "\nFROM ")
+	if strings.Contains(content[lastFrom:], " AS ") {
+		t.Error("Expected runtime stage to remain the final stage")
+	}
+}
+
+func TestGenerator_Generate_SkipStages(t *testing.T) {
+	cfg := testutil.NewGeneratorTestConfig()
+	cfg.Build.SkipStages = []string{config.StageTest, config.StageLint}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx, "amd64")
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	for _, stage := range []string{"FROM deps AS test", "FROM deps AS lint", "test.sh", "lint.sh"} {
+		if strings.Contains(content, stage) {
+			t.Errorf("Skipped stages should not be rendered, found %q", stage)
+		}
+	}
+}
+
+func TestGenerator_Generate_ImageLock(t *testing.T) {
+	cfg := testutil.NewGeneratorTestConfig()
+	cfg.Build.BuilderImage = config.NewImageSpec("golang:1.23-alpine")
+	cfg.Build.RuntimeImage = config.NewImageSpec("localhost:5000/os/minimal:1.0")
+	cfg.ImageLock = &config.ImageLock{Images: map[string]string{
+		"golang:1.23-alpine":            "sha256:bbb",
+		"localhost:5000/os/minimal:1.0": "sha256:ccc",
+	}}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx, "amd64")
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	expected := []string{
+		"ARG BUILDER_IMAGE_X86=golang:1.23-alpine@sha256:bbb",
+		"ARG TLINUX_BASE_IMAGE_X86=localhost:5000/os/minimal",
+		"ARG TLINUX_TAG_X86=1.0@sha256:ccc",
+	}
+	for _, want := range expected {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected %q in Dockerfile", want)
+		}
+	}
+}
+
+func TestGenerator_TemplateOverride(t *testing.T) {
+	cfg := testutil.NewGeneratorTestConfig()
+	cfg.Templates.OverrideDir = t.TempDir()
+	overridePath := filepath.Join(cfg.Templates.OverrideDir, filepath.FromSlash(templatePath))
+	if err := os.MkdirAll(filepath.Dir(overridePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx, "amd64")
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+	original, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	// 只覆盖 runtime_extra 块，其余内容保持不变
+	block := "{{ define \"runtime_extra\" }}\n\n# Timezone\nRUN ln -sf /usr/share/zoneinfo/UTC /etc/localtime{{ end }}\n"
+	if err := os.WriteFile(overridePath, []byte(block), 0644); err != nil {
+		t.Fatal(err)
+	}
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	if !strings.Contains(content, "# Timezone\nRUN ln -sf /usr/share/zoneinfo/UTC /etc/localtime\n\n# Set working directory") {
+		t.Errorf("Block override not applied:\n%s", content)
+	}
+	if strings.Replace(content, "\n\n# Timezone\nRUN ln -sf /usr/share/zoneinfo/UTC /etc/localtime", "", 1) != original {
+		t.Error("Block override changed content outside the block")
+	}
+
+	// 文件整体替换
+	if err := os.WriteFile(overridePath, []byte("FROM {{ .RUNTIME_IMAGE }}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	content, err = gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	if strings.Contains(content, "AS builder") || !strings.HasPrefix(content, "FROM ") {
+		t.Errorf("Full override not applied:\n%s", content)
+	}
+}
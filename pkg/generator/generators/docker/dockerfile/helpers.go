@@ -1,22 +1,26 @@
 package dockerfile
 
 import (
+	"fmt"
 	"github.com/junjiewwang/service-template/pkg/generator/domain/services/languageservice"
+	"sort"
 	"strings"
 
+	"github.com/junjiewwang/service-template/pkg/config"
 	"github.com/junjiewwang/service-template/pkg/generator/context"
+	"github.com/junjiewwang/service-template/pkg/generator/core"
 )
 
 // getDependencyFilesList returns list of dependency files
-// projectDir: the root directory of the project to scan for dependency files
-func getDependencyFilesList(ctx *context.GeneratorContext, projectDir string) []string {
+// Dependency files are detected in the project file system of the context (ctx.FS)
+func getDependencyFilesList(ctx *context.GeneratorContext) []string {
 	cfg := ctx.Config
 	if cfg.Build.DependencyFiles.AutoDetect {
 		// Use language service to detect actual dependency files in the project
 		langService := languageservice.NewLanguageService(ctx)
 		return langService.GetDependencyFilesWithDetection(
 			cfg.Language.Type,
-			projectDir,
+			ctx.FS,
 			true,
 			nil,
 		)
@@ -57,3 +61,95 @@ func detectPackageManager(image string) string {
 
 	return "yum"
 }
+
+// prepareRuntimeModeVars prepares runtime stage variables for shell-less mode and exec-form healthchecks
+func prepareRuntimeModeVars(ctx *context.GeneratorContext, composer *context.VariableComposer) error {
+	runtime := ctx.Config.Runtime
+	vars := composer.Build()
+
+	composer.WithCustom("SHELL_LESS", runtime.ShellLess)
+
+	// binary 类型健康检查：以 exec 形式写入 HEALTHCHECK
+	healthcheckExec := ""
+	if runtime.Healthcheck.Enabled && runtime.Healthcheck.EffectiveType() == config.HealthcheckTypeBinary {
+		array, err := execFormArray(runtime.Healthcheck.ExecCommand(), vars)
+		if err != nil {
+			return fmt.Errorf("failed to build healthcheck command: %w", err)
+		}
+		healthcheckExec = array
+	}
+	composer.WithCustom("HEALTHCHECK_EXEC", healthcheckExec)
+
+	if !runtime.ShellLess {
+		return nil
+	}
+
+	args, err := runtime.Startup.ExecArgs()
+	if err != nil {
+		return fmt.Errorf("runtime.startup.command: %w", err)
+	}
+	entrypoint, err := execFormArray(args, vars)
+	if err != nil {
+		return fmt.Errorf("failed to build entrypoint: %w", err)
+	}
+
+	composer.WithCustom("ENTRYPOINT_EXEC", entrypoint)
+
+	return nil
+}
+
+// execFormArray substitutes variables and encodes args as a Dockerfile exec-form JSON array
+func execFormArray(args []string, vars map[string]interface{}) (string, error) {
+	resolved := make([]string, 0, len(args))
+	for _, arg := range args {
+		resolved = append(resolved, core.SubstituteVariables(arg, vars))
+	}
+	return core.DockerJSONArray(resolved)
+}
+
+// ociLabel is a single LABEL key/value pair in the runtime stage
+type ociLabel struct {
+	Key   string
+	Value string
+}
+
+// buildOCILabels builds org.opencontainers.image.* labels for the runtime stage
+// revision / version / created reference build args so they are filled at build time
+func buildOCILabels(cfg *config.ServiceConfig) []ociLabel {
+	const prefix = "org.opencontainers.image."
+	labelsCfg := cfg.Build.ImageLabels
+
+	labels := []ociLabel{{Key: prefix + "title", Value: cfg.Service.Name}}
+
+	optional := []ociLabel{
+		{Key: prefix + "description", Value: cfg.Service.Description},
+		{Key: prefix + "source", Value: labelsCfg.Source},
+		{Key: prefix + "url", Value: labelsCfg.URL},
+		{Key: prefix + "documentation", Value: labelsCfg.Documentation},
+		{Key: prefix + "vendor", Value: labelsCfg.Vendor},
+		{Key: prefix + "licenses", Value: labelsCfg.Licenses},
+	}
+	for _, label := range optional {
+		if label.Value != "" {
+			labels = append(labels, label)
+		}
+	}
+
+	labels = append(labels,
+		ociLabel{Key: prefix + "revision", Value: "${" + config.BuildArgRevision + "}"},
+		ociLabel{Key: prefix + "version", Value: "${" + config.BuildArgVersion + "}"},
+		ociLabel{Key: prefix + "created", Value: "${" + config.BuildArgBuildDate + "}"},
+	)
+
+	// Custom labels in stable order
+	keys := make([]string, 0, len(labelsCfg.Custom))
+	for key := range labelsCfg.Custom {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		labels = append(labels, ociLabel{Key: key, Value: labelsCfg.Custom[key]})
+	}
+
+	return labels
+}
@@ -34,7 +34,7 @@ func (g *Generator) Generate() (string, error) {
 	}
 
 	vars := g.prepareTemplateVars()
-	return g.RenderTemplate(tmpl, vars)
+	return g.RenderTemplateFile(templatePath, vars)
 }
 
 // ServicePort represents a port entry for K8s Service strategic merge patch
@@ -64,3 +64,6 @@ func (g *Generator) prepareTemplateVars() map[string]interface{} {
 
 //go:embed templates/service.yaml.tmpl
 var tmpl string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("k8s/service/service.yaml.tmpl", tmpl)
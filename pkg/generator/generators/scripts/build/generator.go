@@ -48,12 +48,11 @@ func (g *Generator) Generate() (string, error) {
 		composer.Override("PLUGINS", plugins)
 	}
 
-	// Add build script specific variables
-	composer.
-		WithCustom("GENERATE_SCRIPTS", ctx.Config.Runtime.GenerateScripts)
-
-	return g.RenderTemplate(template, composer.Build())
+	return g.RenderTemplateFile(templatePath, composer.Build())
 }
 
 //go:embed templates/build.sh.tmpl
 var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("scripts/build/build.sh.tmpl", template)
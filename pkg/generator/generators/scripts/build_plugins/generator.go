@@ -52,8 +52,11 @@ func (g *Generator) Generate() (string, error) {
 	plugins := pluginService.PrepareForBuildScript()
 	composer.Override("PLUGINS", plugins)
 
-	return g.RenderTemplate(template, composer.Build())
+	return g.RenderTemplateFile(templatePath, composer.Build())
 }
 
 //go:embed templates/build_plugins.sh.tmpl
 var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("scripts/build_plugins/build_plugins.sh.tmpl", template)
@@ -0,0 +1,75 @@
+package check
+
+import (
+	_ "embed"
+
+	"github.com/junjiewwang/service-template/pkg/generator/context"
+	"github.com/junjiewwang/service-template/pkg/generator/core"
+)
+
+const (
+	TestGeneratorType = "test-script"
+	LintGeneratorType = "lint-script"
+)
+
+// init registers the test and lint script generators
+func init() {
+	core.DefaultRegistry.Register(TestGeneratorType, newCreator(checkKindTest))
+	core.DefaultRegistry.Register(LintGeneratorType, newCreator(checkKindLint))
+}
+
+// checkKind describes one check stage (test / lint)
+type checkKind struct {
+	generatorType string
+	name          string // stage name, also used in script output
+	commandVar    string // variable holding the resolved command
+}
+
+var (
+	checkKindTest = checkKind{generatorType: TestGeneratorType, name: "test", commandVar: context.VarTestCommand}
+	checkKindLint = checkKind{generatorType: LintGeneratorType, name: "lint", commandVar: context.VarLintCommand}
+)
+
+// Generator generates test.sh / lint.sh, executed in the Dockerfile test / lint stages
+type Generator struct {
+	core.BaseGenerator
+	kind checkKind
+}
+
+// newCreator returns a registry constructor bound to the given check kind
+func newCreator(kind checkKind) core.GeneratorCreator {
+	return func(ctx *context.GeneratorContext, options ...interface{}) (core.Generator, error) {
+		engine := core.NewTemplateEngine()
+		return &Generator{
+			BaseGenerator: core.NewBaseGenerator(kind.generatorType, ctx, engine),
+			kind:          kind,
+		}, nil
+	}
+}
+
+// Generate generates the check script content
+// Returns empty string when no command is configured or derived (the stage is not rendered either)
+func (g *Generator) Generate() (string, error) {
+	if err := g.Validate(); err != nil {
+		return "", err
+	}
+
+	composer := g.GetContext().GetVariablePreset().ForBuildScript()
+
+	command, _ := composer.Get(g.kind.commandVar)
+	if cmd, ok := command.(string); !ok || cmd == "" {
+		return "", nil
+	}
+
+	composer.
+		WithCustom("CHECK_NAME", g.kind.name).
+		WithCustom("CHECK_COMMAND", command)
+
+	return g.RenderTemplateFile(templatePath, composer.Build())
+}
+
+//go:embed templates/check.sh.tmpl
+var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("scripts/check/check.sh.tmpl", template)
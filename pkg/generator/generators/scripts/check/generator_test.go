@@ -0,0 +1,78 @@
+package check
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/junjiewwang/service-template/pkg/generator/context"
+	// registers the language strategies' default test / lint commands
+	_ "github.com/junjiewwang/service-template/pkg/generator/domain/services/languageservice"
+	"github.com/junjiewwang/service-template/pkg/generator/internal/testutil"
+)
+
+func TestGenerator_Generate_DefaultTestCommand(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := newCreator(checkKindTest)(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if !strings.Contains(content, "#!/bin/bash") {
+		t.Error("Expected shebang not found")
+	}
+	if !strings.Contains(content, "\ngo test ./...\n") {
+		t.Error("Expected default go test command not found")
+	}
+	if !strings.Contains(content, "make docker-test") {
+		t.Error("Expected usage hint for docker-test not found")
+	}
+}
+
+func TestGenerator_Generate_CustomLintCommand(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Build.Commands.Lint = "golangci-lint run ./..."
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := newCreator(checkKindLint)(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if !strings.Contains(content, "golangci-lint run ./...") {
+		t.Error("Expected custom lint command not found")
+	}
+	if gen.GetName() != LintGeneratorType {
+		t.Errorf("Expected name %s, got %s", LintGeneratorType, gen.GetName())
+	}
+}
+
+func TestGenerator_Generate_NoCommandSkipped(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Language.Type = "python" // python 没有默认 lint 命令
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := newCreator(checkKindLint)(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	if content != "" {
+		t.Error("Expected empty content when no lint command is available")
+	}
+}
@@ -66,8 +66,11 @@ func (g *Generator) Generate() (string, error) {
 		"GoSumDB":            goSumDB,
 	}
 
-	return g.RenderTemplate(template, data)
+	return g.RenderTemplateFile(templatePath, data)
 }
 
 //go:embed templates/deps_install.sh.tmpl
 var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("scripts/deps_install/deps_install.sh.tmpl", template)
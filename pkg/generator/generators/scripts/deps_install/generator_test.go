@@ -133,3 +133,26 @@ func TestGenerator_DefaultDepsInstallCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerator_Generate_UsesPkgInstallPartial(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Build.Dependencies.SystemPkgs = []string{"git", "make"}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if !strings.Contains(content, "install_packages() {") {
+		t.Error("Expected install_packages function from the pkg_install partial")
+	}
+	if !strings.Contains(content, "install_packages $SYSTEM_PACKAGES") {
+		t.Error("Expected install_packages call for system packages")
+	}
+}
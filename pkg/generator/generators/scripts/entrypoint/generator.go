@@ -36,6 +36,11 @@ func (g *Generator) Generate() (string, error) {
 
 	ctx := g.GetContext()
 
+	// shell-less 运行时没有 /bin/sh，不生成 entrypoint.sh
+	if ctx.Config.Runtime.ShellLess {
+		return "", nil
+	}
+
 	// Use preset for script
 	composer := ctx.GetVariablePreset().ForScript()
 
@@ -48,8 +53,11 @@ func (g *Generator) Generate() (string, error) {
 		WithCustom("PLUGINS_ENV", pluginEnvs).
 		WithCustom("HAS_PLUGINS_ENV", len(pluginEnvs) > 0)
 
-	return g.RenderTemplate(template, composer.Build())
+	return g.RenderTemplateFile(templatePath, composer.Build())
 }
 
 //go:embed templates/entrypoint.sh.tmpl
 var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("scripts/entrypoint/entrypoint.sh.tmpl", template)
@@ -67,3 +67,51 @@ func TestGenerator_Validate(t *testing.T) {
 		t.Errorf("Validation failed: %v", err)
 	}
 }
+
+func TestGenerator_Generate_ShellLessSkipped(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Runtime.ShellLess = true
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if content != "" {
+		t.Errorf("Expected no script in shell-less mode, got:\n%s", content)
+	}
+}
+
+func TestGenerator_Generate_QuotesEnvValues(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Runtime.Startup.Command = "./bin/myapp"
+	cfg.Runtime.Startup.Env = []config.EnvConfig{
+		{Name: "JAVA_OPTS", Value: "-Xmx1g -Dapp.home=${SERVICE_ROOT}"},
+		{Name: "GREETING", Value: `say "hi"`},
+	}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	// Values with spaces are double-quoted; ${VAR} still expands at runtime
+	if !strings.Contains(content, `export JAVA_OPTS="-Xmx1g -Dapp.home=${SERVICE_ROOT}"`) {
+		t.Errorf("Expected quoted JAVA_OPTS export, got:\n%s", content)
+	}
+	if !strings.Contains(content, `export GREETING="say \"hi\""`) {
+		t.Errorf("Expected escaped GREETING export, got:\n%s", content)
+	}
+}
@@ -14,6 +14,8 @@ const GeneratorType = "healthcheck-script"
 // init registers the healthcheck script generator
 func init() {
 	core.DefaultRegistry.Register(GeneratorType, New)
+	core.RegisterTemplate(strategyTemplatePath("default"), defaultScriptTemplate)
+	core.RegisterTemplate(strategyTemplatePath("custom"), customScriptTemplate)
 }
 
 // Generator generates healthchk.sh script
@@ -54,11 +56,16 @@ func (g *Generator) Generate() (string, error) {
 
 	ctx := g.GetContext()
 
+	// shell-less 运行时没有 /bin/sh，不生成 healthchk.sh
+	if ctx.Config.Runtime.ShellLess {
+		return "", nil
+	}
+
 	// Use preset for script
 	composer := ctx.GetVariablePreset().ForScript()
 
 	// Add healthcheck-specific custom variable
-	composer.WithCustom("CUSTOM_SCRIPT", ctx.Config.Runtime.Healthcheck.CustomScript)
+	composer.WithCustom("CUSTOM_SCRIPT", ctx.VariablePool.ExpandUserVariables(ctx.Config.Runtime.Healthcheck.CustomScript))
 
 	vars := composer.Build()
 
@@ -68,8 +75,13 @@ func (g *Generator) Generate() (string, error) {
 		return "", err
 	}
 
-	// Render the template with variables
-	return g.RenderTemplate(scriptTemplate, vars)
+	// Native probes need no script
+	if scriptTemplate == "" {
+		return "", nil
+	}
+
+	// Render the template with variables (overridable per strategy, e.g. scripts/healthcheck/default.sh.tmpl)
+	return g.RenderOverridable(strategyTemplatePath(g.strategy.GetType()), scriptTemplate, vars)
 }
 
 // GetStrategy returns the current healthcheck strategy
@@ -110,8 +122,10 @@ func (f *StrategyFactory) CreateStrategy() (Strategy, error) {
 		return NewDefaultStrategy(f.config), nil
 	case "custom":
 		return NewCustomStrategy(f.config), nil
+	case config.HealthcheckTypeHTTP, config.HealthcheckTypeTCP, config.HealthcheckTypeBinary:
+		return NewNativeStrategy(f.config), nil
 	default:
-		return nil, fmt.Errorf("unsupported healthcheck type: %s (valid: default, custom)", f.config.Runtime.Healthcheck.Type)
+		return nil, fmt.Errorf("unsupported healthcheck type: %s (valid: default, custom, http, tcp, binary)", f.config.Runtime.Healthcheck.Type)
 	}
 }
 
@@ -134,23 +148,7 @@ func (s *DefaultStrategy) GetType() string {
 
 // GenerateScript generates default health check script
 func (s *DefaultStrategy) GenerateScript(vars map[string]interface{}) (string, error) {
-	script := `#!/bin/sh
-
-# Export service paths as environment variables
-export SERVICE_ROOT="{{ .DEPLOY_DIR }}/{{ .SERVICE_NAME }}"
-export SERVICE_BIN_DIR="{{ .DEPLOY_DIR }}/{{ .SERVICE_NAME }}/bin"
-export SERVICE_NAME="{{ .SERVICE_NAME }}"
-
-# Default healthcheck: check if service process is running
-ps=$(ls -l /proc/*/exe 2>/dev/null | grep "${SERVICE_NAME}" | grep -v grep)
-
-# abnormal
-[[ "$ps" == "" ]] && exit 1
-
-# normal
-exit 0
-`
-	return script, nil
+	return defaultScriptTemplate, nil
 }
 
 // Validate validates the default strategy configuration
@@ -182,17 +180,7 @@ func (s *CustomStrategy) GenerateScript(vars map[string]interface{}) (string, er
 		return "", fmt.Errorf("custom_script is required for custom healthcheck type")
 	}
 
-	script := `#!/bin/sh
-
-# Export service paths as environment variables
-export SERVICE_ROOT="{{ .DEPLOY_DIR }}/{{ .SERVICE_NAME }}"
-export SERVICE_BIN_DIR="{{ .DEPLOY_DIR }}/{{ .SERVICE_NAME }}/bin"
-export SERVICE_NAME="{{ .SERVICE_NAME }}"
-
-# Custom healthcheck script
-{{ .CUSTOM_SCRIPT }}
-`
-	return script, nil
+	return customScriptTemplate, nil
 }
 
 // Validate validates the custom strategy configuration
@@ -203,5 +191,70 @@ func (s *CustomStrategy) Validate() error {
 	return nil
 }
 
+// NativeStrategy implements health checks that do not need a shell script:
+// http / tcp probes are executed by the orchestrator, binary runs a compiled checker in exec form
+type NativeStrategy struct {
+	config *config.ServiceConfig
+}
+
+// NewNativeStrategy creates a new native strategy
+func NewNativeStrategy(cfg *config.ServiceConfig) *NativeStrategy {
+	return &NativeStrategy{
+		config: cfg,
+	}
+}
+
+// GetType returns the strategy type
+func (s *NativeStrategy) GetType() string {
+	return s.config.Runtime.Healthcheck.Type
+}
+
+// GenerateScript returns an empty script, no healthchk.sh is generated
+func (s *NativeStrategy) GenerateScript(vars map[string]interface{}) (string, error) {
+	return "", nil
+}
+
+// Validate validates the native strategy configuration
+func (s *NativeStrategy) Validate() error {
+	if s.config.Runtime.Healthcheck.Type == config.HealthcheckTypeBinary && s.config.Runtime.Healthcheck.Binary == "" {
+		return fmt.Errorf("runtime.healthcheck.binary is required when type is 'binary'")
+	}
+	return nil
+}
+
 //go:embed templates/healthcheck.sh.tmpl
 var template string
+
+// 各策略的脚本模板，注册后可通过 templates.override_dir 覆盖
+const defaultScriptTemplate = `#!/bin/sh
+
+# Export service paths as environment variables
+export SERVICE_ROOT="{{ .DEPLOY_DIR }}/{{ .SERVICE_NAME }}"
+export SERVICE_BIN_DIR="{{ .DEPLOY_DIR }}/{{ .SERVICE_NAME }}/bin"
+export SERVICE_NAME="{{ .SERVICE_NAME }}"
+
+# Default healthcheck: check if service process is running
+ps=$(ls -l /proc/*/exe 2>/dev/null | grep "${SERVICE_NAME}" | grep -v grep)
+
+# abnormal
+[[ "$ps" == "" ]] && exit 1
+
+# normal
+exit 0
+`
+
+const customScriptTemplate = `#!/bin/sh
+
+# Export service paths as environment variables
+export SERVICE_ROOT="{{ .DEPLOY_DIR }}/{{ .SERVICE_NAME }}"
+export SERVICE_BIN_DIR="{{ .DEPLOY_DIR }}/{{ .SERVICE_NAME }}/bin"
+export SERVICE_NAME="{{ .SERVICE_NAME }}"
+
+# Custom healthcheck script
+{{ .CUSTOM_SCRIPT }}
+`
+
+// strategyTemplatePath 策略脚本模板路径
+func strategyTemplatePath(strategyType string) string {
+	return "scripts/healthcheck/" + strategyType + ".sh.tmpl"
+}
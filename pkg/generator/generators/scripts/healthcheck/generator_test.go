@@ -139,3 +139,43 @@ func TestStrategy_Custom(t *testing.T) {
 		t.Log("Script contains template variables, which is expected")
 	}
 }
+
+func TestGenerator_Generate_ShellLessSkipped(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Runtime.ShellLess = true
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if content != "" {
+		t.Errorf("Expected no script in shell-less mode, got:\n%s", content)
+	}
+}
+
+func TestGenerator_Generate_NativeProbeSkipped(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Runtime.Healthcheck.Type = "tcp"
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if content != "" {
+		t.Errorf("Expected no healthchk.sh for native probes, got:\n%s", content)
+	}
+}
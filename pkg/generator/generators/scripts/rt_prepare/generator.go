@@ -35,14 +35,22 @@ func (g *Generator) Generate() (string, error) {
 
 	ctx := g.GetContext()
 
+	// shell-less 运行时没有 /bin/sh，不生成 rt_prepare.sh
+	if ctx.Config.Runtime.ShellLess {
+		return "", nil
+	}
+
 	// Use preset for script
 	composer := ctx.GetVariablePreset().ForScript()
 
 	// Add rt_prepare-specific custom variable
 	composer.WithCustom("RUNTIME_DEPS_PACKAGES", ctx.Config.Runtime.SystemDependencies.Packages)
 
-	return g.RenderTemplate(template, composer.Build())
+	return g.RenderTemplateFile(templatePath, composer.Build())
 }
 
 //go:embed templates/rt_prepare.sh.tmpl
 var template string
+
+// templatePath 模板相对路径，templates.override_dir 下同路径的文件会覆盖内置模板
+var templatePath = core.RegisterTemplate("scripts/rt_prepare/rt_prepare.sh.tmpl", template)
@@ -57,3 +57,46 @@ func TestGenerator_Validate(t *testing.T) {
 		t.Errorf("Validation failed: %v", err)
 	}
 }
+
+func TestGenerator_Generate_ShellLessSkipped(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Runtime.ShellLess = true
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if content != "" {
+		t.Errorf("Expected no script in shell-less mode, got:\n%s", content)
+	}
+}
+
+func TestGenerator_Generate_UsesPkgInstallPartial(t *testing.T) {
+	cfg := testutil.NewTestConfig()
+	cfg.Runtime.SystemDependencies.Packages = []string{"ca-certificates"}
+
+	ctx := context.NewGeneratorContext(cfg, "/tmp/output")
+	gen, err := New(ctx)
+	if err != nil {
+		t.Fatalf("Failed to create generator: %v", err)
+	}
+
+	content, err := gen.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+
+	if !strings.Contains(content, "install_packages() {") {
+		t.Error("Expected install_packages function from the pkg_install partial")
+	}
+	if !strings.Contains(content, "install_packages $RUNTIME_PACKAGES") {
+		t.Error("Expected install_packages call for runtime packages")
+	}
+}
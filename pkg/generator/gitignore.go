@@ -1,12 +1,10 @@
 package generator
 
 import (
-	"fmt"
-	"os"
-	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/generator/filewriter/strategies"
 )
 
 const (
@@ -66,62 +64,21 @@ func buildGitignoreBlock(entries []string) string {
 	return sb.String()
 }
 
-// updateGitignore 更新目标目录下的 .gitignore 文件
-// - 如果不存在：创建新文件
-// - 如果已存在：用 marker block 增量更新，保留用户自定义内容
-func (g *Generator) updateGitignore() error {
-	entries := g.gitignoreEntries()
-	newBlock := buildGitignoreBlock(entries)
-
-	gitignorePath := filepath.Join(g.outputDir, ".gitignore")
-
-	existing, err := os.ReadFile(gitignorePath)
-	if err != nil {
-		if os.IsNotExist(err) {
-			// 文件不存在，创建新文件
-			if writeErr := os.WriteFile(gitignorePath, []byte(newBlock+"\n"), 0644); writeErr != nil {
-				return fmt.Errorf("failed to create .gitignore: %w", writeErr)
-			}
-			fmt.Println("✓ Created .gitignore")
-			return nil
-		}
-		return fmt.Errorf("failed to read .gitignore: %w", err)
+// gitignoreFile 返回 .gitignore 的 marker block，写入时保留 block 之外的用户内容
+func (g *Generator) gitignoreFile() RenderedFile {
+	return RenderedFile{
+		Path:     ".gitignore",
+		Content:  buildGitignoreBlock(g.gitignoreEntries()),
+		Strategy: strategies.BlockStrategyID,
 	}
-
-	// 文件已存在，检查是否有 marker block
-	updated := replaceOrAppendBlock(string(existing), newBlock)
-
-	if updated == string(existing) {
-		// 内容未变化
-		fmt.Println("✓ .gitignore is up to date")
-		return nil
-	}
-
-	if writeErr := os.WriteFile(gitignorePath, []byte(updated), 0644); writeErr != nil {
-		return fmt.Errorf("failed to update .gitignore: %w", writeErr)
-	}
-	fmt.Println("✓ Updated .gitignore")
-	return nil
 }
 
-// replaceOrAppendBlock 替换已有 marker block 或追加新 block
+// replaceOrAppendBlock 替换已有 .gitignore marker block 或追加新 block
 func replaceOrAppendBlock(existing, newBlock string) string {
-	pattern := fmt.Sprintf(`(?s)%s\n.*?%s`,
-		regexp.QuoteMeta(gitignoreStartMarker),
-		regexp.QuoteMeta(gitignoreEndMarker))
-
-	re := regexp.MustCompile(pattern)
-
-	if re.MatchString(existing) {
-		// 替换已有 block
-		return re.ReplaceAllString(existing, newBlock)
-	}
+	return replaceOrAppendMarkedBlock(existing, newBlock, gitignoreStartMarker, gitignoreEndMarker)
+}
 
-	// 追加新 block
-	result := strings.TrimRight(existing, "\n")
-	if result != "" {
-		result += "\n\n"
-	}
-	result += newBlock + "\n"
-	return result
+// replaceOrAppendMarkedBlock 替换 startMarker/endMarker 之间的 block，不存在时追加到末尾
+func replaceOrAppendMarkedBlock(existing, newBlock, startMarker, endMarker string) string {
+	return strategies.ReplaceOrAppendBlock(existing, newBlock, startMarker, endMarker)
 }
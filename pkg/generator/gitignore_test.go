@@ -177,7 +177,7 @@ func TestGenerator_GitignoreEntries_ScriptDirUnderTad(t *testing.T) {
 	assert.NotContains(t, entries, ".tad/custom/scripts/")
 }
 
-func TestGenerator_UpdateGitignore_CreateNew(t *testing.T) {
+func TestGenerator_WriteGitignore_CreateNew(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := configtestutil.NewConfigBuilder().
@@ -190,11 +190,12 @@ func TestGenerator_UpdateGitignore_CreateNew(t *testing.T) {
 		WithRuntimeImage("@runtimes.alpine_3.18").
 		WithBuildCommand("go build -o bin/test-service").
 		WithDeployDir("/opt/services").
+		WithManageGitignore(true).
 		BuildWithDefaults()
 
 	gen := NewGenerator(cfg, tmpDir)
 
-	err := gen.updateGitignore()
+	err := writeRenderedFile(gen, ".gitignore")
 	require.NoError(t, err)
 
 	// 验证文件已创建
@@ -210,7 +211,7 @@ func TestGenerator_UpdateGitignore_CreateNew(t *testing.T) {
 	assert.Contains(t, contentStr, ".env.make")
 }
 
-func TestGenerator_UpdateGitignore_AppendToExisting(t *testing.T) {
+func TestGenerator_WriteGitignore_AppendToExisting(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// 创建已有 .gitignore
@@ -228,11 +229,12 @@ func TestGenerator_UpdateGitignore_AppendToExisting(t *testing.T) {
 		WithRuntimeImage("@runtimes.alpine_3.18").
 		WithBuildCommand("go build -o bin/test-service").
 		WithDeployDir("/opt/services").
+		WithManageGitignore(true).
 		BuildWithDefaults()
 
 	gen := NewGenerator(cfg, tmpDir)
 
-	err = gen.updateGitignore()
+	err = writeRenderedFile(gen, ".gitignore")
 	require.NoError(t, err)
 
 	content, err := os.ReadFile(filepath.Join(tmpDir, ".gitignore"))
@@ -247,7 +249,7 @@ func TestGenerator_UpdateGitignore_AppendToExisting(t *testing.T) {
 	assert.Contains(t, contentStr, ".tad/")
 }
 
-func TestGenerator_UpdateGitignore_UpdateExisting(t *testing.T) {
+func TestGenerator_WriteGitignore_UpdateExisting(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	// 创建已有 .gitignore（包含旧的 svcgen block）
@@ -273,11 +275,12 @@ compose.yaml
 		WithRuntimeImage("@runtimes.alpine_3.18").
 		WithBuildCommand("go build -o bin/test-service").
 		WithDeployDir("/opt/services").
+		WithManageGitignore(true).
 		BuildWithDefaults()
 
 	gen := NewGenerator(cfg, tmpDir)
 
-	err = gen.updateGitignore()
+	err = writeRenderedFile(gen, ".gitignore")
 	require.NoError(t, err)
 
 	content, err := os.ReadFile(filepath.Join(tmpDir, ".gitignore"))
@@ -293,7 +296,7 @@ compose.yaml
 	assert.Equal(t, 1, countOccurrences(contentStr, gitignoreStartMarker))
 }
 
-func TestGenerator_UpdateGitignore_Idempotent(t *testing.T) {
+func TestGenerator_WriteGitignore_Idempotent(t *testing.T) {
 	tmpDir := t.TempDir()
 
 	cfg := configtestutil.NewConfigBuilder().
@@ -306,25 +309,26 @@ func TestGenerator_UpdateGitignore_Idempotent(t *testing.T) {
 		WithRuntimeImage("@runtimes.alpine_3.18").
 		WithBuildCommand("go build -o bin/test-service").
 		WithDeployDir("/opt/services").
+		WithManageGitignore(true).
 		BuildWithDefaults()
 
 	gen := NewGenerator(cfg, tmpDir)
 
 	// 第一次生成
-	err := gen.updateGitignore()
+	err := writeRenderedFile(gen, ".gitignore")
 	require.NoError(t, err)
 
 	content1, err := os.ReadFile(filepath.Join(tmpDir, ".gitignore"))
 	require.NoError(t, err)
 
 	// 第二次生成（应该幂等）
-	err = gen.updateGitignore()
+	err = writeRenderedFile(gen, ".gitignore")
 	require.NoError(t, err)
 
 	content2, err := os.ReadFile(filepath.Join(tmpDir, ".gitignore"))
 	require.NoError(t, err)
 
-	assert.Equal(t, string(content1), string(content2), "Running updateGitignore twice should produce the same result")
+	assert.Equal(t, string(content1), string(content2), "Writing .gitignore twice should produce the same result")
 }
 
 func TestGenerator_ManageGitignore_DefaultFalse(t *testing.T) {
@@ -185,7 +185,7 @@ func NewGeneratorTestConfig() *config.ServiceConfig {
 		WithBuildCommand("go build -o bin/app").
 		WithCIScriptDir(".tad/build/generator-test").
 		WithCIBuildConfigDir(".tad/build/generator-test/build").
-		WithMetadata("2.0.0", "svcgen").
+		WithMetadata(config.CurrentSchemaVersion, "svcgen").
 		BuildWithDefaults()
 }
 
@@ -214,7 +214,7 @@ curl -fsSL "${PLUGIN_DOWNLOAD_URL}" | bash -s "${PLUGIN_WORK_DIR}"`,
 		}).
 		WithCIScriptDir(".tad/build/generator-test").
 		WithCIBuildConfigDir(".tad/build/generator-test/build").
-		WithMetadata("2.0.0", "svcgen").
+		WithMetadata(config.CurrentSchemaVersion, "svcgen").
 		BuildWithDefaults()
 }
 
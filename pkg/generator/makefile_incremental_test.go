@@ -47,7 +47,7 @@ another-custom:
 	require.NoError(t, err, "Failed to write initial user content")
 
 	// Step 2: Generate Makefile (should append generated content with markers)
-	err = gen.generateMakefile()
+	err = writeRenderedFile(gen, "Makefile")
 	require.NoError(t, err, "Failed to generate Makefile")
 
 	// Step 3: Read the generated Makefile
@@ -72,7 +72,7 @@ another-custom:
 	firstGeneration := contentStr
 
 	// Step 8: Generate again (should be idempotent)
-	err = gen.generateMakefile()
+	err = writeRenderedFile(gen, "Makefile")
 	require.NoError(t, err, "Failed to generate Makefile second time")
 
 	// Step 9: Read the Makefile again
@@ -138,7 +138,7 @@ func TestMakefileIncrementalUpdateWithoutExisting(t *testing.T) {
 	gen := NewGenerator(cfg, tmpDir)
 
 	// Generate Makefile (no existing file)
-	err := gen.generateMakefile()
+	err := writeRenderedFile(gen, "Makefile")
 	require.NoError(t, err, "Failed to generate Makefile")
 
 	// Read the generated Makefile
@@ -157,7 +157,7 @@ func TestMakefileIncrementalUpdateWithoutExisting(t *testing.T) {
 	t.Logf("Generated Makefile without existing file: %d bytes", len(content))
 
 	// Step 2: Generate again to verify idempotency
-	err = gen.generateMakefile()
+	err = writeRenderedFile(gen, "Makefile")
 	require.NoError(t, err, "Failed to generate Makefile second time")
 
 	// Read again
@@ -205,7 +205,7 @@ my-custom-target:
 	require.NoError(t, err)
 
 	// Step 2: First generation
-	err = gen.generateMakefile()
+	err = writeRenderedFile(gen, "Makefile")
 	require.NoError(t, err)
 
 	// Step 3: Read and verify
@@ -230,7 +230,7 @@ post-generation-target:
 	require.NoError(t, err)
 
 	// Step 5: Generate again
-	err = gen.generateMakefile()
+	err = writeRenderedFile(gen, "Makefile")
 	require.NoError(t, err)
 
 	// Step 6: Verify all user content is still preserved
@@ -106,7 +106,7 @@ func TestCIPaths_GetAllScriptPaths(t *testing.T) {
 
 	// Assert: Verify all script paths are present
 	require.NotNil(t, allPaths)
-	assert.Len(t, allPaths, 6, "Should have 6 script paths")
+	assert.Len(t, allPaths, 8, "Should have 8 script paths")
 
 	// 验证所有脚本路径
 	expectedPaths := map[string]string{
@@ -116,6 +116,8 @@ func TestCIPaths_GetAllScriptPaths(t *testing.T) {
 		"entrypoint-script":    "ci/scripts/entrypoint.sh",
 		"healthcheck-script":   "ci/scripts/healthchk.sh",
 		"build-plugins-script": "ci/scripts/build_plugins.sh",
+		"test-script":          "ci/scripts/test.sh",
+		"lint-script":          "ci/scripts/lint.sh",
 	}
 
 	for key, expectedPath := range expectedPaths {
@@ -0,0 +1,294 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/generator/context"
+	"github.com/junjiewwang/service-template/pkg/generator/core"
+)
+
+// UndefinedVariable is a ${NAME} reference in a user-supplied config field that is
+// neither a generator variable nor declared as a shell/env variable
+type UndefinedVariable struct {
+	// Field is the config path, e.g. build.commands.build
+	Field string
+	Name  string
+}
+
+// String formats the reference as a validation message
+func (v UndefinedVariable) String() string {
+	return fmt.Sprintf("%s references undefined variable ${%s}", v.Field, v.Name)
+}
+
+// variableAssignment matches NAME=..., export NAME=..., local NAME=..., for NAME in, read [-r] NAME
+var variableAssignment = regexp.MustCompile(`(?:^|[\s;&|(])(?:(?:export|local|readonly|declare)\s+)?([A-Za-z_][A-Za-z0-9_]*)=|\bfor\s+([A-Za-z_][A-Za-z0-9_]*)\s+in\b|\bread\s+(?:-r\s+)?([A-Za-z_][A-Za-z0-9_]*)`)
+
+// wellKnownVariables are provided by the shell or commonly set in builder/runtime images
+var wellKnownVariables = []string{
+	"HOME", "PATH", "PWD", "OLDPWD", "USER", "UID", "HOSTNAME", "SHELL", "LANG", "TMPDIR", "IFS", "RANDOM",
+	"GOPATH", "GOPROXY", "GOFLAGS", "GOCACHE", "CGO_ENABLED",
+	"JAVA_HOME", "MAVEN_HOME", "MAVEN_OPTS",
+	"NODE_ENV", "PYTHONPATH", "VIRTUAL_ENV",
+}
+
+// userField is a config field whose value may reference ${VAR}
+type userField struct {
+	path  string
+	value string
+}
+
+// CheckVariableReferences scans user-supplied commands and values for ${VAR} references
+// that would only fail (or expand to empty strings) inside the container
+func (g *Generator) CheckVariableReferences() []UndefinedVariable {
+	known := g.knownVariables()
+
+	var undefined []UndefinedVariable
+	for _, field := range g.userFields() {
+		local := map[string]bool{}
+		for _, m := range variableAssignment.FindAllStringSubmatch(field.value, -1) {
+			for _, name := range m[1:] {
+				if name != "" {
+					local[name] = true
+				}
+			}
+		}
+
+		for _, name := range context.ReferencedVariables(field.value) {
+			if !known[name] && !local[name] {
+				undefined = append(undefined, UndefinedVariable{Field: field.path, Name: name})
+			}
+		}
+	}
+	return undefined
+}
+
+// knownVariables returns generator variables, Dockerfile build args, plugin variables,
+// declared env variables and well-known shell variables
+func (g *Generator) knownVariables() map[string]bool {
+	known := map[string]bool{}
+	for name := range g.ctx.GetVariableComposer().WithAll().WithArchitecture("amd64").Build() {
+		known[name] = true
+	}
+	for _, name := range config.BuildArgs {
+		known[name] = true
+	}
+	for _, name := range []string{
+		context.VarPluginName, context.VarPluginDescription, context.VarPluginDownloadURL,
+		context.VarPluginInstallDir, context.VarPluginRootDir, "PLUGIN_WORK_DIR",
+	} {
+		known[name] = true
+	}
+	for _, name := range wellKnownVariables {
+		known[name] = true
+	}
+
+	cfg := g.config
+	for _, env := range cfg.Runtime.Startup.Env {
+		known[env.Name] = true
+	}
+	for _, env := range cfg.LocalDev.Compose.Environment {
+		known[env.Name] = true
+	}
+	for _, plugin := range cfg.Plugins.Items {
+		for _, env := range plugin.RuntimeEnv {
+			known[env.Name] = true
+		}
+	}
+	return known
+}
+
+// userFields lists the user-supplied fields that are substituted or executed by generated files
+func (g *Generator) userFields() []userField {
+	cfg := g.config
+	fields := []userField{
+		{"build.commands.pre_build", cfg.Build.Commands.PreBuild},
+		{"build.commands.build", cfg.Build.Commands.Build},
+		{"build.commands.post_build", cfg.Build.Commands.PostBuild},
+		{"build.commands.test", cfg.Build.Commands.Test},
+		{"build.commands.lint", cfg.Build.Commands.Lint},
+	}
+	for i, pkg := range cfg.Build.Dependencies.CustomPkgs {
+		fields = append(fields, userField{fmt.Sprintf("build.dependencies.custom_pkgs[%d].install_command", i), pkg.InstallCommand})
+	}
+	for i, plugin := range cfg.Plugins.Items {
+		fields = append(fields, userField{fmt.Sprintf("plugins.items[%d].install_command", i), plugin.InstallCommand})
+		for j, env := range plugin.RuntimeEnv {
+			fields = append(fields, userField{fmt.Sprintf("plugins.items[%d].runtime_env[%d].value", i, j), env.Value})
+		}
+	}
+	fields = append(fields,
+		userField{"runtime.healthcheck.custom_script", cfg.Runtime.Healthcheck.CustomScript},
+		userField{"runtime.startup.command", cfg.Runtime.Startup.Command},
+	)
+	for i, env := range cfg.Runtime.Startup.Env {
+		fields = append(fields, userField{fmt.Sprintf("runtime.startup.env[%d].value", i), env.Value})
+	}
+	for i, vol := range cfg.LocalDev.Compose.Volumes {
+		fields = append(fields, userField{fmt.Sprintf("local_dev.compose.volumes[%d].source", i), vol.Source})
+	}
+	names := make([]string, 0, len(cfg.Vars))
+	for name := range cfg.Vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields = append(fields, userField{"vars." + name, cfg.Vars[name]})
+	}
+	return fields
+}
+
+// UserVariableErrors returns one error per invalid user-defined variable (vars:):
+// collisions with built-in variables and reference cycles
+func (g *Generator) UserVariableErrors() []error {
+	return g.ctx.VariablePool.ValidateUserVariables()
+}
+
+// ValidateUserVariables checks user-defined variables (vars:) for collisions with
+// built-in variables and for reference cycles
+func (g *Generator) ValidateUserVariables() error {
+	errs := g.UserVariableErrors()
+	if len(errs) == 0 {
+		return nil
+	}
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return fmt.Errorf("invalid vars:\n  - %s", strings.Join(messages, "\n  - "))
+}
+
+// Variable is a template variable with its resolved value
+type Variable struct {
+	Name     string      `json:"name"`
+	Category string      `json:"category"`
+	Value    interface{} `json:"value"`
+}
+
+// Categories of variables that do not come from the shared variable pool
+const (
+	// CategoryArch variables depend on the target architecture (GOARCH, BUILDER_IMAGE, ...)
+	CategoryArch = "arch"
+	// CategoryGenerator variables are added or overridden by the generator itself
+	CategoryGenerator = "generator"
+)
+
+// sharedCategories in the order VariableComposer.WithAll merges them (the first category wins)
+var sharedCategories = []string{
+	context.CategoryCommon,
+	context.CategoryBuild,
+	context.CategoryRuntime,
+	context.CategoryPlugin,
+	context.CategoryCIPaths,
+	context.CategoryService,
+	context.CategoryLanguage,
+	context.CategoryUser,
+}
+
+// archVariables are set by VariableComposer.WithArchitecture
+var archVariables = map[string]bool{
+	context.VarGOARCH: true, context.VarGOOS: true, "ARCH": true, "BUILDER_IMAGE": true, "RUNTIME_IMAGE": true,
+}
+
+// GeneratorTypes returns the registered generator types (sorted)
+func GeneratorTypes() []string {
+	types := core.DefaultRegistry.GetAll()
+	sort.Strings(types)
+	return types
+}
+
+// Variables returns the variables a generator renders its templates with, sorted by name.
+// An empty generatorType returns the shared variables every generator can compose;
+// arch selects the architecture for architecture-specific variables
+func (g *Generator) Variables(generatorType, arch string) ([]Variable, error) {
+	vars, err := g.variableMap(generatorType, arch)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	variables := make([]Variable, 0, len(names))
+	for _, name := range names {
+		variables = append(variables, Variable{Name: name, Category: g.variableCategory(name, vars[name]), Value: vars[name]})
+	}
+	return variables, nil
+}
+
+// Expand expands {{ .VAR }} template expressions and ${VAR} references in expr
+// with the variables of the given generator (see Variables)
+func (g *Generator) Expand(expr, generatorType, arch string) (string, error) {
+	vars, err := g.variableMap(generatorType, arch)
+	if err != nil {
+		return "", err
+	}
+
+	result := expr
+	if strings.Contains(expr, "{{") {
+		if result, err = core.NewTemplateEngine().Strict().RenderWithName("expr", expr, vars); err != nil {
+			return "", err
+		}
+	}
+	return context.NewSubstitutor(vars).Expand(result)
+}
+
+// variableMap returns the shared variables, or the variables recorded while running the generator
+func (g *Generator) variableMap(generatorType, arch string) (map[string]interface{}, error) {
+	if generatorType == "" {
+		return g.ctx.GetVariableComposer().WithAll().WithArchitecture(arch).Build(), nil
+	}
+
+	creator, exists := core.DefaultRegistry.Get(generatorType)
+	if !exists {
+		return nil, fmt.Errorf("unknown generator %s (available: %s)", generatorType, strings.Join(GeneratorTypes(), ", "))
+	}
+
+	ctx := context.NewGeneratorContext(g.config, g.outputDir)
+	ctx.FS = g.ctx.FS
+	recorded := map[string]interface{}{}
+	ctx.RecordVariables = func(_ string, vars map[string]interface{}) {
+		for name, value := range vars {
+			recorded[name] = value
+		}
+	}
+
+	var options []interface{}
+	if generatorType == "dockerfile" {
+		options = append(options, arch)
+	}
+	generator, err := creator(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s generator: %w", generatorType, err)
+	}
+	if _, err := generator.Generate(); err != nil {
+		return nil, fmt.Errorf("failed to run %s generator: %w", generatorType, err)
+	}
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("generator %s renders no template with this configuration", generatorType)
+	}
+	return recorded, nil
+}
+
+// variableCategory returns the shared category a variable comes from
+func (g *Generator) variableCategory(name string, value interface{}) string {
+	if archVariables[name] {
+		return CategoryArch
+	}
+	for _, category := range sharedCategories {
+		if shared, ok := g.ctx.VariablePool.GetSharedVariables(category).Get(name); ok {
+			if reflect.DeepEqual(shared, value) {
+				return category
+			}
+			break
+		}
+	}
+	return CategoryGenerator
+}
@@ -0,0 +1,248 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	configtestutil "github.com/junjiewwang/service-template/pkg/config/testutil"
+	"github.com/junjiewwang/service-template/pkg/generator/context"
+	"github.com/junjiewwang/service-template/pkg/generator/domain/services/languageservice"
+	"github.com/junjiewwang/service-template/pkg/project"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerator_CheckVariableReferences(t *testing.T) {
+	cfg := configtestutil.NewConfigBuilder().
+		WithService("test-service", "Test Service").
+		WithPort("http", 8080, "TCP", true).
+		WithLanguage("go").
+		WithPreBuildCommand("OUT=${BUILD_OUTPUT_DIR}/tmp; mkdir -p ${OUT}").
+		WithBuildCommand("go build -o ${BUILD_OUTPUT_DIR}/app ${SRC_DIR} ${HOME} $${ESCAPED} ${1}").
+		WithStartupCommand("exec ${SERVICE_BIN_DIR}/app --env ${APP_ENV:-dev} --log ${LOG_LEVEL} ${LOG_LEVEL}").
+		WithStartupEnv("APP_ENV", "prod").
+		WithPluginInstallDir("/plugins").
+		WithPlugin(config.PluginConfig{
+			Name:           "agent",
+			DownloadURL:    config.NewStaticDownloadURL("https://example.com/agent.tar.gz"),
+			InstallCommand: "for f in *.sh; do sh ${f}; done; curl ${PLUGIN_DOWNLOAD_URL} -o ${PLUGIN_WORK_DIR}/${PLUGN_NAME}",
+			RuntimeEnv:     []config.EnvironmentVariable{{Name: "AGENT_HOME", Value: "${PLUGIN_INSTALL_DIR}/agent"}},
+		}).
+		WithCustomHealthcheck("curl -f localhost:${AGENT_HOME_PORT}").
+		WithComposeVolume(config.VolumeConfig{Source: "${PWD}/data", Target: "/data", Type: "bind"}).
+		BuildWithDefaults()
+
+	undefined := NewGenerator(cfg, t.TempDir()).CheckVariableReferences()
+
+	assert.Equal(t, []UndefinedVariable{
+		{Field: "build.commands.build", Name: "SRC_DIR"},
+		{Field: "plugins.items[0].install_command", Name: "PLUGN_NAME"},
+		{Field: "runtime.healthcheck.custom_script", Name: "AGENT_HOME_PORT"},
+		{Field: "runtime.startup.command", Name: "LOG_LEVEL"},
+	}, undefined)
+	assert.Equal(t, "build.commands.build references undefined variable ${SRC_DIR}", undefined[0].String())
+}
+
+func newVariablesTestGenerator(t *testing.T) *Generator {
+	cfg := configtestutil.NewConfigBuilder().
+		WithService("test-service", "Test Service").
+		WithPort("http", 8080, "TCP", true).
+		WithLanguage("go").
+		WithStartupCommand("exec ./bin/app").
+		BuildWithDefaults()
+	return NewGenerator(cfg, t.TempDir())
+}
+
+func findVariable(variables []Variable, name string) (Variable, bool) {
+	for _, v := range variables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return Variable{}, false
+}
+
+func TestGenerator_Variables_Shared(t *testing.T) {
+	gen := newVariablesTestGenerator(t)
+
+	variables, err := gen.Variables("", "arm64")
+	require.NoError(t, err)
+	require.NotEmpty(t, variables)
+
+	for i := 1; i < len(variables); i++ {
+		assert.Less(t, variables[i-1].Name, variables[i].Name, "variables must be sorted by name")
+	}
+
+	serviceName, ok := findVariable(variables, "SERVICE_NAME")
+	require.True(t, ok)
+	assert.Equal(t, "test-service", serviceName.Value)
+	assert.Equal(t, context.CategoryCommon, serviceName.Category)
+
+	goarch, ok := findVariable(variables, context.VarGOARCH)
+	require.True(t, ok)
+	assert.Equal(t, "arm64", goarch.Value)
+	assert.Equal(t, CategoryArch, goarch.Category)
+}
+
+func TestGenerator_Variables_Generator(t *testing.T) {
+	gen := newVariablesTestGenerator(t)
+
+	compose, err := gen.Variables("compose", "amd64")
+	require.NoError(t, err)
+
+	serviceName, ok := findVariable(compose, "SERVICE_NAME")
+	require.True(t, ok)
+	assert.Equal(t, context.CategoryCommon, serviceName.Category)
+
+	var generatorOnly []string
+	for _, v := range compose {
+		if v.Category == CategoryGenerator {
+			generatorOnly = append(generatorOnly, v.Name)
+		}
+	}
+	assert.NotEmpty(t, generatorOnly, "compose adds its own variables")
+}
+
+func TestGenerator_Variables_UnknownGenerator(t *testing.T) {
+	_, err := newVariablesTestGenerator(t).Variables("nope", "amd64")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown generator nope")
+	assert.Contains(t, err.Error(), "compose")
+}
+
+func TestGenerator_Expand(t *testing.T) {
+	gen := newVariablesTestGenerator(t)
+
+	tests := []struct {
+		name      string
+		expr      string
+		generator string
+		arch      string
+		expected  string
+	}{
+		{"variable", "${SERVICE_NAME}/bin", "", "amd64", "test-service/bin"},
+		{"template", "{{ .GOARCH }}-{{ .SERVICE_NAME }}", "", "arm64", "arm64-test-service"},
+		{"escape", "$${SERVICE_NAME}", "", "amd64", "${SERVICE_NAME}"},
+		{"unknown", "${NOT_A_VARIABLE:-${SERVICE_NAME}}", "", "amd64", "${NOT_A_VARIABLE:-test-service}"},
+		{"generator", "${SERVICE_NAME}", "dockerfile", "arm64", "test-service"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := gen.Expand(tt.expr, tt.generator, tt.arch)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestGenerator_Expand_MissingTemplateVariable(t *testing.T) {
+	_, err := newVariablesTestGenerator(t).Expand("{{ .NOT_A_VARIABLE }}", "", "amd64")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "NOT_A_VARIABLE")
+}
+
+func TestGenerator_RenderUserVariables(t *testing.T) {
+	cfg := configtestutil.NewConfigBuilder().
+		WithService("test-service", "Test Service").
+		WithPort("http", 8080, "TCP", true).
+		WithLanguage("go").
+		WithVar("CONF_DIR", "${SERVICE_ROOT}/conf").
+		WithVar("APP_CONF", "${CONF_DIR}/app.yaml").
+		WithVar("TOOLS_DIR", "/opt/tools").
+		WithBuildCommand("go build -o ${BUILD_OUTPUT_DIR}/app -ldflags \"-X main.conf=${APP_CONF}\"").
+		WithStartupCommand("exec ./bin/app --config ${APP_CONF}").
+		WithCustomHealthcheck("test -f ${APP_CONF}").
+		WithCustomPackage(config.CustomPackage{Name: "tool", InstallCommand: "install.sh --prefix ${TOOLS_DIR}"}).
+		WithComposeVolume(config.VolumeConfig{Source: "./conf", Target: "${CONF_DIR}", Type: "bind"}).
+		WithCustomTarget("show-config", "Print the config path", "cat ${APP_CONF} $${HOME}").
+		WithPluginInstallDir("/plugins").
+		WithPlugin(config.PluginConfig{
+			Name:           "agent",
+			DownloadURL:    config.NewStaticDownloadURL("https://example.com/agent.sh"),
+			InstallCommand: "sh agent.sh --tools ${TOOLS_DIR}",
+			RuntimeEnv:     []config.EnvironmentVariable{{Name: "AGENT_CONF", Value: "${CONF_DIR}/agent.yaml"}},
+		}).
+		BuildWithDefaults()
+
+	gen := NewGenerator(cfg, t.TempDir())
+	assert.Empty(t, gen.CheckVariableReferences())
+
+	files, err := gen.WithStrictTemplates(true).Render()
+	require.NoError(t, err)
+	var all strings.Builder
+	contents := map[string]string{}
+	for _, file := range files {
+		contents[filepath.Base(file.Path)] = file.Content
+		all.WriteString(file.Content)
+	}
+
+	const appConf = "/usr/local/services/test-service/conf/app.yaml"
+	assert.Contains(t, contents["build.sh"], "-X main.conf="+appConf)
+	assert.Contains(t, contents["entrypoint.sh"], "--config "+appConf)
+	assert.Contains(t, contents["healthchk.sh"], "test -f "+appConf)
+	assert.Contains(t, contents["build_deps_install.sh"], "install.sh --prefix /opt/tools")
+	assert.Contains(t, contents["compose.yaml"], "./conf:/usr/local/services/test-service/conf")
+	assert.Contains(t, contents["Makefile"], "cat "+appConf+" $${HOME}")
+	assert.Contains(t, all.String(), "sh agent.sh --tools /opt/tools")
+	assert.Contains(t, all.String(), "/usr/local/services/test-service/conf/agent.yaml")
+	assert.NotContains(t, all.String(), "${CONF_DIR}")
+	assert.NotContains(t, all.String(), "${APP_CONF}")
+}
+
+func TestGenerator_ValidateUserVariables(t *testing.T) {
+	cfg := configtestutil.NewConfigBuilder().
+		WithService("test-service", "Test Service").
+		WithPort("http", 8080, "TCP", true).
+		WithLanguage("go").
+		WithStartupCommand("exec ./bin/app").
+		WithVar("SERVICE_NAME", "other").
+		WithVar("A", "${B}").
+		WithVar("B", "${A}").
+		BuildWithDefaults()
+
+	gen := NewGenerator(cfg, t.TempDir())
+	err := gen.ValidateUserVariables()
+	require.Error(t, err)
+	assert.Equal(t, "invalid vars:\n"+
+		"  - vars.A: variable reference cycle: A -> B -> A\n"+
+		"  - vars.B: variable reference cycle: B -> A -> B\n"+
+		"  - vars.SERVICE_NAME collides with the built-in variable ${SERVICE_NAME}", err.Error())
+
+	_, err = gen.Render()
+	assert.ErrorContains(t, err, "invalid vars")
+
+	variables, err := newVariablesTestGenerator(t).Variables("", "amd64")
+	require.NoError(t, err)
+	_, ok := findVariable(variables, "A")
+	assert.False(t, ok)
+}
+
+func TestGenerator_CheckVariableReferences_InitConfig(t *testing.T) {
+	// Mirrors svcgen init: detect the project and fill in the language's default build command
+	p, err := project.Detect(fstest.MapFS{
+		"go.mod": &fstest.MapFile{Data: []byte("module example.com/demo\n\ngo 1.23\n")},
+	}, "demo")
+	require.NoError(t, err)
+	cfg := &config.ServiceConfig{Language: p.LanguageSettings()}
+	p.BuildCommand = languageservice.NewLanguageService(context.NewGeneratorContext(cfg, t.TempDir())).
+		GetDefaultBuildCommand(p.Language)
+	require.Contains(t, p.BuildCommand, "${"+config.BuildArgVersion+":-dev}", "default Go build stamps the version")
+
+	content, err := p.Render()
+	require.NoError(t, err)
+	cfg, err = config.LoadFromBytes([]byte(content))
+	require.NoError(t, err)
+
+	validator := config.NewValidator(cfg)
+	require.NoError(t, validator.Validate())
+	assert.Empty(t, validator.Warnings())
+
+	gen := NewGenerator(cfg, t.TempDir()).WithStrictTemplates(true)
+	_, err = gen.Render()
+	require.NoError(t, err)
+	assert.Empty(t, gen.CheckVariableReferences())
+	assert.Empty(t, gen.Warnings())
+}
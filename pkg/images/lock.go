@@ -0,0 +1,27 @@
+package images
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+)
+
+// Lock 解析所有镜像引用的 digest，生成锁定信息
+func Lock(ctx context.Context, resolver DigestResolver, refs []string) (*config.ImageLock, error) {
+	lock := &config.ImageLock{Images: make(map[string]string, len(refs))}
+
+	for _, raw := range refs {
+		ref, err := ParseReference(raw)
+		if err != nil {
+			return nil, err
+		}
+		digest, err := resolver.ResolveDigest(ctx, ref)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve digest for %s: %w", raw, err)
+		}
+		lock.Images[raw] = digest
+	}
+
+	return lock, nil
+}
@@ -0,0 +1,92 @@
+package images
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// DockerHubRegistry Docker Hub 的 registry v2 API 地址
+	DockerHubRegistry = "registry-1.docker.io"
+	defaultTag        = "latest"
+)
+
+// Reference 解析后的镜像引用
+type Reference struct {
+	Registry   string // registry 主机（可带端口），如 mirrors.tencent.com、localhost:5000
+	Repository string // 仓库路径，如 library/alpine、tencentos/tencentos3-minimal
+	Tag        string // tag，未指定时为 latest
+	Digest     string // digest（sha256:...），未指定时为空
+}
+
+// ParseReference 解析镜像引用
+// 支持 name、name:tag、name@digest、registry:port/name:tag 等格式，Docker Hub 镜像会补全 library/ 前缀
+func ParseReference(ref string) (Reference, error) {
+	if ref == "" {
+		return Reference{}, fmt.Errorf("empty image reference")
+	}
+
+	var r Reference
+	name := ref
+	if i := strings.Index(name, "@"); i >= 0 {
+		r.Digest = name[i+1:]
+		name = name[:i]
+		if !strings.Contains(r.Digest, ":") {
+			return Reference{}, fmt.Errorf("invalid digest in image reference: %s", ref)
+		}
+	}
+
+	// tag 只能出现在最后一个 "/" 之后，避免把 registry 端口误判为 tag
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		r.Tag = name[i+1:]
+		name = name[:i]
+	}
+	if r.Tag == "" {
+		r.Tag = defaultTag
+	}
+
+	// 第一段包含 "." 或 ":" 或为 localhost 时视为 registry 主机
+	if i := strings.Index(name, "/"); i >= 0 {
+		host := name[:i]
+		if strings.ContainsAny(host, ".:") || host == "localhost" {
+			r.Registry = host
+			name = name[i+1:]
+		}
+	}
+	if r.Registry == "" || r.Registry == "docker.io" || r.Registry == "index.docker.io" {
+		r.Registry = DockerHubRegistry
+		if !strings.Contains(name, "/") {
+			name = "library/" + name
+		}
+	}
+
+	if name == "" {
+		return Reference{}, fmt.Errorf("invalid image reference: %s", ref)
+	}
+	r.Repository = name
+	return r, nil
+}
+
+// String 返回 registry/repository:tag[@digest] 形式的完整引用
+func (r Reference) String() string {
+	s := r.Registry + "/" + r.Repository + ":" + r.Tag
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// SplitTag 将镜像引用拆分为名称和 tag（保留原始 registry 写法，不做规范化）
+// digest 会附加在 tag 之后（如 "3.19@sha256:..."），使 ${NAME}:${TAG} 仍是合法且锁定的引用
+func SplitTag(ref string) (name, tag string) {
+	name, digest, _ := strings.Cut(ref, "@")
+
+	tag = defaultTag
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	if digest != "" {
+		tag += "@" + digest
+	}
+	return name, tag
+}
@@ -0,0 +1,62 @@
+package images
+
+import "testing"
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want Reference
+	}{
+		{"alpine", Reference{Registry: DockerHubRegistry, Repository: "library/alpine", Tag: "latest"}},
+		{"golang:1.23-alpine", Reference{Registry: DockerHubRegistry, Repository: "library/golang", Tag: "1.23-alpine"}},
+		{"bitnami/redis:7", Reference{Registry: DockerHubRegistry, Repository: "bitnami/redis", Tag: "7"}},
+		{"docker.io/library/alpine:3.19", Reference{Registry: DockerHubRegistry, Repository: "library/alpine", Tag: "3.19"}},
+		{
+			"mirrors.tencent.com/tencentos/tencentos3-minimal:latest",
+			Reference{Registry: "mirrors.tencent.com", Repository: "tencentos/tencentos3-minimal", Tag: "latest"},
+		},
+		{"localhost:5000/team/app", Reference{Registry: "localhost:5000", Repository: "team/app", Tag: "latest"}},
+		{
+			"gcr.io/distroless/static-debian12:nonroot@sha256:abc",
+			Reference{Registry: "gcr.io", Repository: "distroless/static-debian12", Tag: "nonroot", Digest: "sha256:abc"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := ParseReference(tt.ref)
+			if err != nil {
+				t.Fatalf("ParseReference(%q) unexpected error: %v", tt.ref, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseReference(%q) = %+v, want %+v", tt.ref, got, tt.want)
+			}
+		})
+	}
+
+	for _, bad := range []string{"", "alpine@nodigest"} {
+		if _, err := ParseReference(bad); err == nil {
+			t.Errorf("ParseReference(%q) expected error", bad)
+		}
+	}
+}
+
+func TestSplitTag(t *testing.T) {
+	tests := []struct {
+		ref      string
+		wantName string
+		wantTag  string
+	}{
+		{"alpine", "alpine", "latest"},
+		{"alpine:3.19", "alpine", "3.19"},
+		{"localhost:5000/app", "localhost:5000/app", "latest"},
+		{"localhost:5000/app:1.0@sha256:abc", "localhost:5000/app", "1.0@sha256:abc"},
+	}
+
+	for _, tt := range tests {
+		name, tag := SplitTag(tt.ref)
+		if name != tt.wantName || tag != tt.wantTag {
+			t.Errorf("SplitTag(%q) = (%q, %q), want (%q, %q)", tt.ref, name, tag, tt.wantName, tt.wantTag)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package images
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DigestResolver 将镜像引用解析为 manifest digest
+// 抽象为接口以便在测试中替换为本地 registry 或假实现
+type DigestResolver interface {
+	ResolveDigest(ctx context.Context, ref Reference) (string, error)
+}
+
+// manifestMediaTypes 请求 manifest 时接受的类型
+// 优先返回 manifest list / OCI index，保证 multi-arch 镜像的 digest 对所有架构有效
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// RegistryClient 基于 registry v2 API 的 DigestResolver 实现
+// 支持匿名 Bearer token 认证（Docker Hub 等公开仓库）
+type RegistryClient struct {
+	httpClient *http.Client
+	plainHTTP  map[string]bool
+}
+
+// RegistryOption 配置 RegistryClient
+type RegistryOption func(*RegistryClient)
+
+// WithHTTPClient 使用自定义 http.Client
+func WithHTTPClient(client *http.Client) RegistryOption {
+	return func(c *RegistryClient) {
+		c.httpClient = client
+	}
+}
+
+// WithPlainHTTP 指定使用 http（非 https）访问的 registry 主机
+func WithPlainHTTP(hosts ...string) RegistryOption {
+	return func(c *RegistryClient) {
+		for _, host := range hosts {
+			c.plainHTTP[host] = true
+		}
+	}
+}
+
+// NewRegistryClient 创建 registry 客户端
+func NewRegistryClient(opts ...RegistryOption) *RegistryClient {
+	c := &RegistryClient{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		plainHTTP:  make(map[string]bool),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// ResolveDigest 查询镜像 tag 对应的 manifest digest
+func (c *RegistryClient) ResolveDigest(ctx context.Context, ref Reference) (string, error) {
+	if ref.Digest != "" {
+		return ref.Digest, nil
+	}
+
+	manifestURL := fmt.Sprintf("%s://%s/v2/%s/manifests/%s", c.scheme(ref.Registry), ref.Registry, ref.Repository, ref.Tag)
+
+	resp, err := c.do(ctx, http.MethodHead, manifestURL, ref, "")
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	token := ""
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, err = c.fetchToken(ctx, resp.Header.Get("WWW-Authenticate"), ref)
+		if err != nil {
+			return "", err
+		}
+		resp, err = c.do(ctx, http.MethodHead, manifestURL, ref, token)
+		if err != nil {
+			return "", err
+		}
+		resp.Body.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: registry returned %s", ref, resp.Status)
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+
+	// 部分 registry 的 HEAD 响应不带 digest，退化为 GET 并自行计算
+	resp, err = c.do(ctx, http.MethodGet, manifestURL, ref, token)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: registry returned %s", ref, resp.Status)
+	}
+	if digest := resp.Header.Get("Docker-Content-Digest"); digest != "" {
+		return digest, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to read manifest: %w", ref, err)
+	}
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(body)), nil
+}
+
+// do 发送 manifest 请求
+func (c *RegistryClient) do(ctx context.Context, method, rawURL string, ref Reference, token string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", strings.Join(manifestMediaTypes, ", "))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ref, err)
+	}
+	return resp, nil
+}
+
+// fetchToken 按 WWW-Authenticate 质询匿名获取 Bearer token
+func (c *RegistryClient) fetchToken(ctx context.Context, challenge string, ref Reference) (string, error) {
+	scheme, params := parseChallenge(challenge)
+	if !strings.EqualFold(scheme, "bearer") || params["realm"] == "" {
+		return "", fmt.Errorf("%s: unsupported registry authentication %q", ref, challenge)
+	}
+
+	query := url.Values{}
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", ref.Repository)
+	}
+	query.Set("scope", scope)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, params["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to fetch token: %w", ref, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: token endpoint returned %s", ref, resp.Status)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("%s: failed to decode token: %w", ref, err)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	return body.AccessToken, nil
+}
+
+// scheme 返回访问 registry 使用的协议，localhost 默认使用 http
+func (c *RegistryClient) scheme(host string) string {
+	hostname := host
+	if i := strings.LastIndex(host, ":"); i >= 0 {
+		hostname = host[:i]
+	}
+	if c.plainHTTP[host] || hostname == "localhost" || hostname == "127.0.0.1" {
+		return "http"
+	}
+	return "https"
+}
+
+// parseChallenge 解析 WWW-Authenticate 头，如 Bearer realm="...",service="...",scope="..."
+func parseChallenge(header string) (string, map[string]string) {
+	params := make(map[string]string)
+	scheme, rest, _ := strings.Cut(strings.TrimSpace(header), " ")
+	for _, part := range splitChallengeParams(rest) {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		params[strings.ToLower(strings.TrimSpace(key))] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return scheme, params
+}
+
+// splitChallengeParams 按逗号拆分参数，忽略引号内的逗号（scope 可能包含多个动作，如 pull,push）
+func splitChallengeParams(s string) []string {
+	var parts []string
+	var current strings.Builder
+	inQuotes := false
+	for _, ch := range s {
+		switch {
+		case ch == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(ch)
+		case ch == ',' && !inQuotes:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(ch)
+		}
+	}
+	if current.Len() > 0 {
+		parts = append(parts, current.String())
+	}
+	return parts
+}
@@ -0,0 +1,138 @@
+package images
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// fakeRegistry 模拟 registry v2 API：Bearer token 认证 + manifest 查询
+type fakeRegistry struct {
+	manifests      map[string]string // "repo:tag" → manifest body
+	omitHeadDigest bool
+	tokenRequests  int
+}
+
+func (f *fakeRegistry) handler(serverURL func() string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		f.tokenRequests++
+		if !strings.HasPrefix(r.URL.Query().Get("scope"), "repository:") {
+			http.Error(w, "missing scope", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"token":"test-token"}`)
+	})
+	mux.HandleFunc("/v2/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="fake",scope="repository:x:pull,push"`, serverURL()))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if !strings.Contains(r.Header.Get("Accept"), "manifest.list.v2+json") {
+			http.Error(w, "missing accept header", http.StatusBadRequest)
+			return
+		}
+
+		path := strings.TrimPrefix(r.URL.Path, "/v2/")
+		repo, tag, _ := strings.Cut(path, "/manifests/")
+		body, ok := f.manifests[repo+":"+tag]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if !(f.omitHeadDigest && r.Method == http.MethodHead) {
+			w.Header().Set("Docker-Content-Digest", fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(body))))
+		}
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, body)
+		}
+	})
+	return mux
+}
+
+func newFakeRegistryServer(t *testing.T, f *fakeRegistry) (*httptest.Server, string) {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(f.handler(func() string { return server.URL }))
+	t.Cleanup(server.Close)
+	return server, strings.TrimPrefix(server.URL, "http://")
+}
+
+func TestRegistryClient_ResolveDigest(t *testing.T) {
+	manifest := `{"schemaVersion":2}`
+	want := fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(manifest)))
+
+	for _, omitHeadDigest := range []bool{false, true} {
+		t.Run(fmt.Sprintf("omitHeadDigest=%v", omitHeadDigest), func(t *testing.T) {
+			f := &fakeRegistry{manifests: map[string]string{"team/app:1.0": manifest}, omitHeadDigest: omitHeadDigest}
+			server, host := newFakeRegistryServer(t, f)
+
+			client := NewRegistryClient(WithHTTPClient(server.Client()), WithPlainHTTP(host))
+			ref, err := ParseReference(host + "/team/app:1.0")
+			if err != nil {
+				t.Fatalf("ParseReference() error: %v", err)
+			}
+
+			got, err := client.ResolveDigest(context.Background(), ref)
+			if err != nil {
+				t.Fatalf("ResolveDigest() error: %v", err)
+			}
+			if got != want {
+				t.Errorf("ResolveDigest() = %s, want %s", got, want)
+			}
+			if f.tokenRequests != 1 {
+				t.Errorf("expected 1 token request, got %d", f.tokenRequests)
+			}
+		})
+	}
+}
+
+func TestRegistryClient_ResolveDigest_NotFound(t *testing.T) {
+	f := &fakeRegistry{manifests: map[string]string{}}
+	server, host := newFakeRegistryServer(t, f)
+
+	client := NewRegistryClient(WithHTTPClient(server.Client()), WithPlainHTTP(host))
+	ref, _ := ParseReference(host + "/team/missing:1.0")
+
+	if _, err := client.ResolveDigest(context.Background(), ref); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("ResolveDigest() error = %v, want 404 error", err)
+	}
+}
+
+// staticResolver 测试用 DigestResolver
+type staticResolver map[string]string
+
+func (s staticResolver) ResolveDigest(_ context.Context, ref Reference) (string, error) {
+	digest, ok := s[ref.Repository+":"+ref.Tag]
+	if !ok {
+		return "", fmt.Errorf("unknown image %s", ref)
+	}
+	return digest, nil
+}
+
+func TestLock(t *testing.T) {
+	resolver := staticResolver{
+		"library/alpine:3.19":        "sha256:aaa",
+		"library/golang:1.23-alpine": "sha256:bbb",
+	}
+
+	lock, err := Lock(context.Background(), resolver, []string{"alpine:3.19", "golang:1.23-alpine"})
+	if err != nil {
+		t.Fatalf("Lock() error: %v", err)
+	}
+	if lock.Images["alpine:3.19"] != "sha256:aaa" || lock.Images["golang:1.23-alpine"] != "sha256:bbb" {
+		t.Errorf("Lock() = %v", lock.Images)
+	}
+	if got := lock.Pin("alpine:3.19"); got != "alpine:3.19@sha256:aaa" {
+		t.Errorf("Pin() = %s", got)
+	}
+
+	if _, err := Lock(context.Background(), resolver, []string{"unknown:1"}); err == nil {
+		t.Error("Lock() expected error for unresolvable image")
+	}
+}
@@ -0,0 +1,149 @@
+package importer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ComposeFile compose 文件中与导入相关的部分
+type ComposeFile struct {
+	Services map[string]*ComposeService `yaml:"services"`
+}
+
+// ComposeService compose 中的单个服务
+// 字段类型为 interface{} 的项同时支持 compose 的列表与映射两种写法
+type ComposeService struct {
+	Image       string                 `yaml:"image"`
+	Build       interface{}            `yaml:"build"`
+	Ports       []interface{}          `yaml:"ports"`
+	Expose      []interface{}          `yaml:"expose"`
+	Environment interface{}            `yaml:"environment"`
+	Volumes     []interface{}          `yaml:"volumes"`
+	Labels      interface{}            `yaml:"labels"`
+	Entrypoint  interface{}            `yaml:"entrypoint"`
+	Command     interface{}            `yaml:"command"`
+	Healthcheck *ComposeHealth         `yaml:"healthcheck"`
+	Deploy      *ComposeDeploy         `yaml:"deploy"`
+	Other       map[string]interface{} `yaml:",inline"`
+}
+
+// ComposeHealth compose 健康检查
+type ComposeHealth struct {
+	Test        interface{} `yaml:"test"`
+	Interval    string      `yaml:"interval"`
+	Timeout     string      `yaml:"timeout"`
+	Retries     int         `yaml:"retries"`
+	StartPeriod string      `yaml:"start_period"`
+	Disable     bool        `yaml:"disable"`
+}
+
+// ComposeDeploy compose deploy 配置（只导入 resources）
+type ComposeDeploy struct {
+	Resources struct {
+		Limits       ComposeResources `yaml:"limits"`
+		Reservations ComposeResources `yaml:"reservations"`
+	} `yaml:"resources"`
+	Other map[string]interface{} `yaml:",inline"`
+}
+
+// ComposeResources CPU / 内存限制
+type ComposeResources struct {
+	CPUs   interface{} `yaml:"cpus"`
+	Memory string      `yaml:"memory"`
+}
+
+// ParseCompose 解析 compose 文件内容
+func ParseCompose(content string) (*ComposeFile, error) {
+	var compose ComposeFile
+	if err := yaml.Unmarshal([]byte(content), &compose); err != nil {
+		return nil, fmt.Errorf("failed to parse compose file: %w", err)
+	}
+	if len(compose.Services) == 0 {
+		return nil, fmt.Errorf("compose file defines no services")
+	}
+	return &compose, nil
+}
+
+// ServiceNames 返回排序后的服务名
+func (c *ComposeFile) ServiceNames() []string {
+	names := make([]string, 0, len(c.Services))
+	for name := range c.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SelectService 选择要导入的服务
+// 未指定名称时：只有一个服务则选择它，否则选择唯一带 build 的服务
+func (c *ComposeFile) SelectService(name string) (string, *ComposeService, error) {
+	if name != "" {
+		svc, ok := c.Services[name]
+		if !ok {
+			return "", nil, fmt.Errorf("service '%s' not found in compose file (available: %s)",
+				name, strings.Join(c.ServiceNames(), ", "))
+		}
+		return name, svc, nil
+	}
+
+	if len(c.Services) == 1 {
+		for n, svc := range c.Services {
+			return n, svc, nil
+		}
+	}
+
+	var built []string
+	for _, n := range c.ServiceNames() {
+		if c.Services[n].Build != nil {
+			built = append(built, n)
+		}
+	}
+	if len(built) == 1 {
+		return built[0], c.Services[built[0]], nil
+	}
+	return "", nil, fmt.Errorf("compose file defines several services (%s); choose one with --service",
+		strings.Join(c.ServiceNames(), ", "))
+}
+
+// stringMap 将 compose 的 map 或 "KEY=value" 列表统一为有序键值对
+func stringMap(value interface{}) [][2]string {
+	var pairs [][2]string
+	switch v := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			val := ""
+			if v[k] != nil {
+				val = fmt.Sprint(v[k])
+			}
+			pairs = append(pairs, [2]string{k, val})
+		}
+	case []interface{}:
+		for _, item := range v {
+			key, val, _ := strings.Cut(fmt.Sprint(item), "=")
+			pairs = append(pairs, [2]string{key, val})
+		}
+	}
+	return pairs
+}
+
+// stringList 将 compose 的字符串或列表统一为列表；isList 表示原始值为 exec 形式
+func stringList(value interface{}) (list []string, isList bool) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, false
+	case []interface{}:
+		for _, item := range v {
+			list = append(list, fmt.Sprint(item))
+		}
+		return list, true
+	}
+	return nil, false
+}
@@ -0,0 +1,89 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompose(t *testing.T) {
+	compose, err := ParseCompose(`
+services:
+  web:
+    build: .
+    environment: ["A=1", "B"]
+    networks: [backend]
+  db:
+    image: postgres:16
+`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"db", "web"}, compose.ServiceNames())
+	assert.Contains(t, compose.Services["web"].Other, "networks")
+	assert.Equal(t, [][2]string{{"A", "1"}, {"B", ""}}, stringMap(compose.Services["web"].Environment))
+
+	_, err = ParseCompose("version: '3'\n")
+	assert.ErrorContains(t, err, "no services")
+}
+
+func TestComposeFile_SelectService(t *testing.T) {
+	tests := []struct {
+		name     string
+		services map[string]*ComposeService
+		selected string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "single service",
+			services: map[string]*ComposeService{"app": {Image: "app:1"}},
+			want:     "app",
+		},
+		{
+			name:     "single service with build",
+			services: map[string]*ComposeService{"app": {Build: "."}, "db": {Image: "postgres"}},
+			want:     "app",
+		},
+		{
+			name:     "explicit service",
+			services: map[string]*ComposeService{"app": {Build: "."}, "db": {Image: "postgres"}},
+			selected: "db",
+			want:     "db",
+		},
+		{
+			name:     "unknown service",
+			services: map[string]*ComposeService{"app": {Build: "."}},
+			selected: "web",
+			wantErr:  "available: app",
+		},
+		{
+			name:     "ambiguous",
+			services: map[string]*ComposeService{"a": {Build: "."}, "b": {Build: "."}},
+			wantErr:  "--service",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compose := &ComposeFile{Services: tt.services}
+			name, svc, err := compose.SelectService(tt.selected)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, name)
+			assert.Same(t, tt.services[tt.want], svc)
+		})
+	}
+}
+
+func TestStringList(t *testing.T) {
+	list, isList := stringList("./app --debug")
+	assert.Equal(t, []string{"./app --debug"}, list)
+	assert.False(t, isList)
+
+	list, isList = stringList([]interface{}{"./app", 8080})
+	assert.Equal(t, []string{"./app", "8080"}, list)
+	assert.True(t, isList)
+}
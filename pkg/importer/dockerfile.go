@@ -0,0 +1,238 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Instruction Dockerfile 中的一条指令（续行已合并）
+type Instruction struct {
+	// Command 大写的指令名，如 FROM / RUN
+	Command string
+	// Args 指令参数原文
+	Args string
+	// Line 指令起始行号（从 1 开始）
+	Line int
+}
+
+// Stage 多阶段构建中的一个阶段
+type Stage struct {
+	// Name AS 后的阶段名（可能为空）
+	Name string
+	// Image FROM 的镜像（已替换全局 ARG 默认值）
+	Image string
+	// From FROM 指令本身
+	From Instruction
+	// Instructions FROM 之后的指令
+	Instructions []Instruction
+}
+
+// Dockerfile 解析结果
+type Dockerfile struct {
+	// Args 第一个 FROM 之前声明的 ARG 及其默认值
+	Args   map[string]string
+	Stages []*Stage
+}
+
+var (
+	fromPattern   = regexp.MustCompile(`(?i)^(?:--platform=\S+\s+)?(\S+)(?:\s+AS\s+(\S+))?\s*$`)
+	argRefPattern = regexp.MustCompile(`\$\{?([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}?`)
+)
+
+// ParseDockerfile 解析 Dockerfile 内容：合并续行、跳过注释与解析器指令，按 FROM 拆分阶段
+func ParseDockerfile(content string) (*Dockerfile, error) {
+	df := &Dockerfile{Args: map[string]string{}}
+
+	for _, inst := range splitInstructions(content) {
+		switch {
+		case inst.Command == "FROM":
+			m := fromPattern.FindStringSubmatch(inst.Args)
+			if m == nil {
+				return nil, fmt.Errorf("line %d: invalid FROM instruction: %s", inst.Line, inst.Args)
+			}
+			df.Stages = append(df.Stages, &Stage{
+				Name:  m[2],
+				Image: df.expandArgs(m[1]),
+				From:  inst,
+			})
+		case len(df.Stages) == 0:
+			if inst.Command != "ARG" {
+				return nil, fmt.Errorf("line %d: %s before the first FROM", inst.Line, inst.Command)
+			}
+			name, value, _ := strings.Cut(inst.Args, "=")
+			df.Args[strings.TrimSpace(name)] = strings.Trim(strings.TrimSpace(value), `"'`)
+		default:
+			stage := df.Stages[len(df.Stages)-1]
+			stage.Instructions = append(stage.Instructions, inst)
+		}
+	}
+
+	if len(df.Stages) == 0 {
+		return nil, fmt.Errorf("no FROM instruction found")
+	}
+	return df, nil
+}
+
+// FinalStage 返回最后一个阶段（最终镜像）
+func (d *Dockerfile) FinalStage() *Stage {
+	return d.Stages[len(d.Stages)-1]
+}
+
+// Stage 按名称查找阶段
+func (d *Dockerfile) Stage(name string) *Stage {
+	for _, s := range d.Stages {
+		if s.Name != "" && strings.EqualFold(s.Name, name) {
+			return s
+		}
+	}
+	return nil
+}
+
+// BaseImage 返回阶段最终依赖的外部镜像（FROM 另一个阶段时向上追溯）
+func (d *Dockerfile) BaseImage(stage *Stage) string {
+	for i := 0; i < len(d.Stages); i++ {
+		parent := d.Stage(stage.Image)
+		if parent == nil || parent == stage {
+			break
+		}
+		stage = parent
+	}
+	return stage.Image
+}
+
+// BuilderStage 返回构建阶段：最终阶段 COPY --from 引用的阶段，否则为第一个阶段
+// 单阶段 Dockerfile 没有构建阶段，返回 nil
+func (d *Dockerfile) BuilderStage() *Stage {
+	if len(d.Stages) < 2 {
+		return nil
+	}
+	for _, inst := range d.FinalStage().Instructions {
+		if inst.Command != "COPY" {
+			continue
+		}
+		for _, field := range strings.Fields(inst.Args) {
+			if from, ok := strings.CutPrefix(field, "--from="); ok {
+				if stage := d.Stage(from); stage != nil {
+					return stage
+				}
+			}
+		}
+	}
+	return d.Stages[0]
+}
+
+// expandArgs 用全局 ARG 默认值替换 FROM 中的 $VAR / ${VAR} / ${VAR:-default}
+func (d *Dockerfile) expandArgs(s string) string {
+	return argRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		m := argRefPattern.FindStringSubmatch(ref)
+		if v, ok := d.Args[m[1]]; ok && v != "" {
+			return v
+		}
+		return m[2]
+	})
+}
+
+// splitInstructions 拆分指令：合并以 "\" 结尾的续行，忽略空行与注释
+func splitInstructions(content string) []Instruction {
+	var instructions []Instruction
+	var current strings.Builder
+	start := 0
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i, raw := range lines {
+		line := strings.TrimSpace(raw)
+		if current.Len() == 0 && (line == "" || strings.HasPrefix(line, "#")) {
+			continue
+		}
+		if current.Len() > 0 && strings.HasPrefix(line, "#") {
+			// 续行中间的注释行被 Docker 忽略
+			continue
+		}
+		if current.Len() == 0 {
+			start = i + 1
+		}
+
+		if cont, ok := strings.CutSuffix(line, "\\"); ok {
+			current.WriteString(strings.TrimSpace(cont))
+			current.WriteString(" ")
+			continue
+		}
+		current.WriteString(line)
+
+		command, args, _ := strings.Cut(strings.TrimSpace(current.String()), " ")
+		instructions = append(instructions, Instruction{
+			Command: strings.ToUpper(command),
+			Args:    strings.TrimSpace(args),
+			Line:    start,
+		})
+		current.Reset()
+	}
+	return instructions
+}
+
+// parseExecForm 解析 JSON 数组形式的参数（如 CMD ["./app", "--port", "8080"]）
+func parseExecForm(args string) ([]string, bool) {
+	if !strings.HasPrefix(strings.TrimSpace(args), "[") {
+		return nil, false
+	}
+	var list []string
+	if err := json.Unmarshal([]byte(args), &list); err != nil {
+		return nil, false
+	}
+	return list, true
+}
+
+// parseEnv 解析 ENV 指令：支持 "KEY=value KEY2=value2" 与旧格式 "KEY value"
+func parseEnv(args string) [][2]string {
+	fields := splitShellWords(args)
+	if len(fields) == 0 {
+		return nil
+	}
+	if !strings.Contains(fields[0], "=") {
+		_, value, _ := strings.Cut(args, fields[0])
+		return [][2]string{{fields[0], strings.TrimSpace(value)}}
+	}
+
+	var pairs [][2]string
+	for _, field := range fields {
+		key, value, _ := strings.Cut(field, "=")
+		pairs = append(pairs, [2]string{key, value})
+	}
+	return pairs
+}
+
+// splitShellWords 按空白拆分，支持单/双引号（引号本身被去除）
+func splitShellWords(s string) []string {
+	var words []string
+	var current strings.Builder
+	var quote rune
+	inWord := false
+	for _, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words
+}
@@ -0,0 +1,78 @@
+package importer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const multiStageDockerfile = `# syntax=docker/dockerfile:1
+ARG GO_VERSION=1.22
+FROM golang:${GO_VERSION}-alpine AS build
+WORKDIR /src
+RUN go build \
+    # inline comment
+    -o /out/app ./cmd/app
+
+FROM build AS test
+RUN go test ./...
+
+FROM alpine:3.19
+COPY --from=build /out/app /app
+CMD ["/app"]
+`
+
+func TestParseDockerfile(t *testing.T) {
+	df, err := ParseDockerfile(multiStageDockerfile)
+	require.NoError(t, err)
+
+	require.Len(t, df.Stages, 3)
+	assert.Equal(t, "1.22", df.Args["GO_VERSION"])
+	assert.Equal(t, "golang:1.22-alpine", df.Stages[0].Image)
+	assert.Equal(t, "build", df.Stages[0].Name)
+	assert.Equal(t, 3, df.Stages[0].From.Line)
+
+	require.Len(t, df.Stages[0].Instructions, 2)
+	run := df.Stages[0].Instructions[1]
+	assert.Equal(t, "RUN", run.Command)
+	assert.Equal(t, "go build -o /out/app ./cmd/app", run.Args)
+	assert.Equal(t, 5, run.Line)
+
+	assert.Equal(t, "alpine:3.19", df.FinalStage().Image)
+	assert.Equal(t, "golang:1.22-alpine", df.BaseImage(df.Stage("test")))
+	assert.Same(t, df.Stages[0], df.BuilderStage())
+}
+
+func TestParseDockerfile_Errors(t *testing.T) {
+	_, err := ParseDockerfile("# only a comment\n")
+	assert.ErrorContains(t, err, "no FROM")
+
+	_, err = ParseDockerfile("RUN echo hi\nFROM alpine\n")
+	assert.ErrorContains(t, err, "before the first FROM")
+}
+
+func TestDockerfile_BuilderStage(t *testing.T) {
+	df, err := ParseDockerfile("FROM alpine:3.19\nCMD [\"/app\"]\n")
+	require.NoError(t, err)
+	assert.Nil(t, df.BuilderStage(), "single-stage Dockerfile has no builder stage")
+
+	df, err = ParseDockerfile("FROM node:20 AS deps\nFROM golang:1.23 AS compile\nFROM alpine\nCOPY --from=compile /app /app\n")
+	require.NoError(t, err)
+	assert.Equal(t, "compile", df.BuilderStage().Name)
+}
+
+func TestParseEnv(t *testing.T) {
+	assert.Equal(t, [][2]string{{"A", "1"}, {"B", "two words"}}, parseEnv(`A=1 B="two words"`))
+	assert.Equal(t, [][2]string{{"PATH", "/usr/local/bin:/usr/bin"}}, parseEnv("PATH /usr/local/bin:/usr/bin"))
+	assert.Nil(t, parseEnv(""))
+}
+
+func TestParseExecForm(t *testing.T) {
+	list, ok := parseExecForm(`["./app", "--port", "8080"]`)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"./app", "--port", "8080"}, list)
+
+	_, ok = parseExecForm("./app --port 8080")
+	assert.False(t, ok)
+}
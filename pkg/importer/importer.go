@@ -0,0 +1,638 @@
+// Package importer 将已有的 Dockerfile / docker-compose.yaml 映射为 service.yaml 配置
+// 无法映射的指令与配置项会记录在报告中，由用户手动迁移
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/project"
+	"gopkg.in/yaml.v3"
+)
+
+// Options 导入参数
+type Options struct {
+	// DockerfilePath Dockerfile 路径（可选）
+	DockerfilePath string
+	// ComposePath compose 文件路径（可选）
+	ComposePath string
+	// ComposeService 要导入的 compose 服务名（compose 中有多个服务时必需）
+	ComposeService string
+	// Name 服务名（默认取 compose 服务名或项目目录名）
+	Name string
+	// Language 语言类型（默认检测项目目录或根据构建镜像推断）
+	Language string
+	// ProjectDir 项目目录，用于检测语言
+	ProjectDir string
+}
+
+// Finding 一个未能映射的构造
+type Finding struct {
+	// Source 来源位置，如 "Dockerfile:12" 或 "compose.yaml services.app.networks"
+	Source string
+	// Construct 原始内容
+	Construct string
+	// Reason 未映射原因或迁移建议
+	Reason string
+}
+
+// String 格式化为报告行
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s — %s", f.Source, f.Construct, f.Reason)
+}
+
+// Result 导入结果
+type Result struct {
+	Config *config.ServiceConfig
+	// Unmapped 无法映射的构造
+	Unmapped []Finding
+}
+
+// importer 导入过程状态
+type importer struct {
+	opts   Options
+	cfg    *config.ServiceConfig
+	result *Result
+	ports  []project.Port
+	seen   map[string]bool
+
+	builderImage string
+	hasStartup   bool
+	hasHealth    bool
+}
+
+// Import 解析 Dockerfile / compose 文件并生成服务配置
+func Import(opts Options) (*Result, error) {
+	if opts.DockerfilePath == "" && opts.ComposePath == "" {
+		return nil, fmt.Errorf("at least one of a Dockerfile or a compose file is required")
+	}
+	if opts.ProjectDir == "" {
+		opts.ProjectDir = "."
+	}
+
+	cfg := &config.ServiceConfig{
+		Service:  config.ServiceInfo{Name: opts.Name, DeployDir: "/usr/local/services"},
+		Build:    config.BuildConfig{DependencyFiles: config.DependencyFilesConfig{AutoDetect: true}},
+		Metadata: config.MetadataConfig{TemplateVersion: config.CurrentSchemaVersion, Generator: "svcgen"},
+	}
+	imp := &importer{opts: opts, cfg: cfg, result: &Result{Config: cfg}, seen: map[string]bool{}}
+
+	if opts.DockerfilePath != "" {
+		data, err := os.ReadFile(opts.DockerfilePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read Dockerfile: %w", err)
+		}
+		df, err := ParseDockerfile(string(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", opts.DockerfilePath, err)
+		}
+		imp.importDockerfile(filepath.Base(opts.DockerfilePath), df)
+	}
+
+	if opts.ComposePath != "" {
+		data, err := os.ReadFile(opts.ComposePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read compose file: %w", err)
+		}
+		compose, err := ParseCompose(string(data))
+		if err != nil {
+			return nil, err
+		}
+		if err := imp.importCompose(filepath.Base(opts.ComposePath), compose); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := imp.finish(); err != nil {
+		return nil, err
+	}
+	return imp.result, nil
+}
+
+// ============================================
+// Dockerfile
+// ============================================
+
+func (imp *importer) importDockerfile(file string, df *Dockerfile) {
+	final := df.FinalStage()
+	builder := df.BuilderStage()
+
+	// FROM → builder_image / runtime_image
+	runtimeImage := df.BaseImage(final)
+	imp.cfg.Build.RuntimeImage = config.NewImageSpec(runtimeImage)
+	if builder != nil {
+		imp.builderImage = df.BaseImage(builder)
+		imp.cfg.Build.BuilderImage = config.NewImageSpec(imp.builderImage)
+	} else {
+		imp.unmapped(location(file, final.From.Line), "FROM "+final.From.Args,
+			"single-stage Dockerfile: mapped to runtime_image, builder_image is derived from the language")
+	}
+	if runtimeImage == "scratch" || strings.Contains(runtimeImage, "distroless") {
+		imp.unmapped(location(file, final.From.Line), "FROM "+final.From.Args,
+			"runtime image has no shell: consider runtime.shell_less with an exec-form startup command")
+	}
+
+	for _, stage := range df.Stages {
+		if stage == final {
+			continue
+		}
+		for _, inst := range stage.Instructions {
+			imp.unmapped(location(file, inst.Line), inst.Command+" "+inst.Args,
+				fmt.Sprintf("build stage '%s' is generated from build.commands; review build.commands.build", stageLabel(stage)))
+		}
+	}
+
+	var entrypoint, cmd *Instruction
+	for i := range final.Instructions {
+		inst := final.Instructions[i]
+		src := location(file, inst.Line)
+		switch inst.Command {
+		case "EXPOSE":
+			for _, field := range strings.Fields(inst.Args) {
+				imp.addPort(src, field)
+			}
+		case "ENV":
+			for _, kv := range parseEnv(inst.Args) {
+				imp.cfg.Runtime.Startup.Env = append(imp.cfg.Runtime.Startup.Env, config.EnvConfig{Name: kv[0], Value: kv[1]})
+			}
+		case "ENTRYPOINT":
+			entrypoint = &final.Instructions[i]
+		case "CMD":
+			cmd = &final.Instructions[i]
+		case "HEALTHCHECK":
+			imp.importDockerHealthcheck(src, inst.Args)
+		case "COPY", "ADD":
+			if !strings.Contains(inst.Args, "--from=") {
+				imp.unmapped(src, inst.Command+" "+inst.Args, "build output is copied from ${BUILD_OUTPUT_DIR}; add extra files to the build command output")
+			}
+		case "RUN":
+			imp.unmapped(src, "RUN "+inst.Args, "runtime setup steps: use runtime.system_dependencies.packages or plugins")
+		default:
+			imp.unmapped(src, inst.Command+" "+inst.Args, "no equivalent in service.yaml")
+		}
+	}
+
+	if command := dockerStartupCommand(entrypoint, cmd); command != "" {
+		imp.cfg.Runtime.Startup.Command = command
+		imp.hasStartup = true
+	}
+}
+
+func (imp *importer) importDockerHealthcheck(src, args string) {
+	if strings.EqualFold(strings.TrimSpace(args), "NONE") {
+		imp.cfg.Runtime.Healthcheck = config.HealthcheckConfig{Enabled: false}
+		imp.hasHealth = true
+		return
+	}
+
+	fields := strings.Fields(args)
+	health := &imp.cfg.LocalDev.Compose.Healthcheck
+	i := 0
+	for ; i < len(fields) && strings.HasPrefix(fields[i], "--"); i++ {
+		key, value, _ := strings.Cut(strings.TrimPrefix(fields[i], "--"), "=")
+		switch key {
+		case "interval":
+			health.Interval = value
+		case "timeout":
+			health.Timeout = value
+		case "start-period":
+			health.StartPeriod = value
+		case "retries":
+			health.Retries, _ = strconv.Atoi(value)
+		default:
+			imp.unmapped(src, "HEALTHCHECK "+fields[i], "option not supported")
+		}
+	}
+	if i >= len(fields) || !strings.EqualFold(fields[i], "CMD") {
+		imp.unmapped(src, "HEALTHCHECK "+args, "expected HEALTHCHECK [OPTIONS] CMD command")
+		return
+	}
+
+	rest := strings.TrimSpace(strings.SplitN(args, fields[i], 2)[1])
+	if list, ok := parseExecForm(rest); ok {
+		rest = shellJoin(list)
+	}
+	imp.cfg.Runtime.Healthcheck = healthcheckFromCommand(rest)
+	imp.hasHealth = true
+}
+
+// dockerStartupCommand 将 ENTRYPOINT / CMD 合并为启动脚本命令
+// exec 形式的 ENTRYPOINT 会拼接 CMD 作为参数；shell 形式的 ENTRYPOINT 忽略 CMD（与 Docker 行为一致）
+func dockerStartupCommand(entrypoint, cmd *Instruction) string {
+	var args []string
+	if entrypoint != nil {
+		list, ok := parseExecForm(entrypoint.Args)
+		if !ok {
+			return execPrefix(entrypoint.Args)
+		}
+		args = append(args, list...)
+	}
+	if cmd != nil {
+		list, ok := parseExecForm(cmd.Args)
+		if !ok {
+			if len(args) > 0 {
+				// exec 形式 ENTRYPOINT + shell 形式 CMD：Docker 以 /bin/sh -c 作为参数传入
+				return "exec " + shellJoin(append(args, "/bin/sh", "-c", cmd.Args))
+			}
+			return execPrefix(cmd.Args)
+		}
+		args = append(args, list...)
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	return "exec " + shellJoin(args)
+}
+
+// ============================================
+// compose
+// ============================================
+
+// composeIgnoredKeys 由生成的 compose 文件接管的服务字段及说明
+var composeIgnoredKeys = map[string]string{
+	"container_name": "container name is derived from service.name",
+	"restart":        "restart policy is set by the generated compose file",
+}
+
+func (imp *importer) importCompose(file string, compose *ComposeFile) error {
+	name, svc, err := compose.SelectService(imp.opts.ComposeService)
+	if err != nil {
+		return err
+	}
+	prefix := fmt.Sprintf("%s services.%s", file, name)
+	if imp.cfg.Service.Name == "" {
+		imp.cfg.Service.Name = name
+	}
+
+	for _, other := range compose.ServiceNames() {
+		if other != name {
+			imp.unmapped(fmt.Sprintf("%s services.%s", file, other), "service "+other,
+				"only one service is imported; dependencies such as databases stay in your own compose file")
+		}
+	}
+
+	if svc.Image != "" {
+		imp.unmapped(prefix+".image", svc.Image, "the service image is built by svcgen; not mapped")
+	}
+	for _, entry := range append(svc.Ports, svc.Expose...) {
+		imp.addPort(prefix+".ports", project.ComposePortSpec(entry))
+	}
+
+	local := &imp.cfg.LocalDev.Compose
+	for _, kv := range stringMap(svc.Environment) {
+		local.Environment = append(local.Environment, config.EnvConfig{Name: kv[0], Value: kv[1]})
+	}
+	if labels := stringMap(svc.Labels); len(labels) > 0 {
+		local.Labels = make(map[string]string, len(labels))
+		for _, kv := range labels {
+			local.Labels[kv[0]] = kv[1]
+		}
+	}
+	for _, v := range svc.Volumes {
+		imp.addVolume(prefix+".volumes", v)
+	}
+
+	if entrypoint, _ := stringList(svc.Entrypoint); len(entrypoint) > 0 {
+		local.Entrypoint = entrypoint
+	}
+	if command, isList := stringList(svc.Command); len(command) > 0 {
+		startup := execPrefix(command[0])
+		if isList {
+			startup = "exec " + shellJoin(command)
+		}
+		if imp.hasStartup {
+			imp.unmapped(prefix+".command", strings.Join(command, " "), "startup command already taken from the Dockerfile")
+		} else {
+			imp.cfg.Runtime.Startup.Command = startup
+			imp.hasStartup = true
+		}
+	}
+
+	if svc.Healthcheck != nil {
+		imp.importComposeHealthcheck(prefix+".healthcheck", svc.Healthcheck)
+	}
+	if svc.Deploy != nil {
+		imp.importDeploy(prefix+".deploy", svc.Deploy)
+	}
+
+	keys := make([]string, 0, len(svc.Other))
+	for k := range svc.Other {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		reason, ok := composeIgnoredKeys[k]
+		if !ok {
+			reason = "no equivalent in service.yaml"
+		}
+		imp.unmapped(prefix+"."+k, fmt.Sprint(svc.Other[k]), reason)
+	}
+	return nil
+}
+
+func (imp *importer) importComposeHealthcheck(src string, hc *ComposeHealth) {
+	health := &imp.cfg.LocalDev.Compose.Healthcheck
+	health.Interval, health.Timeout, health.Retries, health.StartPeriod = hc.Interval, hc.Timeout, hc.Retries, hc.StartPeriod
+
+	test, isList := stringList(hc.Test)
+	command := ""
+	switch {
+	case hc.Disable || (isList && len(test) > 0 && test[0] == "NONE"):
+		if !imp.hasHealth {
+			imp.cfg.Runtime.Healthcheck = config.HealthcheckConfig{Enabled: false}
+			imp.hasHealth = true
+		}
+		return
+	case isList && len(test) > 1 && test[0] == "CMD":
+		command = shellJoin(test[1:])
+	case isList && len(test) > 1 && test[0] == "CMD-SHELL":
+		command = test[1]
+	case !isList && len(test) == 1:
+		command = test[0]
+	}
+
+	if command == "" {
+		return
+	}
+	if imp.hasHealth {
+		imp.unmapped(src+".test", command, "healthcheck already taken from the Dockerfile")
+		return
+	}
+	imp.cfg.Runtime.Healthcheck = healthcheckFromCommand(command)
+	imp.hasHealth = true
+}
+
+func (imp *importer) importDeploy(src string, deploy *ComposeDeploy) {
+	resources := &imp.cfg.LocalDev.Compose.Resources
+	resources.Limits = config.ResourceLimits{CPUs: cpuString(deploy.Resources.Limits.CPUs), Memory: deploy.Resources.Limits.Memory}
+	resources.Reservations = config.ResourceLimits{CPUs: cpuString(deploy.Resources.Reservations.CPUs), Memory: deploy.Resources.Reservations.Memory}
+
+	keys := make([]string, 0, len(deploy.Other))
+	for k := range deploy.Other {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		imp.unmapped(src+"."+k, fmt.Sprint(deploy.Other[k]), "only deploy.resources is imported")
+	}
+}
+
+func (imp *importer) addVolume(src string, entry interface{}) {
+	switch v := entry.(type) {
+	case string:
+		parts := strings.Split(v, ":")
+		if len(parts) < 2 {
+			imp.unmapped(src, v, "anonymous volumes are not supported")
+			return
+		}
+		if len(parts) > 2 {
+			imp.unmapped(src, v, "volume mode '"+parts[2]+"' is not supported; mounted read-write")
+		}
+		imp.cfg.LocalDev.Compose.Volumes = append(imp.cfg.LocalDev.Compose.Volumes, config.VolumeConfig{
+			Source: parts[0],
+			Target: parts[1],
+			Type:   volumeType(parts[0]),
+		})
+	case map[string]interface{}:
+		source, _ := v["source"].(string)
+		target, _ := v["target"].(string)
+		typ, _ := v["type"].(string)
+		if source == "" || target == "" || (typ != "bind" && typ != "volume") {
+			imp.unmapped(src, fmt.Sprint(v), "only bind and named volume mounts with source and target are supported")
+			return
+		}
+		imp.cfg.LocalDev.Compose.Volumes = append(imp.cfg.LocalDev.Compose.Volumes, config.VolumeConfig{
+			Source: source,
+			Target: target,
+			Type:   typ,
+		})
+	}
+}
+
+// ============================================
+// 汇总
+// ============================================
+
+func (imp *importer) addPort(src, spec string) {
+	port, protocol, ok := project.ParsePortSpec(spec)
+	if !ok {
+		imp.unmapped(src, spec, "port is not a number")
+		return
+	}
+	key := fmt.Sprintf("%d/%s", port, protocol)
+	if imp.seen[key] {
+		return
+	}
+	imp.seen[key] = true
+	imp.ports = append(imp.ports, project.Port{Port: port, Protocol: protocol, Source: src})
+}
+
+func (imp *importer) unmapped(source, construct, reason string) {
+	imp.result.Unmapped = append(imp.result.Unmapped, Finding{Source: source, Construct: construct, Reason: reason})
+}
+
+// finish 补全服务名、语言、端口与启动命令
+func (imp *importer) finish() error {
+	cfg := imp.cfg
+
+	project.AssignPortNames(imp.ports)
+	for _, p := range imp.ports {
+		cfg.Service.Ports = append(cfg.Service.Ports, config.PortConfig{Name: p.Name, Port: p.Port, Protocol: p.Protocol, Expose: true})
+	}
+
+	detected, _ := project.DetectDir(imp.opts.ProjectDir)
+	if cfg.Service.Name == "" {
+		cfg.Service.Name = filepath.Base(mustAbs(imp.opts.ProjectDir))
+		if detected != nil {
+			cfg.Service.Name = detected.Name
+		}
+	}
+	cfg.Service.Name = project.SanitizeName(cfg.Service.Name)
+
+	cfg.Language.Type = imp.opts.Language
+	if cfg.Language.Type == "" && detected != nil {
+		cfg.Language.Type, cfg.Language.Version = detected.Language, detected.Version
+	}
+	if cfg.Language.Type == "" {
+		cfg.Language.Type, cfg.Language.Version = languageFromImage(imp.builderImage)
+	}
+	if cfg.Language.Type == "" {
+		return fmt.Errorf("cannot determine the service language; pass --language")
+	}
+
+	if !imp.hasStartup {
+		cfg.Runtime.Startup.Command = project.DefaultStartCommand(cfg.Language.Type)
+		imp.unmapped("startup", "CMD / ENTRYPOINT", "not found; using the default startup command for "+cfg.Language.Type)
+	}
+	if !imp.hasHealth {
+		cfg.Runtime.Healthcheck = config.HealthcheckConfig{Enabled: true, Type: config.HealthcheckTypeDefault}
+	}
+	return nil
+}
+
+// ============================================
+// helpers
+// ============================================
+
+// languageImages 构建镜像名称与语言的对应关系
+var languageImages = []struct {
+	language string
+	pattern  *regexp.Regexp
+}{
+	{"go", regexp.MustCompile(`(^|/)golang:(\d+\.\d+)`)},
+	{"python", regexp.MustCompile(`(^|/)python:(\d+\.\d+)`)},
+	{"nodejs", regexp.MustCompile(`(^|/)node:(\d+)`)},
+	{"rust", regexp.MustCompile(`(^|/)rust:(\d+\.\d+)`)},
+	{"java", regexp.MustCompile(`(^|/)(?:maven:[\d.]+-[a-z-]+-|gradle:[\d.]+-jdk|eclipse-temurin:|openjdk:|amazoncorretto:)(\d+)`)},
+}
+
+// languageFromImage 根据构建镜像推断语言与版本（版本格式无效时留空）
+func languageFromImage(image string) (string, string) {
+	for _, li := range languageImages {
+		if m := li.pattern.FindStringSubmatch(image); m != nil {
+			version := m[len(m)-1]
+			if (&config.LanguageConfig{Type: li.language, Version: version}).ValidateVersion() != nil {
+				version = ""
+			}
+			return li.language, version
+		}
+	}
+	return "", ""
+}
+
+var localHTTPURL = regexp.MustCompile(`https?://(?:localhost|127\.0\.0\.1|0\.0\.0\.0)(?::(\d+))?(/[^\s'"|&;]*)?`)
+
+// healthcheckFromCommand 将健康检查命令映射为 runtime.healthcheck
+// curl / wget 访问本机 HTTP 地址时映射为 http 探针，否则作为 custom 脚本
+func healthcheckFromCommand(command string) config.HealthcheckConfig {
+	command = strings.TrimSpace(command)
+	if strings.Contains(command, "curl") || strings.Contains(command, "wget") {
+		if m := localHTTPURL.FindStringSubmatch(command); m != nil {
+			port, _ := strconv.Atoi(m[1])
+			return config.HealthcheckConfig{Enabled: true, Type: config.HealthcheckTypeHTTP, Port: port, Path: m[2]}
+		}
+	}
+	return config.HealthcheckConfig{
+		Enabled:      true,
+		Type:         config.HealthcheckTypeCustom,
+		CustomScript: "#!/bin/sh\n" + command + "\n",
+	}
+}
+
+// execPrefix 为单条 shell 命令加上 exec，使服务进程替换 shell 接收信号
+func execPrefix(command string) string {
+	command = strings.TrimSpace(command)
+	if strings.HasPrefix(command, "exec ") || strings.ContainsAny(command, ";&|\n") {
+		return command
+	}
+	return "exec " + command
+}
+
+// shellJoin 将参数列表拼接为 shell 命令，必要时加单引号
+func shellJoin(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'$`\\;&|<>*?()[]{}") {
+			quoted[i] = "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+		} else {
+			quoted[i] = arg
+		}
+	}
+	return strings.Join(quoted, " ")
+}
+
+func volumeType(source string) string {
+	if strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~") {
+		return "bind"
+	}
+	return "volume"
+}
+
+func cpuString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+func location(file string, line int) string {
+	return fmt.Sprintf("%s:%d", file, line)
+}
+
+func stageLabel(stage *Stage) string {
+	if stage.Name != "" {
+		return stage.Name
+	}
+	return stage.Image
+}
+
+func mustAbs(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	return abs
+}
+
+// Render 输出 service.yaml 内容（带来源说明的文件头）
+func (r *Result) Render(sources ...string) (string, error) {
+	var buf bytes.Buffer
+	buf.WriteString("# ============================================\n")
+	buf.WriteString("# Service Configuration\n")
+	fmt.Fprintf(&buf, "# Imported by 'svcgen import' from: %s\n", strings.Join(sources, ", "))
+	if len(r.Unmapped) > 0 {
+		fmt.Fprintf(&buf, "# %d construct(s) could not be mapped, see the import report\n", len(r.Unmapped))
+	}
+	buf.WriteString("# ============================================\n\n")
+
+	var doc yaml.Node
+	if err := doc.Encode(r.Config); err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+	pruneEmpty(&doc)
+
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// pruneEmpty 删除值为空字符串、空映射或空列表的键，使导入结果只包含实际映射到的配置
+// false / 0 等显式值保留（如 healthcheck.enabled: false）
+func pruneEmpty(node *yaml.Node) bool {
+	switch node.Kind {
+	case yaml.MappingNode:
+		kept := node.Content[:0]
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if !pruneEmpty(node.Content[i+1]) {
+				kept = append(kept, node.Content[i], node.Content[i+1])
+			}
+		}
+		node.Content = kept
+		return len(node.Content) == 0
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			pruneEmpty(item)
+		}
+		return len(node.Content) == 0
+	case yaml.ScalarNode:
+		return node.Tag == "!!str" && node.Value == ""
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			pruneEmpty(child)
+		}
+	}
+	return false
+}
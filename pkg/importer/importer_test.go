@@ -0,0 +1,194 @@
+package importer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const importDockerfile = `ARG GO_VERSION=1.22
+FROM golang:${GO_VERSION}-alpine AS build
+WORKDIR /src
+RUN go build -o /out/app ./cmd/app
+
+FROM alpine:3.19
+RUN apk add --no-cache ca-certificates
+COPY --from=build /out/app /app
+ENV APP_ENV=prod LOG_LEVEL="info"
+EXPOSE 8080 9090
+USER nobody
+HEALTHCHECK --interval=30s --timeout=3s CMD wget -qO- http://localhost:8080/healthz || exit 1
+ENTRYPOINT ["/app"]
+CMD ["--config", "/etc/app/config yaml"]
+`
+
+const importCompose = `services:
+  api:
+    build: .
+    ports: ["18080:8080", "9000"]
+    environment:
+      DB_HOST: db
+    volumes:
+      - ./config:/etc/app:ro
+      - data:/var/lib/app
+    labels: ["team=core"]
+    deploy:
+      replicas: 2
+      resources:
+        limits: {cpus: 0.5, memory: 512M}
+    networks: [backend]
+  db:
+    image: postgres:16
+`
+
+func writeImportFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestImport_DockerfileAndCompose(t *testing.T) {
+	dir := writeImportFiles(t, map[string]string{"Dockerfile": importDockerfile, "compose.yaml": importCompose})
+
+	result, err := Import(Options{
+		DockerfilePath: filepath.Join(dir, "Dockerfile"),
+		ComposePath:    filepath.Join(dir, "compose.yaml"),
+		ProjectDir:     dir,
+	})
+	require.NoError(t, err)
+	cfg := result.Config
+
+	assert.Equal(t, "api", cfg.Service.Name)
+	assert.Equal(t, "go", cfg.Language.Type)
+	assert.Equal(t, "1.22", cfg.Language.Version)
+	assert.Equal(t, "golang:1.22-alpine", cfg.Build.BuilderImage.String())
+	assert.Equal(t, "alpine:3.19", cfg.Build.RuntimeImage.String())
+
+	require.Len(t, cfg.Service.Ports, 3)
+	assert.Equal(t, "http", cfg.Service.Ports[0].Name)
+	assert.Equal(t, 8080, cfg.Service.Ports[0].Port)
+	assert.Equal(t, "metrics", cfg.Service.Ports[1].Name)
+	assert.Equal(t, "port-9000", cfg.Service.Ports[2].Name)
+
+	assert.Equal(t, "exec /app --config '/etc/app/config yaml'", cfg.Runtime.Startup.Command)
+	assert.Equal(t, config.HealthcheckTypeHTTP, cfg.Runtime.Healthcheck.Type)
+	assert.Equal(t, 8080, cfg.Runtime.Healthcheck.Port)
+	assert.Equal(t, "/healthz", cfg.Runtime.Healthcheck.Path)
+
+	compose := cfg.LocalDev.Compose
+	assert.Equal(t, "0.5", compose.Resources.Limits.CPUs)
+	assert.Equal(t, "512M", compose.Resources.Limits.Memory)
+	require.Len(t, compose.Volumes, 2)
+	assert.Equal(t, "bind", compose.Volumes[0].Type)
+	assert.Equal(t, "volume", compose.Volumes[1].Type)
+	assert.Equal(t, "core", compose.Labels["team"])
+	assert.Equal(t, "30s", compose.Healthcheck.Interval)
+
+	var report []string
+	for _, f := range result.Unmapped {
+		report = append(report, f.String())
+	}
+	joined := strings.Join(report, "\n")
+	assert.Contains(t, joined, "Dockerfile:11: USER nobody")
+	assert.Contains(t, joined, "services.db")
+	assert.Contains(t, joined, "services.api.networks")
+	assert.Contains(t, joined, "deploy.replicas")
+	assert.Contains(t, joined, "volume mode 'ro'")
+
+	validator := config.NewValidator(cfg)
+	assert.NoError(t, validator.Validate())
+}
+
+func TestImport_Render(t *testing.T) {
+	dir := writeImportFiles(t, map[string]string{"Dockerfile": importDockerfile})
+
+	result, err := Import(Options{DockerfilePath: filepath.Join(dir, "Dockerfile"), Name: "My App", ProjectDir: dir})
+	require.NoError(t, err)
+
+	content, err := result.Render("Dockerfile")
+	require.NoError(t, err)
+	assert.Contains(t, content, "Imported by 'svcgen import' from: Dockerfile")
+	assert.Contains(t, content, "builder_image: golang:1.22-alpine")
+	assert.NotContains(t, content, `: ""`, "empty values are pruned")
+
+	cfg, err := config.LoadFromBytes([]byte(content))
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", cfg.Service.Name)
+	assert.Equal(t, "alpine:3.19", cfg.Build.RuntimeImage.String())
+}
+
+func TestImport_Errors(t *testing.T) {
+	_, err := Import(Options{})
+	assert.ErrorContains(t, err, "at least one")
+
+	dir := writeImportFiles(t, map[string]string{"Dockerfile": "FROM alpine\nCMD [\"/app\"]\n"})
+	_, err = Import(Options{DockerfilePath: filepath.Join(dir, "Dockerfile"), ProjectDir: dir})
+	assert.ErrorContains(t, err, "--language")
+
+	result, err := Import(Options{DockerfilePath: filepath.Join(dir, "Dockerfile"), ProjectDir: dir, Language: "go"})
+	require.NoError(t, err)
+	assert.Equal(t, "exec /app", result.Config.Runtime.Startup.Command)
+}
+
+func TestDockerStartupCommand(t *testing.T) {
+	inst := func(args string) *Instruction { return &Instruction{Args: args} }
+
+	tests := []struct {
+		name       string
+		entrypoint *Instruction
+		cmd        *Instruction
+		want       string
+	}{
+		{"exec cmd", nil, inst(`["./app", "serve"]`), "exec ./app serve"},
+		{"shell cmd", nil, inst("./app serve"), "exec ./app serve"},
+		{"shell cmd with pipeline", nil, inst("./migrate && ./app"), "./migrate && ./app"},
+		{"exec entrypoint with cmd args", inst(`["/app"]`), inst(`["--port", "80"]`), "exec /app --port 80"},
+		{"shell entrypoint ignores cmd", inst("/app --debug"), inst(`["--port", "80"]`), "exec /app --debug"},
+		{"none", nil, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, dockerStartupCommand(tt.entrypoint, tt.cmd))
+		})
+	}
+}
+
+func TestLanguageFromImage(t *testing.T) {
+	tests := []struct {
+		image    string
+		language string
+		version  string
+	}{
+		{"golang:1.23-alpine", "go", "1.23"},
+		{"docker.io/library/python:3.11-slim", "python", "3.11"},
+		{"node:20-alpine", "nodejs", "20"},
+		{"maven:3.9-eclipse-temurin-17", "java", "17"},
+		{"alpine:3.19", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			language, version := languageFromImage(tt.image)
+			assert.Equal(t, tt.language, language)
+			assert.Equal(t, tt.version, version)
+		})
+	}
+}
+
+func TestHealthcheckFromCommand(t *testing.T) {
+	hc := healthcheckFromCommand("curl -f http://127.0.0.1:9000/ready || exit 1")
+	assert.Equal(t, config.HealthcheckTypeHTTP, hc.Type)
+	assert.Equal(t, 9000, hc.Port)
+	assert.Equal(t, "/ready", hc.Path)
+
+	hc = healthcheckFromCommand("pg_isready -U app")
+	assert.Equal(t, config.HealthcheckTypeCustom, hc.Type)
+	assert.Contains(t, hc.CustomScript, "pg_isready -U app")
+}
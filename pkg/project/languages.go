@@ -0,0 +1,332 @@
+package project
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+)
+
+// ============================================
+// Go
+// ============================================
+
+var (
+	goModModule    = regexp.MustCompile(`(?m)^module\s+(\S+)`)
+	goModGo        = regexp.MustCompile(`(?m)^go\s+(\d+\.\d+)`)
+	goPackageMain  = regexp.MustCompile(`(?m)^package\s+main\s*$`)
+	versionNumeric = regexp.MustCompile(`\d+(?:\.\d+)*`)
+)
+
+// detectGo 读取 go.mod 的 module / go 指令，并查找 main 包（根目录或 cmd/*）
+func detectGo(fsys fs.FS, p *Project) error {
+	gomod := readFile(fsys, "go.mod")
+	if m := goModModule.FindStringSubmatch(gomod); m != nil {
+		p.Name = m[1]
+	}
+	if m := goModGo.FindStringSubmatch(gomod); m != nil {
+		p.Version = m[1]
+	}
+
+	mains := map[string]bool{}
+	if isGoMainDir(fsys, ".") {
+		mains["."] = true
+	}
+	if entries, err := fs.ReadDir(fsys, "cmd"); err == nil {
+		for _, entry := range entries {
+			dir := path.Join("cmd", entry.Name())
+			if entry.IsDir() && isGoMainDir(fsys, dir) {
+				mains["./"+dir] = true
+			}
+		}
+	}
+	p.Binaries = sortedKeys(mains)
+
+	if mainPkg := pickGoMain(p.Binaries, SanitizeName(p.Name)); mainPkg != "" && mainPkg != config.DefaultGoMainPackage {
+		p.LanguageConfig["main_package"] = mainPkg
+	}
+	p.StartCommand = DefaultStartCommand(p.Language)
+	return nil
+}
+
+// isGoMainDir 检查目录中是否有 package main 的 Go 文件
+func isGoMainDir(fsys fs.FS, dir string) bool {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if goPackageMain.MatchString(readFile(fsys, path.Join(dir, name))) {
+			return true
+		}
+	}
+	return false
+}
+
+// pickGoMain 选择构建的 main 包：与服务同名 > cmd/server > 根目录 > 第一个
+func pickGoMain(mains []string, name string) string {
+	if len(mains) == 0 {
+		return ""
+	}
+	for _, preferred := range []string{"./cmd/" + name, config.DefaultGoMainPackage, "."} {
+		for _, m := range mains {
+			if m == preferred {
+				return m
+			}
+		}
+	}
+	return mains[0]
+}
+
+// ============================================
+// Node.js
+// ============================================
+
+type packageJSON struct {
+	Name    string            `json:"name"`
+	Main    string            `json:"main"`
+	Scripts map[string]string `json:"scripts"`
+	Engines struct {
+		Node string `json:"node"`
+	} `json:"engines"`
+}
+
+// detectNodeJS 读取 package.json 的 name / scripts.start / scripts.build / engines.node / main
+func detectNodeJS(fsys fs.FS, p *Project) error {
+	var pkg packageJSON
+	if err := json.Unmarshal([]byte(readFile(fsys, "package.json")), &pkg); err != nil {
+		return err
+	}
+	p.Name = pkg.Name
+	// engines.node 形如 ">=20"、"^18.17.0"、"20.x"，取第一个数字作为主版本
+	if m := versionNumeric.FindString(pkg.Engines.Node); m != "" {
+		p.Version = majorOf(m)
+	}
+
+	if _, ok := pkg.Scripts["build"]; ok {
+		p.BuildCommand = "npm run build && cp -r . ${BUILD_OUTPUT_DIR}/"
+	}
+	switch {
+	case pkg.Scripts["start"] != "":
+		p.StartCommand = "cd ${SERVICE_ROOT} && exec npm start"
+	case pkg.Main != "":
+		p.StartCommand = "exec node ${SERVICE_ROOT}/" + strings.TrimPrefix(pkg.Main, "./")
+	default:
+		p.StartCommand = DefaultStartCommand(p.Language)
+	}
+	return nil
+}
+
+// ============================================
+// Python
+// ============================================
+
+// detectPython 读取 pyproject.toml 的 [project] name / requires-python，并查找入口脚本
+func detectPython(fsys fs.FS, p *Project) error {
+	if pyproject := readFile(fsys, "pyproject.toml"); pyproject != "" {
+		project := tomlSection(pyproject, "project")
+		p.Name = project["name"]
+		if m := versionNumeric.FindString(project["requires-python"]); m != "" {
+			p.Version = majorMinorOf(m)
+		}
+	}
+	if version := strings.TrimSpace(readFile(fsys, ".python-version")); version != "" {
+		p.Version = majorMinorOf(version)
+	}
+
+	entry := firstExisting(fsys, "main.py", "app.py", "server.py", "manage.py")
+	if entry == "" {
+		entry = "main.py"
+	}
+	if entry == "manage.py" {
+		p.StartCommand = "exec python ${SERVICE_ROOT}/manage.py runserver 0.0.0.0:8000"
+	} else {
+		p.StartCommand = "exec python ${SERVICE_ROOT}/" + entry
+	}
+	return nil
+}
+
+// ============================================
+// Java
+// ============================================
+
+var (
+	pomArtifactID      = regexp.MustCompile(`<artifactId>([^<]+)</artifactId>`)
+	pomJavaVersion     = regexp.MustCompile(`<(?:java\.version|maven\.compiler\.release|maven\.compiler\.source)>([^<]+)<`)
+	gradleToolchain    = regexp.MustCompile(`languageVersion(?:\.set\()?\s*=?\s*JavaLanguageVersion\.of\((\d+)\)`)
+	gradleSourceCompat = regexp.MustCompile(`sourceCompatibility\s*=\s*(?:JavaVersion\.VERSION_)?['"]?([\d_.]+)`)
+	gradleRootName     = regexp.MustCompile(`rootProject\.name\s*=\s*['"]([^'"]+)['"]`)
+)
+
+// detectJava 区分 Maven / Gradle，读取 artifactId / rootProject.name 与 Java 版本
+func detectJava(fsys fs.FS, p *Project) error {
+	if pom := readFile(fsys, "pom.xml"); pom != "" {
+		// 跳过 <parent> 中的 artifactId
+		body := pom
+		if end := strings.Index(body, "</parent>"); end >= 0 {
+			body = body[end:]
+		}
+		if m := pomArtifactID.FindStringSubmatch(body); m != nil {
+			p.Name = m[1]
+		}
+		if m := pomJavaVersion.FindStringSubmatch(pom); m != nil {
+			p.Version = normalizeJavaVersion(m[1])
+		}
+		p.StartCommand = DefaultStartCommand(p.Language)
+		return nil
+	}
+
+	p.LanguageConfig["build_tool"] = "gradle"
+	gradle := readFile(fsys, "build.gradle") + readFile(fsys, "build.gradle.kts")
+	if m := gradleToolchain.FindStringSubmatch(gradle); m != nil {
+		p.Version = m[1]
+	} else if m := gradleSourceCompat.FindStringSubmatch(gradle); m != nil {
+		p.Version = normalizeJavaVersion(m[1])
+	}
+	settings := readFile(fsys, "settings.gradle") + readFile(fsys, "settings.gradle.kts")
+	if m := gradleRootName.FindStringSubmatch(settings); m != nil {
+		p.Name = m[1]
+	}
+	p.StartCommand = DefaultStartCommand(p.Language)
+	return nil
+}
+
+// normalizeJavaVersion 将 1.8 / 1_8 规范化为 8
+func normalizeJavaVersion(v string) string {
+	v = strings.ReplaceAll(strings.TrimSpace(v), "_", ".")
+	if strings.HasPrefix(v, "1.") {
+		return strings.TrimPrefix(v, "1.")
+	}
+	return majorOf(v)
+}
+
+// ============================================
+// Rust
+// ============================================
+
+// detectRust 读取 Cargo.toml 的 [package] name / rust-version 与 [[bin]] 名称
+func detectRust(fsys fs.FS, p *Project) error {
+	cargo := readFile(fsys, "Cargo.toml")
+	pkg := tomlSection(cargo, "package")
+	p.Name = pkg["name"]
+	if v := pkg["rust-version"]; v != "" {
+		p.Version = majorMinorOf(v)
+	}
+	if channel := tomlSection(readFile(fsys, "rust-toolchain.toml"), "toolchain")["channel"]; versionNumeric.MatchString(channel) {
+		p.Version = majorMinorOf(channel)
+	}
+
+	bins := tomlArraySections(cargo, "bin")
+	names := make([]string, 0, len(bins))
+	for _, bin := range bins {
+		if bin["name"] != "" {
+			names = append(names, bin["name"])
+		}
+	}
+	sort.Strings(names)
+	p.Binaries = names
+
+	// 默认构建命令拷贝 target/release/${SERVICE_NAME}；二进制名与服务名不同时显式指定
+	binary := p.Name
+	if len(names) > 0 && !containsString(names, p.Name) {
+		binary = names[0]
+	}
+	if binary != "" && binary != SanitizeName(p.Name) {
+		p.BuildCommand = fmt.Sprintf("cargo build --release && cp target/release/%s ${BUILD_OUTPUT_DIR}/bin/${SERVICE_NAME}", binary)
+	}
+	p.StartCommand = DefaultStartCommand(p.Language)
+	return nil
+}
+
+// ============================================
+// helpers
+// ============================================
+
+// tomlSection 解析简单 TOML 中某个 [section] 下的 key = "value"（只支持字符串值）
+func tomlSection(content, section string) map[string]string {
+	values := map[string]string{}
+	inSection := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			inSection = line == "["+section+"]"
+			continue
+		}
+		if inSection {
+			if key, value, ok := parseTOMLString(line); ok {
+				values[key] = value
+			}
+		}
+	}
+	return values
+}
+
+// tomlArraySections 解析 [[section]] 数组表
+func tomlArraySections(content, section string) []map[string]string {
+	var tables []map[string]string
+	var current map[string]string
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			current = nil
+			if line == "[["+section+"]]" {
+				current = map[string]string{}
+				tables = append(tables, current)
+			}
+			continue
+		}
+		if current != nil {
+			if key, value, ok := parseTOMLString(line); ok {
+				current[key] = value
+			}
+		}
+	}
+	return tables
+}
+
+func parseTOMLString(line string) (string, string, bool) {
+	key, value, ok := strings.Cut(line, "=")
+	if !ok {
+		return "", "", false
+	}
+	value = strings.TrimSpace(value)
+	if len(value) < 2 || (value[0] != '"' && value[0] != '\'') {
+		return "", "", false
+	}
+	end := strings.IndexByte(value[1:], value[0])
+	if end < 0 {
+		return "", "", false
+	}
+	return strings.TrimSpace(key), value[1 : end+1], true
+}
+
+func majorOf(version string) string {
+	major, _, _ := strings.Cut(version, ".")
+	return major
+}
+
+func majorMinorOf(version string) string {
+	parts := strings.SplitN(versionNumeric.FindString(version), ".", 3)
+	if len(parts) < 2 {
+		return parts[0]
+	}
+	return parts[0] + "." + parts[1]
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
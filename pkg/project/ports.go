@@ -0,0 +1,188 @@
+package project
+
+import (
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// composeFiles 按 docker compose 的查找顺序排列
+var composeFiles = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// wellKnownPortNames 常见端口的默认名称
+var wellKnownPortNames = map[int]string{
+	80:    "http",
+	3000:  "http",
+	5000:  "http",
+	8000:  "http",
+	8080:  "http",
+	443:   "https",
+	8443:  "https",
+	9090:  "metrics",
+	50051: "grpc",
+}
+
+var exposeLine = regexp.MustCompile(`(?im)^\s*EXPOSE\s+(.+)$`)
+
+// detectPorts 从已有 Dockerfile 的 EXPOSE 与 compose 文件的 services.*.ports 中收集容器端口
+// 返回去重后的端口列表（按端口号排序）以及来源文件列表
+func detectPorts(fsys fs.FS) ([]Port, []string) {
+	seen := map[string]bool{}
+	var ports []Port
+	var sources []string
+
+	add := func(source string, port int, protocol string) {
+		key := strconv.Itoa(port) + "/" + protocol
+		if port <= 0 || port > 65535 || seen[key] {
+			return
+		}
+		seen[key] = true
+		ports = append(ports, Port{Port: port, Protocol: protocol, Source: source})
+	}
+
+	for _, file := range findDockerfiles(fsys) {
+		found := false
+		for _, m := range exposeLine.FindAllStringSubmatch(readFile(fsys, file), -1) {
+			for _, field := range strings.Fields(m[1]) {
+				if port, protocol, ok := ParsePortSpec(field); ok {
+					add(file, port, protocol)
+					found = true
+				}
+			}
+		}
+		if found {
+			sources = append(sources, file)
+		}
+	}
+
+	if file := firstExisting(fsys, composeFiles...); file != "" {
+		found := false
+		for _, spec := range composePorts(readFile(fsys, file)) {
+			if port, protocol, ok := ParsePortSpec(spec); ok {
+				add(file, port, protocol)
+				found = true
+			}
+		}
+		if found {
+			sources = append(sources, file)
+		}
+	}
+
+	sort.SliceStable(ports, func(i, j int) bool { return ports[i].Port < ports[j].Port })
+	AssignPortNames(ports)
+	return ports, sources
+}
+
+// findDockerfiles 查找根目录与 docker/ 下的 Dockerfile、Dockerfile.*、*.Dockerfile
+func findDockerfiles(fsys fs.FS) []string {
+	var files []string
+	for _, dir := range []string{".", "docker"} {
+		entries, err := fs.ReadDir(fsys, dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() {
+				continue
+			}
+			if name == "Dockerfile" || strings.HasPrefix(name, "Dockerfile.") || strings.HasSuffix(name, ".Dockerfile") {
+				files = append(files, path.Join(dir, name))
+			}
+		}
+	}
+	return files
+}
+
+// composePorts 提取 compose 文件中所有服务的端口定义
+func composePorts(content string) []string {
+	var compose struct {
+		Services map[string]struct {
+			Ports  []interface{} `yaml:"ports"`
+			Expose []interface{} `yaml:"expose"`
+		} `yaml:"services"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &compose); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(compose.Services))
+	for name := range compose.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var specs []string
+	for _, name := range names {
+		svc := compose.Services[name]
+		for _, entry := range append(svc.Ports, svc.Expose...) {
+			if spec := ComposePortSpec(entry); spec != "" {
+				specs = append(specs, spec)
+			}
+		}
+	}
+	return specs
+}
+
+// ComposePortSpec 将 compose 的单个 ports / expose 条目转换为 "<container>[/<protocol>]"
+// 支持短语法（"8080:80"、"127.0.0.1:8080:80/udp"、9000）与长语法（target / protocol）
+func ComposePortSpec(entry interface{}) string {
+	switch v := entry.(type) {
+	case string:
+		// 短语法中容器端口总在最后一个 ":" 之后
+		return v[strings.LastIndex(v, ":")+1:]
+	case int:
+		return strconv.Itoa(v)
+	case map[string]interface{}:
+		target := ""
+		switch t := v["target"].(type) {
+		case int:
+			target = strconv.Itoa(t)
+		case string:
+			target = t
+		}
+		if protocol, ok := v["protocol"].(string); ok && target != "" {
+			target += "/" + protocol
+		}
+		return target
+	}
+	return ""
+}
+
+// ParsePortSpec 解析 "8080"、"8080/tcp"、"53/udp"；端口范围（"8000-8010"）只取起始端口
+func ParsePortSpec(spec string) (int, string, bool) {
+	spec = strings.TrimSpace(spec)
+	portPart, protocol, _ := strings.Cut(spec, "/")
+	portPart, _, _ = strings.Cut(portPart, "-")
+
+	port, err := strconv.Atoi(portPart)
+	if err != nil {
+		// ${PORT} 等变量无法静态解析
+		return 0, "", false
+	}
+	if strings.EqualFold(protocol, "udp") {
+		return port, "UDP", true
+	}
+	return port, "TCP", true
+}
+
+// AssignPortNames 为端口分配唯一名称：常见端口使用约定名称，其余使用 port-<n>
+func AssignPortNames(ports []Port) {
+	used := map[string]bool{}
+	for i := range ports {
+		name, ok := wellKnownPortNames[ports[i].Port]
+		if !ok || used[name] {
+			name = "port-" + strconv.Itoa(ports[i].Port)
+			if ports[i].Protocol == "UDP" {
+				name += "-udp"
+			}
+		}
+		used[name] = true
+		ports[i].Name = name
+	}
+}
@@ -0,0 +1,61 @@
+package project
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectPorts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"Dockerfile":            file("FROM alpine\nEXPOSE 8080 9090/tcp\nexpose ${PORT}\n"),
+		"docker/api.Dockerfile": file("FROM alpine\nEXPOSE 8080\n"),
+		"docker-compose.yml": file(`services:
+  app:
+    ports:
+      - "127.0.0.1:18080:8080"
+      - "53:53/udp"
+      - target: 50051
+        published: 50051
+      - 3000
+  sidecar:
+    expose:
+      - "7000"
+`),
+	}
+
+	ports, sources := detectPorts(fsys)
+	assert.Equal(t, []string{"Dockerfile", "docker/api.Dockerfile", "docker-compose.yml"}, sources)
+	assert.Equal(t, []Port{
+		{Name: "port-53-udp", Port: 53, Protocol: "UDP", Source: "docker-compose.yml"},
+		{Name: "http", Port: 3000, Protocol: "TCP", Source: "docker-compose.yml"},
+		{Name: "port-7000", Port: 7000, Protocol: "TCP", Source: "docker-compose.yml"},
+		{Name: "port-8080", Port: 8080, Protocol: "TCP", Source: "Dockerfile"},
+		{Name: "metrics", Port: 9090, Protocol: "TCP", Source: "Dockerfile"},
+		{Name: "grpc", Port: 50051, Protocol: "TCP", Source: "docker-compose.yml"},
+	}, ports)
+}
+
+func TestParsePortSpec(t *testing.T) {
+	tests := []struct {
+		spec     string
+		port     int
+		protocol string
+		ok       bool
+	}{
+		{"8080", 8080, "TCP", true},
+		{"53/udp", 53, "UDP", true},
+		{"8000-8010/tcp", 8000, "TCP", true},
+		{"${PORT}", 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			port, protocol, ok := ParsePortSpec(tt.spec)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.port, port)
+			assert.Equal(t, tt.protocol, protocol)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+// Package project 检测已有项目的语言、版本、构建/启动方式与端口，
+// 用于 svcgen init 生成预填充的 service.yaml
+package project
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+)
+
+// Port 检测到的容器端口
+type Port struct {
+	Name     string
+	Port     int
+	Protocol string // TCP | UDP
+	Source   string // 来源文件，如 Dockerfile
+}
+
+// Project 项目检测结果
+type Project struct {
+	// Name 服务名（已规范化为小写、仅含字母数字与 "-"）
+	Name string
+	// Language 语言类型（go / python / nodejs / java / rust）
+	Language string
+	// Version 从项目文件中读取到的语言版本（可能为空）
+	Version string
+	// LanguageConfig 需要写入 language.config 的配置项（如 build_tool、main_package）
+	LanguageConfig map[string]string
+	// Binaries 检测到的可执行入口（Go 的 cmd/* 或 Rust 的 [[bin]]）
+	Binaries []string
+	// BuildCommand 检测到的构建命令；为空时由语言策略的默认构建命令补全
+	BuildCommand string
+	// StartCommand 容器启动命令
+	StartCommand string
+	// Ports 从已有 Dockerfile / compose 文件中收集到的端口
+	Ports []Port
+	// Evidence 检测依据（文件名），用于提示用户
+	Evidence []string
+}
+
+// detector 单个语言的检测器：manifest 存在时返回检测结果
+type detector struct {
+	language  string
+	manifests []string
+	detect    func(fsys fs.FS, p *Project) error
+}
+
+// detectors 按优先级排列（同时存在多个 manifest 时，如 Go 项目中带有前端 package.json，取靠前的语言）
+var detectors = []detector{
+	{language: "go", manifests: []string{"go.mod"}, detect: detectGo},
+	{language: "rust", manifests: []string{"Cargo.toml"}, detect: detectRust},
+	{language: "java", manifests: []string{"pom.xml", "build.gradle", "build.gradle.kts"}, detect: detectJava},
+	{language: "python", manifests: []string{"pyproject.toml", "requirements.txt"}, detect: detectPython},
+	{language: "nodejs", manifests: []string{"package.json"}, detect: detectNodeJS},
+}
+
+// ErrNotDetected 目录中没有可识别的项目 manifest
+var ErrNotDetected = fmt.Errorf("no supported project manifest found (go.mod, Cargo.toml, pom.xml, build.gradle, pyproject.toml, requirements.txt, package.json)")
+
+// DetectDir 检测本地目录中的项目，服务名缺省时使用目录名
+func DetectDir(dir string) (*Project, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	return Detect(os.DirFS(abs), filepath.Base(abs))
+}
+
+// Detect 检测 fsys 根目录中的项目
+// defaultName 在 manifest 中没有可用名称时作为服务名
+func Detect(fsys fs.FS, defaultName string) (*Project, error) {
+	for _, d := range detectors {
+		manifest := firstExisting(fsys, d.manifests...)
+		if manifest == "" {
+			continue
+		}
+
+		p := &Project{Language: d.language, LanguageConfig: map[string]string{}, Evidence: []string{manifest}}
+		if err := d.detect(fsys, p); err != nil {
+			return nil, fmt.Errorf("failed to inspect %s: %w", manifest, err)
+		}
+		if p.Name == "" {
+			p.Name = defaultName
+		}
+		p.Name = SanitizeName(p.Name)
+		if p.Version != "" && !isSupportedVersion(p.Language, p.Version) {
+			// 格式错误的版本不写入，避免生成无法通过校验的配置
+			p.Version = ""
+		}
+
+		ports, sources := detectPorts(fsys)
+		p.Ports = ports
+		p.Evidence = append(p.Evidence, sources...)
+		return p, nil
+	}
+	return nil, ErrNotDetected
+}
+
+// LanguageSettings 返回用于推导默认值的语言配置
+func (p *Project) LanguageSettings() config.LanguageConfig {
+	cfg := config.LanguageConfig{Type: p.Language, Version: p.Version}
+	if len(p.LanguageConfig) > 0 {
+		cfg.Config = make(map[string]interface{}, len(p.LanguageConfig))
+		for k, v := range p.LanguageConfig {
+			cfg.Config[k] = v
+		}
+	}
+	return cfg
+}
+
+// defaultStartCommands 各语言的默认启动命令（与默认构建命令的产物位置对应）
+var defaultStartCommands = map[string]string{
+	"go":     "exec ${SERVICE_BIN_DIR}/${SERVICE_NAME}",
+	"rust":   "exec ${SERVICE_BIN_DIR}/${SERVICE_NAME}",
+	"java":   "exec java -jar ${SERVICE_ROOT}/app.jar",
+	"python": "exec python ${SERVICE_ROOT}/main.py",
+	"nodejs": "exec node ${SERVICE_ROOT}/index.js",
+}
+
+// DefaultStartCommand 返回语言的默认启动命令，未知语言返回空字符串
+func DefaultStartCommand(language string) string {
+	return defaultStartCommands[language]
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// SanitizeName 将任意名称转换为合法的服务名（小写字母、数字和 "-"）
+func SanitizeName(name string) string {
+	// npm scope（@org/pkg）与 Go module 路径只保留最后一段
+	if idx := strings.LastIndex(name, "/"); idx >= 0 {
+		name = name[idx+1:]
+	}
+	name = invalidNameChars.ReplaceAllString(strings.ToLower(name), "-")
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "my-service"
+	}
+	return name
+}
+
+// isSupportedVersion 检查版本能否通过 config 的版本校验
+func isSupportedVersion(language, version string) bool {
+	return (&config.LanguageConfig{Type: language, Version: version}).ValidateVersion() == nil
+}
+
+// firstExisting 返回第一个存在的文件名
+func firstExisting(fsys fs.FS, names ...string) string {
+	for _, name := range names {
+		if info, err := fs.Stat(fsys, name); err == nil && !info.IsDir() {
+			return name
+		}
+	}
+	return ""
+}
+
+// readFile 读取文件，不存在时返回空字符串
+func readFile(fsys fs.FS, name string) string {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// sortedKeys 返回 map 的有序 key 列表
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,166 @@
+package project
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func file(content string) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(content)}
+}
+
+func TestDetect_Go(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":               file("module github.com/acme/Order_Service\n\ngo 1.22.3\n"),
+		"cmd/api/main.go":      file("package main\n\nfunc main() {}\n"),
+		"cmd/worker/main.go":   file("package main\n\nfunc main() {}\n"),
+		"cmd/internal/util.go": file("package util\n"),
+	}
+
+	p, err := Detect(fsys, "fallback")
+	require.NoError(t, err)
+	assert.Equal(t, "go", p.Language)
+	assert.Equal(t, "order-service", p.Name)
+	assert.Equal(t, "1.22", p.Version)
+	assert.Equal(t, []string{"./cmd/api", "./cmd/worker"}, p.Binaries)
+	assert.Equal(t, "./cmd/api", p.LanguageConfig["main_package"])
+	assert.Empty(t, p.BuildCommand, "build command comes from the language strategy")
+	assert.Equal(t, "exec ${SERVICE_BIN_DIR}/${SERVICE_NAME}", p.StartCommand)
+}
+
+func TestDetect_GoPrefersServerMain(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":             file("module demo\n\ngo 1.19\n"),
+		"cmd/admin/main.go":  file("package main\n"),
+		"cmd/server/main.go": file("package main\n"),
+	}
+
+	p, err := Detect(fsys, "fallback")
+	require.NoError(t, err)
+	assert.NotContains(t, p.LanguageConfig, "main_package", "./cmd/server is the default")
+	assert.Equal(t, "1.19", p.Version, "versions below the minimum are kept (validation only warns)")
+}
+
+func TestDetect_NodeJS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"package.json": file(`{"name": "@acme/web-api", "scripts": {"start": "node dist/index.js", "build": "tsc"}, "engines": {"node": ">=18.17"}}`),
+	}
+
+	p, err := Detect(fsys, "fallback")
+	require.NoError(t, err)
+	assert.Equal(t, "nodejs", p.Language)
+	assert.Equal(t, "web-api", p.Name)
+	assert.Equal(t, "18", p.Version)
+	assert.Contains(t, p.BuildCommand, "npm run build")
+	assert.Equal(t, "cd ${SERVICE_ROOT} && exec npm start", p.StartCommand)
+}
+
+func TestDetect_Python(t *testing.T) {
+	fsys := fstest.MapFS{
+		"pyproject.toml": file("[build-system]\nrequires = [\"setuptools\"]\n\n[project]\nname = \"billing\"\nrequires-python = \">=3.11\"\n"),
+		"app.py":         file("print('hi')\n"),
+	}
+
+	p, err := Detect(fsys, "fallback")
+	require.NoError(t, err)
+	assert.Equal(t, "python", p.Language)
+	assert.Equal(t, "billing", p.Name)
+	assert.Equal(t, "3.11", p.Version)
+	assert.Equal(t, "exec python ${SERVICE_ROOT}/app.py", p.StartCommand)
+
+	p, err = Detect(fstest.MapFS{"requirements.txt": file("flask\n")}, "My App")
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", p.Name)
+	assert.Empty(t, p.Version)
+}
+
+func TestDetect_Java(t *testing.T) {
+	pom := `<project>
+  <parent><artifactId>spring-boot-starter-parent</artifactId></parent>
+  <artifactId>inventory</artifactId>
+  <properties><java.version>17</java.version></properties>
+</project>`
+
+	p, err := Detect(fstest.MapFS{"pom.xml": file(pom)}, "fallback")
+	require.NoError(t, err)
+	assert.Equal(t, "inventory", p.Name)
+	assert.Equal(t, "17", p.Version)
+	assert.NotContains(t, p.LanguageConfig, "build_tool")
+
+	p, err = Detect(fstest.MapFS{
+		"build.gradle":    file("java {\n  toolchain {\n    languageVersion = JavaLanguageVersion.of(21)\n  }\n}\n"),
+		"settings.gradle": file("rootProject.name = 'catalog'\n"),
+	}, "fallback")
+	require.NoError(t, err)
+	assert.Equal(t, "catalog", p.Name)
+	assert.Equal(t, "21", p.Version)
+	assert.Equal(t, "gradle", p.LanguageConfig["build_tool"])
+
+	p, err = Detect(fstest.MapFS{"build.gradle": file("sourceCompatibility = '1.8'\n")}, "legacy")
+	require.NoError(t, err)
+	assert.Equal(t, "8", p.Version)
+}
+
+func TestDetect_Rust(t *testing.T) {
+	cargo := "[package]\nname = \"edge_proxy\"\nrust-version = \"1.75.0\"\n\n[[bin]]\nname = \"proxyd\"\npath = \"src/main.rs\"\n"
+
+	p, err := Detect(fstest.MapFS{"Cargo.toml": file(cargo)}, "fallback")
+	require.NoError(t, err)
+	assert.Equal(t, "edge-proxy", p.Name)
+	assert.Equal(t, "1.75", p.Version)
+	assert.Equal(t, []string{"proxyd"}, p.Binaries)
+	assert.Equal(t, "cargo build --release && cp target/release/proxyd ${BUILD_OUTPUT_DIR}/bin/${SERVICE_NAME}", p.BuildCommand)
+
+	p, err = Detect(fstest.MapFS{"Cargo.toml": file("[package]\nname = \"gateway\"\n")}, "fallback")
+	require.NoError(t, err)
+	assert.Empty(t, p.BuildCommand, "binary matches the service name, default build command applies")
+}
+
+func TestDetect_NotDetected(t *testing.T) {
+	_, err := Detect(fstest.MapFS{"README.md": file("# hi\n")}, "fallback")
+	assert.ErrorIs(t, err, ErrNotDetected)
+}
+
+func TestDetect_LanguagePriority(t *testing.T) {
+	fsys := fstest.MapFS{
+		"go.mod":       file("module demo\n"),
+		"package.json": file(`{"name": "frontend"}`),
+	}
+
+	p, err := Detect(fsys, "fallback")
+	require.NoError(t, err)
+	assert.Equal(t, "go", p.Language)
+}
+
+func TestSanitizeName(t *testing.T) {
+	assert.Equal(t, "web-api", SanitizeName("@acme/web-api"))
+	assert.Equal(t, "order-service", SanitizeName("Order_Service"))
+	assert.Equal(t, "my-service", SanitizeName("___"))
+}
+
+func TestProject_Render(t *testing.T) {
+	p := &Project{
+		Name:           "demo",
+		Language:       "go",
+		Version:        "1.23",
+		LanguageConfig: map[string]string{"main_package": "./cmd/api"},
+		BuildCommand:   "go build -o ${BUILD_OUTPUT_DIR}/bin/${SERVICE_NAME} ./cmd/api",
+		StartCommand:   "exec ${SERVICE_BIN_DIR}/${SERVICE_NAME}",
+		Ports:          []Port{{Name: "http", Port: 8080, Protocol: "TCP", Source: "Dockerfile"}},
+		Evidence:       []string{"go.mod", "Dockerfile"},
+	}
+
+	content, err := p.Render()
+	require.NoError(t, err)
+	assert.Contains(t, content, "  version: \"1.23\"\n")
+	assert.Contains(t, content, "    main_package: \"./cmd/api\"\n")
+	assert.Contains(t, content, "    - name: http\n      port: 8080\n")
+	assert.Contains(t, content, "    build: |\n      go build")
+	assert.Contains(t, content, "      #!/bin/sh\n      exec ${SERVICE_BIN_DIR}/${SERVICE_NAME}\n")
+
+	_, err = (&Project{Name: "demo", Language: "go"}).Render()
+	assert.Error(t, err)
+}
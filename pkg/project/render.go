@@ -0,0 +1,40 @@
+package project
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/service.yaml.tmpl
+var serviceYamlTemplate string
+
+var renderTemplate = template.Must(template.New("service.yaml").Funcs(template.FuncMap{
+	"join": strings.Join,
+	"indent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = pad + line
+			}
+		}
+		return strings.Join(lines, "\n")
+	},
+}).Parse(serviceYamlTemplate))
+
+// Render 生成最小化的 service.yaml 内容
+// 调用方需保证 BuildCommand 与 StartCommand 已填充（BuildCommand 可由语言策略的默认构建命令补全）
+func (p *Project) Render() (string, error) {
+	if p.BuildCommand == "" || p.StartCommand == "" {
+		return "", fmt.Errorf("build and startup commands are required to render service.yaml")
+	}
+
+	var buf bytes.Buffer
+	if err := renderTemplate.Execute(&buf, p); err != nil {
+		return "", fmt.Errorf("failed to render service.yaml: %w", err)
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,68 @@
+package svcgen
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity of a diagnostic
+type Severity string
+
+const (
+	// SeverityError fails Render
+	SeverityError Severity = "error"
+	// SeverityWarning is reported without failing Render
+	SeverityWarning Severity = "warning"
+)
+
+// Sources of diagnostics
+const (
+	// SourceConfig diagnostics come from validating service.yaml
+	SourceConfig = "config"
+	// SourceTemplates diagnostics come from checking the template override directory
+	SourceTemplates = "templates"
+	// SourceVars diagnostics come from checking user-defined variables (vars:)
+	SourceVars = "vars"
+	// SourceVariables diagnostics are ${VAR} references to undefined variables in user commands
+	SourceVariables = "variables"
+	// SourceRender diagnostics are template rendering failures
+	SourceRender = "render"
+	// SourceGenerator diagnostics are reported by external generators
+	SourceGenerator = "generator"
+)
+
+// Diagnostic is a finding reported while validating or rendering
+type Diagnostic struct {
+	Severity Severity `json:"severity"`
+	// Source is the check that reported the diagnostic (SourceConfig, SourceRender, ...)
+	Source string `json:"source"`
+	// Field is the service.yaml path the diagnostic refers to, when known (e.g. build.commands.build)
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// String formats the diagnostic as a single message
+func (d Diagnostic) String() string {
+	return d.Message
+}
+
+// Error is returned by Render when a diagnostic has error severity
+type Error struct {
+	// Diagnostics are the errors (warnings are only in ArtifactSet.Diagnostics)
+	Diagnostics []Diagnostic
+}
+
+// Error formats the diagnostics like the svcgen CLI: rendering failures as
+// "generation failed: ...", everything else as a list of validation errors
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Diagnostics))
+	rendering := true
+	for i, d := range e.Diagnostics {
+		messages[i] = d.String()
+		rendering = rendering && d.Source == SourceRender
+	}
+	if rendering {
+		return "generation failed: " + strings.Join(messages, "; ")
+	}
+	return fmt.Sprintf("configuration validation failed:\n  - %s", strings.Join(messages, "\n  - "))
+}
@@ -0,0 +1,213 @@
+// Package svcgen is the embeddable API of svcgen: it renders all generated files of a
+// service.yaml in memory, with structured diagnostics and without printing anything.
+//
+//	cfg, err := config.NewLoader("service.yaml").Load()
+//	...
+//	set, err := svcgen.Render(ctx, cfg, svcgen.Options{ProjectDir: "."})
+//	for _, artifact := range set.Artifacts {
+//		fmt.Println(artifact.Path, artifact.Mode, artifact.Strategy)
+//	}
+//
+// The svcgen CLI is a writer on top of Render (ArtifactSet.WriteTo). Options.FS renders against
+// a project snapshot instead of the output directory, and ArtifactSet.WriteToFS writes into any
+// vfs.FS (memory, an overlay for dry runs, ...).
+package svcgen
+
+import (
+	gocontext "context"
+	"io/fs"
+	"path/filepath"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/generator"
+	"github.com/junjiewwang/service-template/pkg/generator/core"
+	"github.com/junjiewwang/service-template/pkg/vfs"
+)
+
+// Options control Render
+type Options struct {
+	// OutputDir is the directory the artifacts are meant for (default "."); it is only passed
+	// to external generators, Render does not touch it
+	OutputDir string
+	// ProjectDir enables checks against project files such as go.mod and .nvmrc (empty skips them)
+	ProjectDir string
+	// SkipValidation renders without validating the configuration first
+	SkipValidation bool
+	// StrictTemplates fails on templates that reference missing variables and
+	// on undefined ${VAR} references in user commands
+	StrictTemplates bool
+	// Concurrency limits the number of files rendered in parallel (0 uses GOMAXPROCS)
+	Concurrency int
+	// FS is the project the artifacts are rendered against, e.g. for detecting dependency
+	// files (default: the host directory OutputDir)
+	FS vfs.FS
+}
+
+// Artifact is a generated file
+type Artifact struct {
+	// Path is relative to the output directory, with forward slashes
+	Path    string      `json:"path"`
+	Content string      `json:"content"`
+	Mode    fs.FileMode `json:"mode"`
+	// Strategy is how the file is written: overwrite, incremental (only the generated block of the
+	// Makefile is replaced), block (the svcgen block of .gitignore/.dockerignore) or skip (only when missing)
+	Strategy string `json:"strategy"`
+}
+
+// ArtifactSet is the result of Render
+type ArtifactSet struct {
+	// Artifacts in output order
+	Artifacts []Artifact `json:"artifacts"`
+	// Diagnostics are all warnings and errors in the order they were reported
+	Diagnostics []Diagnostic `json:"diagnostics,omitempty"`
+}
+
+// Render validates the configuration and renders all generated files in memory.
+// When a check or a template fails it returns the set with its diagnostics and an *Error
+func Render(ctx gocontext.Context, cfg *config.ServiceConfig, opts Options) (*ArtifactSet, error) {
+	set := &ArtifactSet{}
+	outputDir := opts.OutputDir
+	if outputDir == "" {
+		outputDir = "."
+	}
+
+	gen := generator.NewGenerator(cfg, outputDir).WithStrictTemplates(opts.StrictTemplates)
+	if opts.Concurrency > 0 {
+		gen.WithConcurrency(opts.Concurrency)
+	}
+	if opts.FS != nil {
+		gen.WithFS(opts.FS)
+	}
+
+	if !opts.SkipValidation {
+		set.validate(cfg, gen, opts)
+		if err := set.err(); err != nil {
+			return set, err
+		}
+	}
+
+	files, err := gen.RenderContext(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		set.add(SeverityError, SourceRender, "", err.Error())
+		return set, set.err()
+	}
+	for _, warning := range gen.Warnings() {
+		set.add(SeverityWarning, SourceGenerator, "", warning)
+	}
+
+	for _, file := range files {
+		set.Artifacts = append(set.Artifacts, Artifact{
+			Path:     filepath.ToSlash(file.Path),
+			Content:  file.Content,
+			Mode:     file.FileMode(),
+			Strategy: file.WriteStrategy(),
+		})
+	}
+	return set, nil
+}
+
+// validate runs the checks of svcgen generate: service.yaml, template overrides, user variables
+// and, with StrictTemplates, undefined ${VAR} references
+func (s *ArtifactSet) validate(cfg *config.ServiceConfig, gen *generator.Generator, opts Options) {
+	validator := config.NewValidator(cfg).WithProjectDir(opts.ProjectDir)
+	_ = validator.Validate()
+	for _, message := range validator.Errors() {
+		s.add(SeverityError, SourceConfig, "", message)
+	}
+	for _, message := range validator.Warnings() {
+		s.add(SeverityWarning, SourceConfig, "", message)
+	}
+
+	warnings, err := core.CheckTemplateOverrides(cfg.Templates.OverrideDir)
+	if err != nil {
+		s.add(SeverityError, SourceTemplates, "", err.Error())
+	}
+	for _, warning := range warnings {
+		s.add(SeverityWarning, SourceTemplates, "", warning)
+	}
+
+	for _, err := range gen.UserVariableErrors() {
+		s.add(SeverityError, SourceVars, "", err.Error())
+	}
+
+	if opts.StrictTemplates {
+		for _, undefined := range gen.CheckVariableReferences() {
+			s.add(SeverityError, SourceVariables, undefined.Field, undefined.String())
+		}
+	}
+}
+
+// add records a diagnostic
+func (s *ArtifactSet) add(severity Severity, source, field, message string) {
+	s.Diagnostics = append(s.Diagnostics, Diagnostic{Severity: severity, Source: source, Field: field, Message: message})
+}
+
+// err returns an *Error for the error diagnostics, or nil
+func (s *ArtifactSet) err() error {
+	errs := s.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return &Error{Diagnostics: errs}
+}
+
+// Errors returns the diagnostics with error severity
+func (s *ArtifactSet) Errors() []Diagnostic {
+	return s.filter(SeverityError)
+}
+
+// Warnings returns the diagnostics with warning severity
+func (s *ArtifactSet) Warnings() []Diagnostic {
+	return s.filter(SeverityWarning)
+}
+
+func (s *ArtifactSet) filter(severity Severity) []Diagnostic {
+	var result []Diagnostic
+	for _, d := range s.Diagnostics {
+		if d.Severity == severity {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// Get returns the artifact with the given slash-separated path
+func (s *ArtifactSet) Get(path string) (Artifact, bool) {
+	for _, artifact := range s.Artifacts {
+		if artifact.Path == path {
+			return artifact, true
+		}
+	}
+	return Artifact{}, false
+}
+
+// Paths returns the artifact paths in output order
+func (s *ArtifactSet) Paths() []string {
+	paths := make([]string, len(s.Artifacts))
+	for i, artifact := range s.Artifacts {
+		paths[i] = artifact.Path
+	}
+	return paths
+}
+
+// WriteTo writes the artifacts into dir with their modes and write strategies
+func (s *ArtifactSet) WriteTo(ctx gocontext.Context, dir string) error {
+	return s.WriteToFS(ctx, vfs.OS(dir))
+}
+
+// WriteToFS writes the artifacts into fsys with their modes and write strategies
+func (s *ArtifactSet) WriteToFS(ctx gocontext.Context, fsys vfs.FS) error {
+	files := make([]generator.RenderedFile, len(s.Artifacts))
+	for i, artifact := range s.Artifacts {
+		files[i] = generator.RenderedFile{
+			Path:     filepath.FromSlash(artifact.Path),
+			Content:  artifact.Content,
+			Mode:     artifact.Mode,
+			Strategy: artifact.Strategy,
+		}
+	}
+	return generator.WriteFiles(ctx, fsys, files)
+}
@@ -0,0 +1,193 @@
+package svcgen
+
+import (
+	gocontext "context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	configtestutil "github.com/junjiewwang/service-template/pkg/config/testutil"
+	"github.com/junjiewwang/service-template/pkg/vfs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig() *configtestutil.ConfigBuilder {
+	return configtestutil.NewConfigBuilder().
+		WithService("test-service", "Test Service").
+		WithPort("http", 8080, "TCP", true).
+		WithLanguage("go").
+		WithBuilder("go_1.21", "golang:1.21", "golang:1.21").
+		WithRuntime("alpine_3.18", "alpine:3.18", "alpine:3.18").
+		WithBuilderImage("@builders.go_1.21").
+		WithRuntimeImage("@runtimes.alpine_3.18").
+		WithBuildCommand("go build -o bin/test-service").
+		WithStartupCommand("./bin/test-service")
+}
+
+// captureStdout returns what fn prints to stdout
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	fn()
+	require.NoError(t, w.Close())
+	out, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(out)
+}
+
+func TestRender(t *testing.T) {
+	cfg := newTestConfig().WithManageGitignore(true).BuildWithDefaults()
+
+	var set *ArtifactSet
+	var err error
+	output := captureStdout(t, func() {
+		set, err = Render(gocontext.Background(), cfg, Options{OutputDir: t.TempDir()})
+	})
+	require.NoError(t, err)
+	assert.Empty(t, output, "Render should not print anything")
+	assert.Empty(t, set.Errors())
+
+	compose, ok := set.Get("compose.yaml")
+	require.True(t, ok)
+	assert.Contains(t, compose.Content, "test-service")
+	assert.Equal(t, fs.FileMode(0644), compose.Mode)
+	assert.Equal(t, "overwrite", compose.Strategy)
+
+	makefile, ok := set.Get("Makefile")
+	require.True(t, ok)
+	assert.Equal(t, "incremental", makefile.Strategy)
+
+	entrypoint, ok := set.Get(".tad/build/test-service/entrypoint.sh")
+	require.True(t, ok, "paths use forward slashes: %v", set.Paths())
+	assert.Equal(t, fs.FileMode(0755), entrypoint.Mode)
+
+	for _, path := range []string{".dockerignore", ".gitignore"} {
+		artifact, ok := set.Get(path)
+		require.True(t, ok, path)
+		assert.Equal(t, "block", artifact.Strategy)
+	}
+}
+
+func TestRender_ValidationErrors(t *testing.T) {
+	cfg := newTestConfig().BuildWithDefaults()
+	cfg.Service.Name = ""
+	cfg.Vars = map[string]string{"SERVICE_ROOT": "/srv"}
+
+	set, err := Render(gocontext.Background(), cfg, Options{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "configuration validation failed:")
+	assert.Contains(t, err.Error(), "service.name is required")
+
+	var renderErr *Error
+	require.True(t, errors.As(err, &renderErr))
+	assert.Equal(t, set.Errors(), renderErr.Diagnostics)
+	assert.Empty(t, set.Artifacts)
+
+	sources := map[string]bool{}
+	for _, d := range set.Errors() {
+		sources[d.Source] = true
+	}
+	assert.True(t, sources[SourceConfig], "service.name error comes from the config validator")
+	assert.True(t, sources[SourceVars], "the vars: collision is reported separately")
+}
+
+func TestRender_SkipValidation(t *testing.T) {
+	cfg := newTestConfig().BuildWithDefaults()
+	cfg.Service.Ports[0].Name = ""
+
+	_, err := Render(gocontext.Background(), cfg, Options{})
+	require.Error(t, err)
+
+	set, err := Render(gocontext.Background(), cfg, Options{SkipValidation: true})
+	require.NoError(t, err)
+	assert.NotEmpty(t, set.Artifacts)
+}
+
+func TestRender_StrictTemplates(t *testing.T) {
+	cfg := newTestConfig().
+		WithBuildCommand("go build -o ${OUT_DIR}/app").
+		BuildWithDefaults()
+
+	_, err := Render(gocontext.Background(), cfg, Options{})
+	require.NoError(t, err, "undefined ${VAR} references are only checked with StrictTemplates")
+
+	set, err := Render(gocontext.Background(), cfg, Options{StrictTemplates: true})
+	require.Error(t, err)
+	require.Len(t, set.Errors(), 1)
+	d := set.Errors()[0]
+	assert.Equal(t, SourceVariables, d.Source)
+	assert.Equal(t, "build.commands.build", d.Field)
+	assert.Contains(t, d.Message, "${OUT_DIR}")
+}
+
+func TestRender_Cancelled(t *testing.T) {
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	cancel()
+
+	set, err := Render(ctx, newTestConfig().BuildWithDefaults(), Options{})
+	assert.ErrorIs(t, err, gocontext.Canceled)
+	assert.Nil(t, set)
+}
+
+func TestArtifactSet_WriteTo(t *testing.T) {
+	cfg := newTestConfig().WithManageGitignore(true).BuildWithDefaults()
+	set, err := Render(gocontext.Background(), cfg, Options{})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.log\n"), 0644))
+	require.NoError(t, set.WriteTo(gocontext.Background(), dir))
+
+	for _, path := range set.Paths() {
+		assert.FileExists(t, filepath.Join(dir, filepath.FromSlash(path)))
+	}
+	info, err := os.Stat(filepath.Join(dir, ".tad", "build", "test-service", "entrypoint.sh"))
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0755), info.Mode().Perm())
+
+	gitignore, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Contains(t, string(gitignore), "*.log\n", "user entries outside the svcgen block are kept")
+	assert.Contains(t, string(gitignore), "compose.yaml")
+}
+
+func TestArtifactSet_WriteToFS(t *testing.T) {
+	cfg := newTestConfig().WithDependencyFiles(true, nil).BuildWithDefaults()
+
+	// 针对内存中的项目快照渲染，并写回同一个文件系统
+	project := vfs.NewMem()
+	require.NoError(t, project.WriteFile("go.sum", []byte(""), 0644))
+	set, err := Render(gocontext.Background(), cfg, Options{OutputDir: t.TempDir(), FS: project})
+	require.NoError(t, err)
+
+	dockerfile, ok := set.Get(".tad/build/test-service/Dockerfile.test-service.amd64")
+	require.True(t, ok)
+	assert.Contains(t, dockerfile.Content, "COPY go.sum ./")
+	assert.NotContains(t, dockerfile.Content, "COPY go.mod ./", "go.mod is not in the snapshot")
+
+	require.NoError(t, set.WriteToFS(gocontext.Background(), project))
+	assert.Len(t, project.Files(), len(set.Artifacts)+1)
+	info, err := project.Stat(".tad/build/test-service/entrypoint.sh")
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0755), info.Mode().Perm())
+}
+
+func TestError_Error(t *testing.T) {
+	err := &Error{Diagnostics: []Diagnostic{{Severity: SeverityError, Source: SourceRender, Message: "failed to generate compose.yaml: boom"}}}
+	assert.Equal(t, "generation failed: failed to generate compose.yaml: boom", err.Error())
+
+	err = &Error{Diagnostics: []Diagnostic{
+		{Severity: SeverityError, Source: SourceConfig, Message: "service.name is required"},
+		{Severity: SeverityError, Source: SourceVars, Message: "vars.A: variable reference cycle: A -> A"},
+	}}
+	assert.Equal(t, "configuration validation failed:\n  - service.name is required\n  - vars.A: variable reference cycle: A -> A", err.Error())
+}
@@ -0,0 +1,144 @@
+package vfs
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"sync"
+	"testing/fstest"
+	"time"
+)
+
+// Mem is an in-memory FS, safe for concurrent use. The zero value is not usable, use NewMem.
+type Mem struct {
+	mu    sync.RWMutex
+	files fstest.MapFS
+}
+
+// NewMem returns an empty in-memory file system
+func NewMem() *Mem {
+	return &Mem{files: fstest.MapFS{}}
+}
+
+// Open opens the named file
+func (m *Mem) Open(name string) (fs.File, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	// MapFS 的 file 持有 *MapFile，写入时总是替换 entry 而不是修改它，已打开的文件不受影响
+	return m.files.Open(name)
+}
+
+// Stat returns the FileInfo of the named file
+func (m *Mem) Stat(name string) (fs.FileInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.files.Stat(name)
+}
+
+// ReadFile reads the named file
+func (m *Mem) ReadFile(name string) ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.files.ReadFile(name)
+}
+
+// ReadDir reads the named directory
+func (m *Mem) ReadDir(name string) ([]fs.DirEntry, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.files.ReadDir(name)
+}
+
+// WriteFile writes the named file; like os.WriteFile, perm is only used when the file is created
+func (m *Mem) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if dir := path.Dir(name); dir != "." {
+		info, err := m.files.Stat(dir)
+		if err != nil {
+			return &fs.PathError{Op: "write", Path: name, Err: fs.ErrNotExist}
+		}
+		if !info.IsDir() {
+			return &fs.PathError{Op: "write", Path: name, Err: errNotDir}
+		}
+	}
+
+	mode := perm & fs.ModePerm
+	if existing, ok := m.files[name]; ok {
+		if existing.Mode.IsDir() {
+			return &fs.PathError{Op: "write", Path: name, Err: errIsDir}
+		}
+		mode = existing.Mode
+	}
+
+	m.files[name] = &fstest.MapFile{
+		Data:    append([]byte(nil), data...),
+		Mode:    mode,
+		ModTime: time.Now(),
+	}
+	return nil
+}
+
+// MkdirAll creates the named directory and its parents
+func (m *Mem) MkdirAll(name string, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var dirs []string
+	for dir := name; dir != "."; dir = path.Dir(dir) {
+		dirs = append(dirs, dir)
+	}
+	for i := len(dirs) - 1; i >= 0; i-- {
+		dir := dirs[i]
+		if existing, ok := m.files[dir]; ok {
+			if !existing.Mode.IsDir() {
+				return &fs.PathError{Op: "mkdir", Path: dir, Err: errNotDir}
+			}
+			continue
+		}
+		m.files[dir] = &fstest.MapFile{Mode: fs.ModeDir | perm&fs.ModePerm, ModTime: time.Now()}
+	}
+	return nil
+}
+
+// Chmod changes the permission bits of the named file
+func (m *Mem) Chmod(name string, mode fs.FileMode) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.files[name]
+	if !ok {
+		return &fs.PathError{Op: "chmod", Path: name, Err: fs.ErrNotExist}
+	}
+	updated := *existing
+	updated.Mode = existing.Mode&^fs.ModePerm | mode&fs.ModePerm
+	m.files[name] = &updated
+	return nil
+}
+
+// Files returns the names of all regular files, sorted
+func (m *Mem) Files() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var names []string
+	for name, file := range m.files {
+		if file.Mode.IsRegular() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
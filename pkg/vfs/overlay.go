@@ -0,0 +1,224 @@
+package vfs
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+// overlayFS reads from upper, falling back to lower, and writes only to upper
+type overlayFS struct {
+	upper FS
+	lower fs.FS
+}
+
+// Overlay returns a file system that shows upper on top of lower. Writes go to upper, so
+// Overlay(NewMem(), OS(dir)) renders against the files in dir without modifying them.
+func Overlay(upper FS, lower fs.FS) FS {
+	return &overlayFS{upper: upper, lower: lower}
+}
+
+// Open opens the named file; directories list the entries of both layers
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	info, err := o.upper.Stat(name)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		return o.lower.Open(name)
+	}
+	if !info.IsDir() {
+		return o.upper.Open(name)
+	}
+
+	entries, err := o.ReadDir(name)
+	if err != nil {
+		return nil, err
+	}
+	return &dirFile{info: info, entries: entries}, nil
+}
+
+// Stat returns the FileInfo of the named file
+func (o *overlayFS) Stat(name string) (fs.FileInfo, error) {
+	info, err := o.upper.Stat(name)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return info, err
+	}
+	return fs.Stat(o.lower, name)
+}
+
+// ReadFile reads the named file
+func (o *overlayFS) ReadFile(name string) ([]byte, error) {
+	data, err := o.upper.ReadFile(name)
+	if err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return data, err
+	}
+	return fs.ReadFile(o.lower, name)
+}
+
+// ReadDir reads the named directory, merging the entries of both layers (upper wins)
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	info, err := o.upper.Stat(name)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		return fs.ReadDir(o.lower, name)
+	}
+	if !info.IsDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: errNotDir}
+	}
+
+	upperEntries, err := fs.ReadDir(o.upper, name)
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]fs.DirEntry, len(upperEntries))
+	for _, entry := range upperEntries {
+		merged[entry.Name()] = entry
+	}
+
+	// lower 中没有该目录或它不是目录时，只有 upper 的 entry
+	if lowerInfo, err := fs.Stat(o.lower, name); err == nil && lowerInfo.IsDir() {
+		lowerEntries, err := fs.ReadDir(o.lower, name)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range lowerEntries {
+			if _, ok := merged[entry.Name()]; !ok {
+				merged[entry.Name()] = entry
+			}
+		}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(merged))
+	for _, entry := range merged {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// WriteFile writes the named file to upper, keeping the mode of a file that exists in lower
+func (o *overlayFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	if !fs.ValidPath(name) || name == "." {
+		return &fs.PathError{Op: "write", Path: name, Err: fs.ErrInvalid}
+	}
+	if err := o.copyUpDir(path.Dir(name)); err != nil {
+		return &fs.PathError{Op: "write", Path: name, Err: unwrapPathError(err)}
+	}
+
+	if _, err := o.upper.Stat(name); errors.Is(err, fs.ErrNotExist) {
+		if info, err := fs.Stat(o.lower, name); err == nil && info.Mode().IsRegular() {
+			perm = info.Mode().Perm()
+		}
+	}
+	return o.upper.WriteFile(name, data, perm)
+}
+
+// MkdirAll creates the named directory and its parents in upper
+func (o *overlayFS) MkdirAll(name string, perm fs.FileMode) error {
+	if !fs.ValidPath(name) {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: fs.ErrInvalid}
+	}
+	for dir := name; dir != "."; dir = path.Dir(dir) {
+		if info, err := o.Stat(dir); err == nil && !info.IsDir() {
+			return &fs.PathError{Op: "mkdir", Path: dir, Err: errNotDir}
+		}
+	}
+	return o.upper.MkdirAll(name, perm)
+}
+
+// Chmod changes the mode of the named file, copying it from lower to upper first if needed
+func (o *overlayFS) Chmod(name string, mode fs.FileMode) error {
+	if _, err := o.upper.Stat(name); err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return o.upper.Chmod(name, mode)
+	}
+
+	info, err := fs.Stat(o.lower, name)
+	if err != nil {
+		return &fs.PathError{Op: "chmod", Path: name, Err: unwrapPathError(err)}
+	}
+	if info.IsDir() {
+		return o.MkdirAll(name, mode)
+	}
+	data, err := fs.ReadFile(o.lower, name)
+	if err != nil {
+		return err
+	}
+	if err := o.WriteFile(name, data, info.Mode().Perm()); err != nil {
+		return err
+	}
+	return o.upper.Chmod(name, mode)
+}
+
+// copyUpDir creates dir in upper when it only exists in lower
+func (o *overlayFS) copyUpDir(dir string) error {
+	if dir == "." {
+		return nil
+	}
+	if info, err := o.upper.Stat(dir); err == nil {
+		if !info.IsDir() {
+			return &fs.PathError{Op: "stat", Path: dir, Err: errNotDir}
+		}
+		return nil
+	}
+
+	info, err := fs.Stat(o.lower, dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return &fs.PathError{Op: "stat", Path: dir, Err: errNotDir}
+	}
+	if err := o.copyUpDir(path.Dir(dir)); err != nil {
+		return err
+	}
+	return o.upper.MkdirAll(dir, info.Mode().Perm())
+}
+
+func unwrapPathError(err error) error {
+	var pathErr *fs.PathError
+	if errors.As(err, &pathErr) {
+		return pathErr.Err
+	}
+	return err
+}
+
+// dirFile is an open directory of an overlay
+type dirFile struct {
+	info    fs.FileInfo
+	entries []fs.DirEntry
+	offset  int
+}
+
+func (d *dirFile) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *dirFile) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.info.Name(), Err: errIsDir}
+}
+
+func (d *dirFile) Close() error {
+	return nil
+}
+
+// ReadDir returns the next n entries, or all remaining entries when n <= 0
+func (d *dirFile) ReadDir(n int) ([]fs.DirEntry, error) {
+	remaining := d.entries[d.offset:]
+	if n <= 0 {
+		d.offset = len(d.entries)
+		return remaining, nil
+	}
+	if len(remaining) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	d.offset += n
+	return remaining[:n], nil
+}
@@ -0,0 +1,115 @@
+// Package vfs provides writable file systems for generation: the host file system (OS),
+// an in-memory file system (Mem) and an overlay of a writable file system on top of a
+// read-only one (Overlay).
+//
+// Reads go through io/fs, so names are slash-separated and relative to the root of the
+// file system (see fs.ValidPath); "." is the root itself.
+package vfs
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+var (
+	errNotDir = errors.New("not a directory")
+	errIsDir  = errors.New("is a directory")
+)
+
+// FS is a file system that can be read through io/fs and written
+type FS interface {
+	fs.StatFS
+	fs.ReadFileFS
+
+	// WriteFile writes data to the named file, creating it with perm if necessary;
+	// the parent directory must exist
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	// MkdirAll creates a directory with perm, along with any missing parents
+	MkdirAll(name string, perm fs.FileMode) error
+	// Chmod changes the permission bits of the named file
+	Chmod(name string, mode fs.FileMode) error
+}
+
+// osFS is FS on a host directory
+type osFS struct {
+	dir  string
+	fsys fs.FS
+}
+
+// OS returns the file system rooted at the host directory dir
+func OS(dir string) FS {
+	return &osFS{dir: dir, fsys: os.DirFS(dir)}
+}
+
+// Open opens the named file
+func (f *osFS) Open(name string) (fs.File, error) {
+	return f.fsys.Open(name)
+}
+
+// Stat returns the FileInfo of the named file
+func (f *osFS) Stat(name string) (fs.FileInfo, error) {
+	return fs.Stat(f.fsys, name)
+}
+
+// ReadFile reads the named file
+func (f *osFS) ReadFile(name string) ([]byte, error) {
+	return fs.ReadFile(f.fsys, name)
+}
+
+// WriteFile writes the named file
+func (f *osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	hostPath, err := f.hostPath("write", name)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(hostPath, data, perm)
+}
+
+// MkdirAll creates the named directory and its parents
+func (f *osFS) MkdirAll(name string, perm fs.FileMode) error {
+	hostPath, err := f.hostPath("mkdir", name)
+	if err != nil {
+		return err
+	}
+	return os.MkdirAll(hostPath, perm)
+}
+
+// Chmod changes the mode of the named file
+func (f *osFS) Chmod(name string, mode fs.FileMode) error {
+	hostPath, err := f.hostPath("chmod", name)
+	if err != nil {
+		return err
+	}
+	return os.Chmod(hostPath, mode)
+}
+
+// hostPath converts a file system name to a host path
+func (f *osFS) hostPath(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	return filepath.Join(f.dir, filepath.FromSlash(name)), nil
+}
+
+// MkdirParent creates the parent directory of the named file
+func MkdirParent(fsys FS, name string) error {
+	dir := path.Dir(name)
+	if dir == "." {
+		return nil
+	}
+	return fsys.MkdirAll(dir, 0755)
+}
+
+// Exists reports whether the named file or directory exists
+func Exists(fsys fs.FS, name string) bool {
+	_, err := fs.Stat(fsys, name)
+	return err == nil
+}
+
+// IsNotExist reports whether err reports a missing file
+func IsNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
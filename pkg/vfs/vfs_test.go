@@ -0,0 +1,170 @@
+package vfs
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTree writes a small project tree through fsys
+func writeTree(t *testing.T, fsys FS) {
+	t.Helper()
+	require.NoError(t, fsys.MkdirAll("src/app", 0755))
+	require.NoError(t, fsys.WriteFile("go.mod", []byte("module app\n"), 0644))
+	require.NoError(t, fsys.WriteFile("src/app/main.go", []byte("package main\n"), 0644))
+	require.NoError(t, fsys.WriteFile("build.sh", []byte("#!/bin/sh\n"), 0644))
+	require.NoError(t, fsys.Chmod("build.sh", 0755))
+}
+
+// checkTree verifies the tree written by writeTree
+func checkTree(t *testing.T, fsys FS) {
+	t.Helper()
+	data, err := fsys.ReadFile("src/app/main.go")
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(data))
+
+	info, err := fsys.Stat("build.sh")
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0755), info.Mode().Perm())
+
+	info, err = fsys.Stat("src/app")
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	assert.NoError(t, fstest.TestFS(fsys, "go.mod", "build.sh", "src/app/main.go"))
+}
+
+func TestOS(t *testing.T) {
+	dir := t.TempDir()
+	fsys := OS(dir)
+	writeTree(t, fsys)
+	checkTree(t, fsys)
+
+	data, err := os.ReadFile(filepath.Join(dir, "src", "app", "main.go"))
+	require.NoError(t, err)
+	assert.Equal(t, "package main\n", string(data))
+
+	assert.ErrorIs(t, fsys.WriteFile("../escape", nil, 0644), fs.ErrInvalid)
+	assert.ErrorIs(t, fsys.MkdirAll("/abs", 0755), fs.ErrInvalid)
+}
+
+func TestMem(t *testing.T) {
+	fsys := NewMem()
+	writeTree(t, fsys)
+	checkTree(t, fsys)
+
+	assert.Equal(t, []string{"build.sh", "go.mod", "src/app/main.go"}, fsys.Files())
+
+	// 父目录必须存在
+	err := fsys.WriteFile("missing/file", nil, 0644)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	assert.ErrorIs(t, fsys.WriteFile("go.mod/file", nil, 0644), errNotDir)
+	assert.ErrorIs(t, fsys.WriteFile("src", nil, 0644), errIsDir)
+	assert.ErrorIs(t, fsys.MkdirAll("go.mod/dir", 0755), errNotDir)
+	assert.ErrorIs(t, fsys.Chmod("missing", 0644), fs.ErrNotExist)
+
+	// 覆盖写入保留原有权限
+	require.NoError(t, fsys.WriteFile("build.sh", []byte("#!/bin/bash\n"), 0644))
+	info, err := fsys.Stat("build.sh")
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0755), info.Mode().Perm())
+}
+
+func TestMem_OpenFileIsStable(t *testing.T) {
+	fsys := NewMem()
+	require.NoError(t, fsys.WriteFile("a.txt", []byte("old"), 0644))
+
+	f, err := fsys.Open("a.txt")
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, fsys.WriteFile("a.txt", []byte("new"), 0644))
+
+	buf := make([]byte, 3)
+	_, err = f.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "old", string(buf))
+}
+
+func TestOverlay(t *testing.T) {
+	lower := fstest.MapFS{
+		"go.mod":          {Data: []byte("module app\n"), Mode: 0644},
+		"scripts/run.sh":  {Data: []byte("#!/bin/sh\n"), Mode: 0755},
+		"scripts/env.txt": {Data: []byte("A=1\n"), Mode: 0644},
+	}
+	upper := NewMem()
+	fsys := Overlay(upper, lower)
+
+	// 读取回落到 lower
+	data, err := fsys.ReadFile("go.mod")
+	require.NoError(t, err)
+	assert.Equal(t, "module app\n", string(data))
+
+	// 写入只落在 upper，并在 upper 中补齐父目录
+	require.NoError(t, fsys.WriteFile("scripts/env.txt", []byte("A=2\n"), 0600))
+	require.NoError(t, fsys.MkdirAll("out", 0755))
+	require.NoError(t, fsys.WriteFile("out/Dockerfile", []byte("FROM scratch\n"), 0644))
+	assert.Equal(t, "A=1\n", string(lower["scripts/env.txt"].Data))
+	assert.Equal(t, []string{"out/Dockerfile", "scripts/env.txt"}, upper.Files())
+
+	data, err = fsys.ReadFile("scripts/env.txt")
+	require.NoError(t, err)
+	assert.Equal(t, "A=2\n", string(data))
+
+	// 覆盖 lower 中的文件时沿用其权限
+	info, err := fsys.Stat("scripts/env.txt")
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0644), info.Mode().Perm())
+
+	// Chmod 先把文件复制到 upper
+	require.NoError(t, fsys.Chmod("go.mod", 0600))
+	info, err = upper.Stat("go.mod")
+	require.NoError(t, err)
+	assert.Equal(t, fs.FileMode(0600), info.Mode().Perm())
+	assert.Equal(t, fs.FileMode(0644), lower["go.mod"].Mode)
+
+	entries, err := fs.ReadDir(fsys, "scripts")
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"env.txt", "run.sh"}, names)
+
+	assert.ErrorIs(t, fsys.WriteFile("missing/file", nil, 0644), fs.ErrNotExist)
+	assert.ErrorIs(t, fsys.MkdirAll("go.mod/dir", 0755), errNotDir)
+
+	assert.NoError(t, fstest.TestFS(fsys, "go.mod", "scripts/run.sh", "scripts/env.txt", "out/Dockerfile"))
+}
+
+func TestOverlay_OnOS(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("bin/\n"), 0644))
+
+	fsys := Overlay(NewMem(), OS(dir))
+	require.NoError(t, fsys.WriteFile(".gitignore", []byte("bin/\ndist/\n"), 0644))
+
+	data, err := fsys.ReadFile(".gitignore")
+	require.NoError(t, err)
+	assert.Equal(t, "bin/\ndist/\n", string(data))
+
+	data, err = os.ReadFile(filepath.Join(dir, ".gitignore"))
+	require.NoError(t, err)
+	assert.Equal(t, "bin/\n", string(data))
+}
+
+func TestHelpers(t *testing.T) {
+	fsys := NewMem()
+	require.NoError(t, MkdirParent(fsys, "a/b/c.txt"))
+	require.NoError(t, MkdirParent(fsys, "top.txt"))
+	assert.True(t, Exists(fsys, "a/b"))
+	assert.False(t, Exists(fsys, "a/b/c.txt"))
+
+	_, err := fsys.ReadFile("a/b/c.txt")
+	assert.True(t, IsNotExist(err))
+}
@@ -0,0 +1,219 @@
+// Package wizard 实现 svcgen init --interactive 的问答式配置向导
+// 交互模式逐项提问；非 TTY 环境下从 answers 文件 / 命令行参数读取答案，便于脚本化与测试
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/junjiewwang/service-template/pkg/project"
+	"gopkg.in/yaml.v3"
+)
+
+// 镜像配置方式
+const (
+	ImageFormatAuto    = "auto"     // 根据 language.version 自动推导默认镜像
+	ImageFormatDirect  = "direct"   // 单个镜像地址（多架构 manifest）
+	ImageFormatPreset  = "preset"   // 引用镜像目录中的预设（@builders.* / @runtimes.*）
+	ImageFormatPerArch = "per-arch" // 分别指定 amd64 / arm64 镜像
+)
+
+// ImageFormats 支持的镜像配置方式
+var ImageFormats = []string{ImageFormatAuto, ImageFormatDirect, ImageFormatPreset, ImageFormatPerArch}
+
+// HealthcheckNone 不启用健康检查
+const HealthcheckNone = "none"
+
+// HealthcheckTypes 向导支持的健康检查类型（custom 需要编写脚本，建议生成后手动配置）
+var HealthcheckTypes = []string{
+	config.HealthcheckTypeDefault,
+	config.HealthcheckTypeHTTP,
+	config.HealthcheckTypeTCP,
+	config.HealthcheckTypeBinary,
+	HealthcheckNone,
+}
+
+// Answers 向导的全部答案，也是 --answers 文件的格式
+type Answers struct {
+	Name        string              `yaml:"name"`
+	Description string              `yaml:"description,omitempty"`
+	Language    string              `yaml:"language"`
+	Version     string              `yaml:"version,omitempty"`
+	Ports       []config.PortConfig `yaml:"ports,omitempty"`
+	Image       ImageAnswers        `yaml:"image,omitempty"`
+	Plugins     []PluginAnswers     `yaml:"plugins,omitempty"`
+	Healthcheck HealthcheckAnswers  `yaml:"healthcheck,omitempty"`
+	Kubernetes  KubernetesAnswers   `yaml:"kubernetes,omitempty"`
+}
+
+// ImageAnswers 镜像相关答案
+type ImageAnswers struct {
+	Format string `yaml:"format,omitempty"`
+	// direct: 镜像地址；preset: 预设名（如 org/go_1.23）
+	Builder string `yaml:"builder,omitempty"`
+	Runtime string `yaml:"runtime,omitempty"`
+	// per-arch
+	BuilderAMD64 string `yaml:"builder_amd64,omitempty"`
+	BuilderARM64 string `yaml:"builder_arm64,omitempty"`
+	RuntimeAMD64 string `yaml:"runtime_amd64,omitempty"`
+	RuntimeARM64 string `yaml:"runtime_arm64,omitempty"`
+	// preset: 导入的镜像目录（如 embedded:org）
+	Catalog string `yaml:"catalog,omitempty"`
+}
+
+// PluginAnswers 插件答案
+type PluginAnswers struct {
+	Name           string `yaml:"name"`
+	Description    string `yaml:"description,omitempty"`
+	DownloadURL    string `yaml:"download_url"`
+	InstallCommand string `yaml:"install_command,omitempty"`
+}
+
+// HealthcheckAnswers 健康检查答案
+type HealthcheckAnswers struct {
+	Type   string `yaml:"type,omitempty"`
+	Path   string `yaml:"path,omitempty"`
+	Port   int    `yaml:"port,omitempty"`
+	Binary string `yaml:"binary,omitempty"`
+}
+
+// KubernetesAnswers K8s 本地开发答案
+type KubernetesAnswers struct {
+	Enabled   bool   `yaml:"enabled"`
+	Namespace string `yaml:"namespace,omitempty"`
+}
+
+// DefaultPluginInstallCommand 插件默认安装命令：下载到插件工作目录
+const DefaultPluginInstallCommand = `mkdir -p "${PLUGIN_WORK_DIR}" && curl -fsSL "${PLUGIN_DOWNLOAD_URL}" -o "${PLUGIN_WORK_DIR}/$(basename "${PLUGIN_DOWNLOAD_URL}")"`
+
+// DefaultPluginInstallDir 插件默认安装目录
+const DefaultPluginInstallDir = "/plugins"
+
+// LoadAnswers 从 YAML 文件读取答案
+func LoadAnswers(path string) (*Answers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read answers file: %w", err)
+	}
+
+	var answers Answers
+	if err := yaml.Unmarshal(data, &answers); err != nil {
+		return nil, fmt.Errorf("failed to parse answers file: %w", err)
+	}
+	return &answers, nil
+}
+
+// ParsePorts 解析端口列表，格式为 "name:port[/protocol]"，多个端口以逗号分隔
+// 省略 name 时（如 "8080"）使用 port-<n>
+func ParsePorts(value string) ([]config.PortConfig, error) {
+	var ports []config.PortConfig
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		name, rest, hasName := strings.Cut(item, ":")
+		if !hasName {
+			name, rest = "", item
+		}
+		portStr, protocol, _ := strings.Cut(rest, "/")
+		port, err := strconv.Atoi(portStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port '%s' (expected name:port[/protocol])", item)
+		}
+		if name == "" {
+			name = "port-" + portStr
+		}
+		if protocol == "" {
+			protocol = "TCP"
+		}
+		ports = append(ports, config.PortConfig{
+			Name:     name,
+			Port:     port,
+			Protocol: strings.ToUpper(protocol),
+			Expose:   true,
+		})
+	}
+	return ports, nil
+}
+
+// FormatPorts 将端口列表格式化为 ParsePorts 接受的字符串
+func FormatPorts(ports []config.PortConfig) string {
+	items := make([]string, 0, len(ports))
+	for _, p := range ports {
+		item := fmt.Sprintf("%s:%d", p.Name, p.Port)
+		if p.Protocol != "" && !strings.EqualFold(p.Protocol, "TCP") {
+			item += "/" + strings.ToUpper(p.Protocol)
+		}
+		items = append(items, item)
+	}
+	return strings.Join(items, ",")
+}
+
+// Config 将答案转换为服务配置（用于校验；preset 方式会加载导入的镜像目录）
+func (a *Answers) Config() (*config.ServiceConfig, error) {
+	cfg := &config.ServiceConfig{
+		Service: config.ServiceInfo{
+			Name:        a.Name,
+			Description: a.Description,
+			Ports:       a.Ports,
+		},
+		Language: config.LanguageConfig{Type: a.Language, Version: a.Version},
+		Runtime: config.RuntimeConfig{
+			Startup: config.StartupConfig{Command: a.StartCommand()},
+		},
+	}
+
+	switch a.Image.Format {
+	case ImageFormatDirect:
+		cfg.Build.BuilderImage = config.NewImageSpec(a.Image.Builder)
+		cfg.Build.RuntimeImage = config.NewImageSpec(a.Image.Runtime)
+	case ImageFormatPerArch:
+		cfg.Build.BuilderImage = config.NewImageSpecPerArch(a.Image.BuilderAMD64, a.Image.BuilderARM64)
+		cfg.Build.RuntimeImage = config.NewImageSpecPerArch(a.Image.RuntimeAMD64, a.Image.RuntimeARM64)
+	case ImageFormatPreset:
+		cfg.BaseImages.Imports = []string{a.Image.Catalog}
+		cfg.Build.BuilderImage = config.NewImageSpec("@builders." + a.Image.Builder)
+		cfg.Build.RuntimeImage = config.NewImageSpec("@runtimes." + a.Image.Runtime)
+		if err := cfg.BaseImages.ResolveImports("."); err != nil {
+			return cfg, fmt.Errorf("base_images.imports: %w", err)
+		}
+	}
+
+	if len(a.Plugins) > 0 {
+		cfg.Plugins.InstallDir = DefaultPluginInstallDir
+		for _, p := range a.Plugins {
+			cfg.Plugins.Items = append(cfg.Plugins.Items, config.PluginConfig{
+				Name:           p.Name,
+				Description:    p.Description,
+				DownloadURL:    config.NewStaticDownloadURL(p.DownloadURL),
+				InstallCommand: p.InstallCommand,
+			})
+		}
+	}
+
+	if a.Healthcheck.Type != "" && a.Healthcheck.Type != HealthcheckNone {
+		cfg.Runtime.Healthcheck = config.HealthcheckConfig{
+			Enabled: true,
+			Type:    a.Healthcheck.Type,
+			Path:    a.Healthcheck.Path,
+			Port:    a.Healthcheck.Port,
+			Binary:  a.Healthcheck.Binary,
+		}
+	}
+
+	cfg.LocalDev.Kubernetes = config.KubernetesConfig{
+		Enabled:   a.Kubernetes.Enabled,
+		Namespace: a.Kubernetes.Namespace,
+	}
+	return cfg, nil
+}
+
+// StartCommand 返回语言对应的默认启动命令
+func (a *Answers) StartCommand() string {
+	return project.DefaultStartCommand(a.Language)
+}
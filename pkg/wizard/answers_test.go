@@ -0,0 +1,71 @@
+package wizard
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePorts(t *testing.T) {
+	ports, err := ParsePorts("http:8080, 9090 ,dns:53/udp")
+	require.NoError(t, err)
+	assert.Equal(t, []config.PortConfig{
+		{Name: "http", Port: 8080, Protocol: "TCP", Expose: true},
+		{Name: "port-9090", Port: 9090, Protocol: "TCP", Expose: true},
+		{Name: "dns", Port: 53, Protocol: "UDP", Expose: true},
+	}, ports)
+	assert.Equal(t, "http:8080,port-9090:9090,dns:53/UDP", FormatPorts(ports))
+
+	_, err = ParsePorts("http:abc")
+	assert.ErrorContains(t, err, "invalid port 'http:abc'")
+}
+
+func TestLoadAnswers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+name: demo
+language: go
+ports:
+  - name: http
+    port: 8080
+    protocol: TCP
+image:
+  format: per-arch
+  builder_amd64: golang:1.23
+kubernetes:
+  enabled: true
+`), 0644))
+
+	answers, err := LoadAnswers(path)
+	require.NoError(t, err)
+	assert.Equal(t, "demo", answers.Name)
+	assert.Equal(t, ImageFormatPerArch, answers.Image.Format)
+	assert.Equal(t, "golang:1.23", answers.Image.BuilderAMD64)
+	assert.True(t, answers.Kubernetes.Enabled)
+
+	_, err = LoadAnswers(filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "failed to read answers file")
+}
+
+func TestAnswers_Config(t *testing.T) {
+	answers := &Answers{
+		Name:        "demo",
+		Language:    "go",
+		Image:       ImageAnswers{Format: ImageFormatPerArch, BuilderAMD64: "b:amd", BuilderARM64: "b:arm", RuntimeAMD64: "r:amd", RuntimeARM64: "r:arm"},
+		Healthcheck: HealthcheckAnswers{Type: HealthcheckNone},
+	}
+
+	cfg, err := answers.Config()
+	require.NoError(t, err)
+	assert.Equal(t, config.ImageSpecPerArch, cfg.Build.BuilderImage.Kind())
+	assert.False(t, cfg.Runtime.Healthcheck.Enabled)
+	assert.Equal(t, "exec ${SERVICE_BIN_DIR}/${SERVICE_NAME}", cfg.Runtime.Startup.Command)
+
+	answers.Image = ImageAnswers{Format: ImageFormatPreset, Catalog: "embedded:missing"}
+	_, err = answers.Config()
+	assert.ErrorContains(t, err, "base_images.imports")
+}
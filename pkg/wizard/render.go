@@ -0,0 +1,37 @@
+package wizard
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/service.yaml.tmpl
+var serviceYamlTemplate string
+
+var renderTemplate = template.Must(template.New("service.yaml").Funcs(template.FuncMap{
+	"quote":      strconv.Quote,
+	"installDir": func() string { return DefaultPluginInstallDir },
+	"indent": func(spaces int, s string) string {
+		pad := strings.Repeat(" ", spaces)
+		lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+		for i, line := range lines {
+			if line != "" {
+				lines[i] = pad + line
+			}
+		}
+		return strings.Join(lines, "\n")
+	},
+}).Parse(serviceYamlTemplate))
+
+// Render 生成带注释的 service.yaml，只包含用户选择的配置段
+func (a *Answers) Render() (string, error) {
+	var buf bytes.Buffer
+	if err := renderTemplate.Execute(&buf, a); err != nil {
+		return "", fmt.Errorf("failed to render service.yaml: %w", err)
+	}
+	return buf.String(), nil
+}
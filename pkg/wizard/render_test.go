@@ -0,0 +1,72 @@
+package wizard
+
+import (
+	"testing"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnswers_Render(t *testing.T) {
+	tests := []struct {
+		name       string
+		answers    Answers
+		contains   []string
+		notContain []string
+	}{
+		{
+			name:       "minimal",
+			answers:    Answers{Name: "demo", Language: "go", Healthcheck: HealthcheckAnswers{Type: config.HealthcheckTypeDefault}},
+			contains:   []string{"name: demo", "type: go", "根据 language.version 自动推导", "type: default"},
+			notContain: []string{"base_images:", "plugins:", "local_dev:", "ports:", "  version:"},
+		},
+		{
+			name: "full",
+			answers: Answers{
+				Name:     "demo",
+				Language: "python",
+				Version:  "3.11",
+				Ports:    []config.PortConfig{{Name: "http", Port: 8000, Protocol: "TCP", Expose: true}},
+				Image:    ImageAnswers{Format: ImageFormatPreset, Catalog: "embedded:org", Builder: "org/python_3.11", Runtime: "org/alpine_3.18"},
+				Plugins: []PluginAnswers{{
+					Name:           "agent",
+					DownloadURL:    "https://example.com/agent.tar.gz",
+					InstallCommand: "curl -fsSL ${PLUGIN_DOWNLOAD_URL} -o ${PLUGIN_WORK_DIR}/agent.tar.gz",
+				}},
+				Healthcheck: HealthcheckAnswers{Type: config.HealthcheckTypeHTTP, Path: "/healthz"},
+				Kubernetes:  KubernetesAnswers{Enabled: true, Namespace: "dev"},
+			},
+			contains: []string{
+				"    - \"embedded:org\"",
+				"builder_image: \"@builders.org/python_3.11\"",
+				"version: \"3.11\"",
+				"        curl -fsSL ${PLUGIN_DOWNLOAD_URL}",
+				"path: /healthz",
+				"namespace: dev",
+			},
+		},
+		{
+			name:     "no healthcheck",
+			answers:  Answers{Name: "demo", Language: "nodejs", Healthcheck: HealthcheckAnswers{Type: HealthcheckNone}},
+			contains: []string{"enabled: false", "exec node ${SERVICE_ROOT}/index.js"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := tt.answers.Render()
+			require.NoError(t, err)
+			for _, s := range tt.contains {
+				assert.Contains(t, content, s)
+			}
+			for _, s := range tt.notContain {
+				assert.NotContains(t, content, s)
+			}
+
+			cfg, err := config.LoadFromBytes([]byte(content))
+			require.NoError(t, err)
+			assert.NoError(t, config.NewValidator(cfg).Validate())
+		})
+	}
+}
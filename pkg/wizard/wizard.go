@@ -0,0 +1,420 @@
+package wizard
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+)
+
+// Wizard 问答式配置向导
+// 每个答案写入 Answers 后立即用 config.Validator 校验对应配置段：
+// 交互模式下校验失败会提示并重新提问，非交互模式下直接返回错误
+type Wizard struct {
+	in          *bufio.Reader
+	out         io.Writer
+	interactive bool
+	languages   []string
+	answers     *Answers
+}
+
+// New 创建向导；默认交互模式，答案为空
+func New(in io.Reader, out io.Writer) *Wizard {
+	return &Wizard{
+		in:          bufio.NewReader(in),
+		out:         out,
+		interactive: true,
+		answers:     &Answers{},
+	}
+}
+
+// WithInteractive 设置是否逐项提问；关闭时使用预填答案（--answers 文件与命令行参数）
+func (w *Wizard) WithInteractive(interactive bool) *Wizard {
+	w.interactive = interactive
+	return w
+}
+
+// WithLanguages 设置可选语言列表（来自 LanguageService.ListSupportedLanguages）
+func (w *Wizard) WithLanguages(languages []string) *Wizard {
+	w.languages = languages
+	return w
+}
+
+// WithAnswers 设置预填答案：交互模式下作为每个问题的默认值，非交互模式下直接使用
+func (w *Wizard) WithAnswers(answers *Answers) *Wizard {
+	if answers != nil {
+		w.answers = answers
+	}
+	return w
+}
+
+// Run 依次完成所有问题，返回通过完整校验的答案
+func (w *Wizard) Run() (*Answers, error) {
+	steps := []func() error{
+		w.askService,
+		w.askLanguage,
+		w.askPorts,
+		w.askImages,
+		w.askPlugins,
+		w.askHealthcheck,
+		w.askKubernetes,
+	}
+	for _, step := range steps {
+		if err := step(); err != nil {
+			return nil, err
+		}
+	}
+
+	// 最终整体校验，确保生成的配置可以直接使用
+	if errs := w.validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("configuration validation failed:\n  - %s", strings.Join(errs, "\n  - "))
+	}
+	return w.answers, nil
+}
+
+// ============================================
+// 问题
+// ============================================
+
+func (w *Wizard) askService() error {
+	a := w.answers
+	if err := w.ask("Service name", a.Name, nil, func(v string) error {
+		if v == "" {
+			return errors.New("service name is required")
+		}
+		a.Name = v
+		return nil
+	}, "service.name"); err != nil {
+		return err
+	}
+	return w.ask("Description", a.Description, nil, func(v string) error {
+		a.Description = v
+		return nil
+	})
+}
+
+func (w *Wizard) askLanguage() error {
+	a := w.answers
+	if err := w.ask("Language", withDefault(a.Language, "go"), w.languages, func(v string) error {
+		a.Language = v
+		return nil
+	}, "language."); err != nil {
+		return err
+	}
+
+	defaultVersion := (&config.LanguageConfig{Type: a.Language}).GetVersion()
+	supported := config.SupportedLanguageVersions(a.Language)
+	question := "Language version"
+	if len(supported) > 0 {
+		question = fmt.Sprintf("Language version (supported: %s)", strings.Join(supported, ", "))
+	}
+	return w.ask(question, withDefault(a.Version, defaultVersion), nil, func(v string) error {
+		// 与默认版本相同时不写入，保持配置最小
+		a.Version = v
+		if v == defaultVersion {
+			a.Version = ""
+		}
+		return nil
+	}, "language.")
+}
+
+func (w *Wizard) askPorts() error {
+	a := w.answers
+	def := FormatPorts(a.Ports)
+	if def == "" && w.interactive {
+		def = "http:8080"
+	}
+	return w.ask("Ports (name:port[/protocol], comma separated, '-' for none)", def, nil, func(v string) error {
+		if v == "-" {
+			a.Ports = nil
+			return nil
+		}
+		ports, err := ParsePorts(v)
+		if err != nil {
+			return err
+		}
+		a.Ports = ports
+		return nil
+	}, "service.ports")
+}
+
+func (w *Wizard) askImages() error {
+	img := &w.answers.Image
+	if err := w.ask("Image format", withDefault(img.Format, ImageFormatAuto), ImageFormats, func(v string) error {
+		img.Format = v
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	lang := &config.LanguageConfig{Type: w.answers.Language, Version: w.answers.Version}
+	defaultBuilder, _ := config.DefaultBuilderImage(lang.Type, lang)
+	defaultRuntime, _ := config.DefaultRuntimeImage(lang.Type, lang)
+	imagePrefixes := []string{"base_images", "build.builder_image", "build.runtime_image"}
+
+	switch img.Format {
+	case ImageFormatDirect:
+		return w.askAll(
+			question{"Builder image", withDefault(img.Builder, defaultBuilder), nil, setString(&img.Builder), imagePrefixes},
+			question{"Runtime image", withDefault(img.Runtime, defaultRuntime), nil, setString(&img.Runtime), imagePrefixes},
+		)
+
+	case ImageFormatPerArch:
+		if err := w.askAll(
+			question{"Builder image (amd64)", withDefault(img.BuilderAMD64, defaultBuilder), nil, setString(&img.BuilderAMD64), nil},
+			question{"Builder image (arm64)", withDefault(img.BuilderARM64, withDefault(img.BuilderAMD64, defaultBuilder)), nil, setString(&img.BuilderARM64), nil},
+			question{"Runtime image (amd64)", withDefault(img.RuntimeAMD64, defaultRuntime), nil, setString(&img.RuntimeAMD64), nil},
+		); err != nil {
+			return err
+		}
+		return w.ask("Runtime image (arm64)", withDefault(img.RuntimeARM64, img.RuntimeAMD64), nil, setString(&img.RuntimeARM64), imagePrefixes...)
+
+	case ImageFormatPreset:
+		catalogs := config.ListEmbeddedCatalogs()
+		var catalog *config.ImageCatalog
+		if err := w.ask("Image catalog", withDefault(img.Catalog, firstOf(catalogs)), nil, func(v string) error {
+			c, err := config.LoadImageCatalog(v, ".")
+			if err != nil {
+				return err
+			}
+			img.Catalog, catalog = v, c
+			return nil
+		}); err != nil {
+			return err
+		}
+		builders := catalogPresetNames(catalog.Namespace, catalog.Builders)
+		runtimes := catalogPresetNames(catalog.Namespace, catalog.Runtimes)
+		return w.askAll(
+			question{"Builder preset", withDefault(img.Builder, firstOf(builders)), builders, setString(&img.Builder), nil},
+			question{"Runtime preset", withDefault(img.Runtime, firstOf(runtimes)), runtimes, setString(&img.Runtime), imagePrefixes},
+		)
+	}
+	return w.check(imagePrefixes)
+}
+
+func (w *Wizard) askPlugins() error {
+	a := w.answers
+	for i := range a.Plugins {
+		if a.Plugins[i].InstallCommand == "" {
+			a.Plugins[i].InstallCommand = DefaultPluginInstallCommand
+		}
+	}
+	if !w.interactive {
+		return w.check([]string{"plugins."})
+	}
+
+	for {
+		add := "n"
+		if err := w.ask("Add a plugin? (y/n)", add, []string{"y", "n"}, setString(&add)); err != nil {
+			return err
+		}
+		if add != "y" {
+			return nil
+		}
+
+		a.Plugins = append(a.Plugins, PluginAnswers{InstallCommand: DefaultPluginInstallCommand})
+		plugin := &a.Plugins[len(a.Plugins)-1]
+		prefix := fmt.Sprintf("plugins.items[%d]", len(a.Plugins)-1)
+		if err := w.askAll(
+			question{"  Plugin name", "", nil, setString(&plugin.Name), []string{prefix + ".name"}},
+			question{"  Download URL", "", nil, setString(&plugin.DownloadURL), []string{prefix + ".download_url"}},
+			question{"  Install command", plugin.InstallCommand, nil, setString(&plugin.InstallCommand), []string{prefix}},
+		); err != nil {
+			return err
+		}
+	}
+}
+
+func (w *Wizard) askHealthcheck() error {
+	hc := &w.answers.Healthcheck
+	if err := w.ask("Healthcheck type", withDefault(hc.Type, config.HealthcheckTypeDefault), HealthcheckTypes, setString(&hc.Type), "runtime.healthcheck"); err != nil {
+		return err
+	}
+
+	switch hc.Type {
+	case config.HealthcheckTypeHTTP:
+		return w.ask("Healthcheck path", withDefault(hc.Path, "/health"), nil, setString(&hc.Path), "runtime.healthcheck")
+	case config.HealthcheckTypeBinary:
+		return w.ask("Healthcheck binary", hc.Binary, nil, setString(&hc.Binary), "runtime.healthcheck")
+	}
+	return nil
+}
+
+func (w *Wizard) askKubernetes() error {
+	k8s := &w.answers.Kubernetes
+	enabled := "n"
+	if k8s.Enabled {
+		enabled = "y"
+	}
+	if err := w.ask("Enable Kubernetes manifests for local development? (y/n)", enabled, []string{"y", "n"}, func(v string) error {
+		k8s.Enabled = v == "y"
+		return nil
+	}); err != nil {
+		return err
+	}
+	if !k8s.Enabled {
+		return nil
+	}
+	return w.ask("Kubernetes namespace", withDefault(k8s.Namespace, "default"), nil, setString(&k8s.Namespace), "local_dev.kubernetes")
+}
+
+// ============================================
+// 提问与校验
+// ============================================
+
+// question 一个问题：apply 将答案写入 Answers，prefixes 为需要检查的校验错误前缀
+type question struct {
+	text     string
+	def      string
+	choices  []string
+	apply    func(string) error
+	prefixes []string
+}
+
+func (w *Wizard) askAll(questions ...question) error {
+	for _, q := range questions {
+		if err := w.ask(q.text, q.def, q.choices, q.apply, q.prefixes...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ask 提问并写入答案；空输入使用默认值
+// 非交互模式下直接使用默认值（即预填答案），校验失败返回错误
+// 交互模式下输入提前结束（如管道输入不完整）时，剩余问题转为非交互模式
+func (w *Wizard) ask(text, def string, choices []string, apply func(string) error, prefixes ...string) error {
+	for {
+		value := def
+		if w.interactive {
+			line, err := w.prompt(text, def, choices)
+			switch {
+			case err == io.EOF:
+				fmt.Fprintln(w.out)
+				w.interactive = false
+			case err != nil:
+				return err
+			case line != "":
+				value = line
+			}
+		}
+
+		err := w.accept(value, choices, apply, prefixes)
+		if err == nil {
+			return nil
+		}
+		if !w.interactive {
+			return fmt.Errorf("%s: %w", strings.TrimSpace(text), err)
+		}
+		fmt.Fprintf(w.out, "  ✗ %v\n", err)
+	}
+}
+
+func (w *Wizard) accept(value string, choices []string, apply func(string) error, prefixes []string) error {
+	if len(choices) > 0 && !contains(choices, value) {
+		return fmt.Errorf("'%s' is not valid (choose from: %s)", value, strings.Join(choices, ", "))
+	}
+	if err := apply(value); err != nil {
+		return err
+	}
+	return w.check(prefixes)
+}
+
+// check 对当前答案运行校验，只报告与 prefixes 相关的错误
+func (w *Wizard) check(prefixes []string) error {
+	if len(prefixes) == 0 {
+		return nil
+	}
+	if errs := w.validate(prefixes...); len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// validate 使用 config.Validator 校验当前答案；prefixes 为空时返回全部错误
+func (w *Wizard) validate(prefixes ...string) []string {
+	cfg, err := w.answers.Config()
+	if err != nil {
+		return filterErrors([]string{err.Error()}, prefixes)
+	}
+
+	validator := config.NewValidator(cfg)
+	_ = validator.Validate()
+	return filterErrors(validator.Errors(), prefixes)
+}
+
+func (w *Wizard) prompt(text, def string, choices []string) (string, error) {
+	fmt.Fprintf(w.out, "? %s", text)
+	if len(choices) > 0 && !strings.HasSuffix(text, ")") {
+		fmt.Fprintf(w.out, " (%s)", strings.Join(choices, "/"))
+	}
+	if def != "" {
+		fmt.Fprintf(w.out, " [%s]", def)
+	}
+	fmt.Fprint(w.out, ": ")
+
+	line, err := w.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimSpace(line), err
+}
+
+func filterErrors(errs []string, prefixes []string) []string {
+	if len(prefixes) == 0 {
+		return errs
+	}
+	var matched []string
+	for _, e := range errs {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(e, prefix) {
+				matched = append(matched, e)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+func catalogPresetNames(namespace string, presets map[string]config.ArchImageConfig) []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, namespace+"/"+name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func setString(dst *string) func(string) error {
+	return func(v string) error {
+		*dst = v
+		return nil
+	}
+}
+
+func withDefault(value, def string) string {
+	if value != "" {
+		return value
+	}
+	return def
+}
+
+func firstOf(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func contains(values []string, v string) bool {
+	for _, item := range values {
+		if item == v {
+			return true
+		}
+	}
+	return false
+}
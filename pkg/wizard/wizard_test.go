@@ -0,0 +1,124 @@
+package wizard
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/junjiewwang/service-template/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testLanguages = []string{"go", "java", "nodejs", "python", "rust"}
+
+func runInteractive(t *testing.T, input string, answers *Answers) (*Answers, string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	result, err := New(strings.NewReader(input), &out).
+		WithLanguages(testLanguages).
+		WithAnswers(answers).
+		Run()
+	return result, out.String(), err
+}
+
+func TestWizard_Interactive(t *testing.T) {
+	input := strings.Join([]string{
+		"orders",                        // service name
+		"Order service",                 // description
+		"rust",                          // language
+		"1.80",                          // version
+		"http:8080,grpc:9000",           // ports
+		"direct",                        // image format
+		"",                              // builder image (default)
+		"gcr.io/distroless/cc-debian12", // runtime image
+		"y",                             // add plugin
+		"agent",                         // plugin name
+		"https://example.com/agent.tar.gz",
+		"",  // install command (default)
+		"n", // no more plugins
+		"tcp",
+		"y",
+		"dev",
+	}, "\n") + "\n"
+
+	result, out, err := runInteractive(t, input, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "? Language (go/java/nodejs/python/rust) [go]: ")
+	assert.Equal(t, "orders", result.Name)
+	assert.Equal(t, "rust", result.Language)
+	assert.Equal(t, "1.80", result.Version)
+	assert.Len(t, result.Ports, 2)
+	assert.Equal(t, "rust:1.80-alpine", result.Image.Builder)
+	assert.Equal(t, "gcr.io/distroless/cc-debian12", result.Image.Runtime)
+	require.Len(t, result.Plugins, 1)
+	assert.Equal(t, DefaultPluginInstallCommand, result.Plugins[0].InstallCommand)
+	assert.Equal(t, config.HealthcheckTypeTCP, result.Healthcheck.Type)
+	assert.True(t, result.Kubernetes.Enabled)
+	assert.Equal(t, "dev", result.Kubernetes.Namespace)
+}
+
+func TestWizard_RetriesInvalidAnswers(t *testing.T) {
+	input := strings.Join([]string{
+		"demo", "",
+		"cobol", "go", // 不在语言列表中
+		"go1.22", "1.22", // 版本格式错误
+		"http:99999", "http:8080", // 端口超出范围
+		"", "", "", "",
+	}, "\n") + "\n"
+
+	result, out, err := runInteractive(t, input, nil)
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "✗ 'cobol' is not valid (choose from: go, java, nodejs, python, rust)")
+	assert.Contains(t, out, "✗ language.version 'go1.22' is not a valid go version")
+	assert.Contains(t, out, "✗ service.ports[0].port must be between 1 and 65535")
+	assert.Equal(t, "1.22", result.Version)
+	assert.Equal(t, 8080, result.Ports[0].Port)
+}
+
+func TestWizard_EndOfInputUsesDefaults(t *testing.T) {
+	result, _, err := runInteractive(t, "svc\n", &Answers{Language: "python"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "svc", result.Name)
+	assert.Equal(t, "python", result.Language)
+	assert.Empty(t, result.Version, "default version is not written")
+	assert.Equal(t, ImageFormatAuto, result.Image.Format)
+	assert.Equal(t, config.HealthcheckTypeDefault, result.Healthcheck.Type)
+}
+
+func TestWizard_NonInteractive(t *testing.T) {
+	answers := &Answers{
+		Name:     "billing",
+		Language: "java",
+		Image: ImageAnswers{
+			Format:  ImageFormatPreset,
+			Catalog: "embedded:org",
+			Builder: "org/go_1.23",
+			Runtime: "org/alpine_3.18",
+		},
+		Plugins: []PluginAnswers{{Name: "agent", DownloadURL: "https://example.com/agent.tar.gz"}},
+	}
+
+	var out bytes.Buffer
+	result, err := New(strings.NewReader(""), &out).WithInteractive(false).WithAnswers(answers).Run()
+	require.NoError(t, err)
+	assert.Empty(t, out.String(), "non-interactive mode does not prompt")
+	assert.Equal(t, DefaultPluginInstallCommand, result.Plugins[0].InstallCommand)
+
+	answers.Image.Runtime = "org/missing"
+	_, err = New(strings.NewReader(""), &out).WithInteractive(false).WithAnswers(answers).Run()
+	assert.ErrorContains(t, err, "Runtime preset: 'org/missing' is not valid")
+
+	_, err = New(strings.NewReader(""), &out).WithInteractive(false).WithAnswers(&Answers{Language: "go"}).Run()
+	assert.ErrorContains(t, err, "service name is required")
+
+	_, err = New(strings.NewReader(""), &out).WithInteractive(false).WithAnswers(&Answers{
+		Name:        "demo",
+		Language:    "go",
+		Healthcheck: HealthcheckAnswers{Type: config.HealthcheckTypeHTTP},
+	}).Run()
+	assert.ErrorContains(t, err, "runtime.healthcheck.port is required when type is 'http'")
+}
@@ -964,3 +964,72 @@ func TestScenario_DemoApp(t *testing.T) {
 	lines := strings.Split(dockerfile, "\n")
 	assert.True(t, len(lines) > 10, "Dockerfile should have substantial content")
 }
+
+// ============================================
+// 场景: 仅列出依赖文件（未设置 auto_detect）
+// 列出的文件必须原样进入 Dockerfile，不能被自动检测覆盖
+// ============================================
+func TestScenario_ListedDependencyFiles(t *testing.T) {
+	yaml := `
+service:
+  name: deps-api
+  ports:
+  - name: http
+    port: 8080
+    protocol: TCP
+
+language:
+  type: go
+
+build:
+  dependency_files:
+    files:
+    - go.mod
+    - go.sum
+    - third_party/
+
+runtime:
+  startup:
+    command: ./bin/deps-api
+`
+	outputDir, cfg := helperLoadAndGenerate(t, yaml)
+	assert.False(t, cfg.Build.DependencyFiles.AutoDetect, "listed files should not default auto_detect to true")
+
+	dockerfile := helperReadFile(t, outputDir, ".tad/build/deps-api/Dockerfile.deps-api.amd64")
+	for _, file := range []string{"go.mod", "go.sum", "third_party/"} {
+		assert.Contains(t, dockerfile, "COPY "+file+" ./")
+	}
+}
+
+// TestScenario_MakeBasedBuild 构建命令执行 make 时，Makefile 必须留在 Docker 构建上下文中
+func TestScenario_MakeBasedBuild(t *testing.T) {
+	yaml := `
+service:
+  name: make-api
+  ports:
+  - name: http
+    port: 8080
+    protocol: TCP
+
+language:
+  type: go
+
+build:
+  commands:
+    build: make build
+
+runtime:
+  startup:
+    command: ./bin/make-api
+`
+	outputDir, _ := helperLoadAndGenerate(t, yaml)
+
+	// build.sh 在 builder stage 中执行（COPY . /opt/ 之后）
+	buildScript := helperReadFile(t, outputDir, ".tad/build/make-api/build.sh")
+	assert.Contains(t, buildScript, "make build")
+
+	dockerignore := helperReadFile(t, outputDir, ".dockerignore")
+	for _, line := range strings.Split(dockerignore, "\n") {
+		assert.NotEqual(t, "Makefile", strings.TrimSpace(line), ".dockerignore must not exclude the Makefile")
+	}
+}